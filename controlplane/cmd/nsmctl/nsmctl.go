@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmctl"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+	"github.com/sirupsen/logrus"
+)
+
+func main() {
+	config := &nsmctl.Config{}
+	flag.StringVar(&config.NsmServerSocket, "socket", nsmctl.DefaultNsmServerSocket, "path of NSM server socket NSMD admin API is served on")
+	flag.StringVar(&config.PublicAPI, "api", envOrDefault("NSMD_API_ADDRESS", nsmctl.DefaultPublicAPI), "NSMD public API address")
+	flag.StringVar(&config.Registry, "registry", envOrDefault("NSM_REGISTRY_ADDRESS", nsmctl.DefaultRegistry), "nsmd-k8s registry address")
+	flag.StringVar(&config.Output, "o", nsmctl.OutputTable, "output format: table, json or yaml")
+	flag.DurationVar(&config.Timeout, "timeout", nsmctl.DefaultTimeout, "time to wait for response")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags] <command> [args]\n\n%s\n\nFlags:\n", os.Args[0], nsmctl.Usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	// Keep output clean from gRPC and TLS logs, errors are reported by nsmctl itself.
+	logrus.SetLevel(logrus.WarnLevel)
+
+	securityProvider, err := security.NewProviderFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid TLS configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-c
+		cancel()
+	}()
+
+	if err := nsmctl.Run(ctx, nsmctl.NewClient(config, securityProvider), os.Stdout, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func envOrDefault(env, defaultValue string) string {
+	if value := strings.TrimSpace(os.Getenv(env)); value != "" {
+		return value
+	}
+	return defaultValue
+}
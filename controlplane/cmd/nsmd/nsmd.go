@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
-	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsm"
+	nsm_impl "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
@@ -29,13 +33,33 @@ func main() {
 	opentracing.SetGlobalTracer(tracer)
 	defer closer.Close()
 
+	// Configuration is validated before anything is started, environment variables override values of file.
+	configWatcher, err := nsmd.NewConfigWatcher(os.Getenv(nsmd.ConfigFileEnv))
+	if err != nil {
+		logrus.Fatalf("Error loading nsmd configuration: %v", err)
+	}
+
 	go nsmd.BeginHealthCheck()
 
 	apiRegistry := nsmd.NewApiRegistry()
-	serviceRegistry := nsmd.NewServiceRegistry()
+
+	// Load connections persisted before restart, they will be restored once all servers are started.
+	persistence := model.NewFilePersistence(nsmd.GetNsmBaseDir())
+	restored, err := persistence.Load()
+	if err != nil {
+		logrus.Errorf("Failed to load persisted model, starting with empty one: %v", err)
+	}
+	persistenceListener := model.NewPersistenceListener(persistence, restored...)
 
 	model := model.NewModel() // This is TCP gRPC server uri to access this NSMD via network.
+	model.AddListener(persistenceListener)
+	model.AddListener(metrics.NewActiveConnectionsListener())
 
+	vniAllocator, err := nsmd.NewVniAllocator(model)
+	if err != nil {
+		logrus.Fatalf("Error creating VNI allocator: %+v", err)
+	}
+	serviceRegistry := nsmd.NewServiceRegistryWithVniAllocator(vniAllocator)
 	defer serviceRegistry.Stop()
 
 	if err := nsmd.StartDataplaneRegistrarServer(model); err != nil {
@@ -43,20 +67,58 @@ func main() {
 		nsmd.SetDPServerFailed()
 	}
 
-	manager := nsm.NewNetworkServiceManager(model, serviceRegistry, nsmd.GetExcludedPrefixes())
+	journal := nsmd.NewConnectionJournal(model)
+	defer journal.Close()
+	policyEngine := nsmd.NewPolicyEngine(journal)
+	manager := nsm_impl.NewNetworkServiceManager(model, serviceRegistry, nsmd.GetExcludedPrefixes(), nsmd.GetConnectionLease(), journal)
+	manager.UpdateSettings(configWatcher.Config().ManagerSettings())
 
-	if err := nsmd.StartNSMServer(model, manager, serviceRegistry, apiRegistry); err != nil {
+	if err := nsmd.StartNSMServer(model, manager, serviceRegistry, apiRegistry, policyEngine, journal); err != nil {
 		logrus.Fatalf("Error starting nsmd service: %+v", err)
 		nsmd.SetNSMServerFailed()
 	}
 
-	if err := nsmd.StartAPIServer(model, manager, apiRegistry, serviceRegistry); err != nil {
+	if err := nsmd.StartAPIServer(model, manager, apiRegistry, serviceRegistry, policyEngine); err != nil {
 		logrus.Fatalf("Error starting nsmd api service: %+v", err)
 		nsmd.SetAPIServerFailed()
 	}
 
+	configWatcher.AddListener(func(config *nsmd.Config) {
+		manager.UpdateSettings(config.ManagerSettings())
+		policyEngine.SetPolicy(config.Policy())
+	})
+
+	restoredConnections := []nsm.NSMClientConnection{}
+	for _, cc := range restored {
+		restoredConnections = append(restoredConnections, cc)
+	}
+	manager.RestoreConnections(restoredConnections)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Close connections not refreshed by their sources, since they are most probable dead, and refresh connections
+	// to remote NSMs.
+	nsmd.StartConnectionReaper(ctx, manager)
+
+	// Exclude pod and service CIDRs of cluster discovered by registry, in addition to configured prefixes.
+	nsmd.StartClusterPrefixesMonitor(ctx, serviceRegistry, manager)
+
+	// Check health of local endpoints, so connections to dead ones are healed before clients notice.
+	xconManager := services.NewClientConnectionManager(model, manager, serviceRegistry)
+	nsmd.NewEndpointHealthMonitor(model, serviceRegistry, xconManager).Start(ctx, nsmd.GetEndpointProbeInterval())
+
+	// Reload configuration once it is changed, so settings are applied without restart.
+	configWatcher.Start(ctx, nsmd.ConfigCheckInterval)
+
 	elapsed := time.Since(start)
 	logrus.Debugf("Starting NSMD took: %s", elapsed)
 
-	<-c
+	for sig := range c {
+		if sig != syscall.SIGHUP {
+			return
+		}
+		logrus.Infof("Reloading configuration on %v", sig)
+		_ = configWatcher.Reload()
+	}
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain/proxy"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+const (
+	ProxyNsmdAddressEnv     = "PROXY_NSMD_ADDRESS"
+	DefaultProxyNsmdAddress = "0.0.0.0:5005"
+)
+
+func main() {
+	// Capture signals to cleanup before exiting
+	c := make(chan os.Signal, 1)
+	signal.Notify(c,
+		syscall.SIGHUP,
+		syscall.SIGINT,
+		syscall.SIGTERM,
+		syscall.SIGQUIT)
+
+	tracer, closer := tools.InitJaeger("proxy-nsmd")
+	opentracing.SetGlobalTracer(tracer)
+	defer closer.Close()
+
+	address := os.Getenv(ProxyNsmdAddressEnv)
+	if address == "" {
+		address = DefaultProxyNsmdAddress
+	}
+
+	serviceRegistry := nsmd.NewServiceRegistry()
+	defer serviceRegistry.Stop()
+
+	sock, err := net.Listen("tcp", address)
+	if err != nil {
+		logrus.Fatalf("Error listening on %s: %+v", address, err)
+	}
+	grpcServer := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.UnaryInterceptor(
+			otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads())),
+		grpc.StreamInterceptor(
+			otgrpc.OpenTracingStreamServerInterceptor(tracer))},
+		serviceRegistry.SecurityProvider().ServerOptions()...)...)
+
+	proxyServer := proxy.NewProxyNetworkServiceServer(serviceRegistry)
+	remote_networkservice.RegisterNetworkServiceServer(grpcServer, proxyServer)
+	remote_connection.RegisterMonitorConnectionServer(grpcServer, proxyServer)
+
+	go func() {
+		if err := grpcServer.Serve(sock); err != nil {
+			logrus.Errorf("failed to start gRPC Proxy NSMD server %+v", err)
+		}
+	}()
+	logrus.Infof("Proxy NSMD gRPC server: %s is operational", sock.Addr().String())
+
+	<-c
+}
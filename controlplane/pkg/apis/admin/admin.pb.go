@@ -0,0 +1,832 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin.proto
+
+package admin
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	empty "github.com/golang/protobuf/ptypes/empty"
+	crossconnect "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ClientConnectionInfo - a client connection known by NSM.
+type ClientConnectionInfo struct {
+	ConnectionId   string `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	NetworkService string `protobuf:"bytes,2,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	State          string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Endpoint       string `protobuf:"bytes,4,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Dataplane      string `protobuf:"bytes,5,opt,name=dataplane,proto3" json:"dataplane,omitempty"`
+	// remote_nsm - NSM of remote endpoint, empty for local endpoints.
+	RemoteNsm string `protobuf:"bytes,6,opt,name=remote_nsm,json=remoteNsm,proto3" json:"remote_nsm,omitempty"`
+	// workspace - workspace of local client, empty for connections requested by remote NSMs.
+	Workspace            string                     `protobuf:"bytes,7,opt,name=workspace,proto3" json:"workspace,omitempty"`
+	RemoteNsmUrl         string                     `protobuf:"bytes,8,opt,name=remote_nsm_url,json=remoteNsmUrl,proto3" json:"remote_nsm_url,omitempty"`
+	Xcon                 *crossconnect.CrossConnect `protobuf:"bytes,9,opt,name=xcon,proto3" json:"xcon,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *ClientConnectionInfo) Reset()         { *m = ClientConnectionInfo{} }
+func (m *ClientConnectionInfo) String() string { return proto.CompactTextString(m) }
+func (*ClientConnectionInfo) ProtoMessage()    {}
+func (*ClientConnectionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{0}
+}
+
+func (m *ClientConnectionInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientConnectionInfo.Unmarshal(m, b)
+}
+func (m *ClientConnectionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientConnectionInfo.Marshal(b, m, deterministic)
+}
+func (m *ClientConnectionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientConnectionInfo.Merge(m, src)
+}
+func (m *ClientConnectionInfo) XXX_Size() int {
+	return xxx_messageInfo_ClientConnectionInfo.Size(m)
+}
+func (m *ClientConnectionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientConnectionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientConnectionInfo proto.InternalMessageInfo
+
+func (m *ClientConnectionInfo) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+func (m *ClientConnectionInfo) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
+	}
+	return ""
+}
+
+func (m *ClientConnectionInfo) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *ClientConnectionInfo) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *ClientConnectionInfo) GetDataplane() string {
+	if m != nil {
+		return m.Dataplane
+	}
+	return ""
+}
+
+func (m *ClientConnectionInfo) GetRemoteNsm() string {
+	if m != nil {
+		return m.RemoteNsm
+	}
+	return ""
+}
+
+func (m *ClientConnectionInfo) GetWorkspace() string {
+	if m != nil {
+		return m.Workspace
+	}
+	return ""
+}
+
+func (m *ClientConnectionInfo) GetRemoteNsmUrl() string {
+	if m != nil {
+		return m.RemoteNsmUrl
+	}
+	return ""
+}
+
+func (m *ClientConnectionInfo) GetXcon() *crossconnect.CrossConnect {
+	if m != nil {
+		return m.Xcon
+	}
+	return nil
+}
+
+type ClientConnections struct {
+	Connections          []*ClientConnectionInfo `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ClientConnections) Reset()         { *m = ClientConnections{} }
+func (m *ClientConnections) String() string { return proto.CompactTextString(m) }
+func (*ClientConnections) ProtoMessage()    {}
+func (*ClientConnections) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{1}
+}
+
+func (m *ClientConnections) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientConnections.Unmarshal(m, b)
+}
+func (m *ClientConnections) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientConnections.Marshal(b, m, deterministic)
+}
+func (m *ClientConnections) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientConnections.Merge(m, src)
+}
+func (m *ClientConnections) XXX_Size() int {
+	return xxx_messageInfo_ClientConnections.Size(m)
+}
+func (m *ClientConnections) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientConnections.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientConnections proto.InternalMessageInfo
+
+func (m *ClientConnections) GetConnections() []*ClientConnectionInfo {
+	if m != nil {
+		return m.Connections
+	}
+	return nil
+}
+
+// EndpointInfo - a Network Service Endpoint known by NSM.
+type EndpointInfo struct {
+	Name                  string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	NetworkService        string            `protobuf:"bytes,2,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
+	NetworkServiceManager string            `protobuf:"bytes,3,opt,name=network_service_manager,json=networkServiceManager,proto3" json:"network_service_manager,omitempty"`
+	Labels                map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral  struct{}          `json:"-"`
+	XXX_unrecognized      []byte            `json:"-"`
+	XXX_sizecache         int32             `json:"-"`
+}
+
+func (m *EndpointInfo) Reset()         { *m = EndpointInfo{} }
+func (m *EndpointInfo) String() string { return proto.CompactTextString(m) }
+func (*EndpointInfo) ProtoMessage()    {}
+func (*EndpointInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2}
+}
+
+func (m *EndpointInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EndpointInfo.Unmarshal(m, b)
+}
+func (m *EndpointInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EndpointInfo.Marshal(b, m, deterministic)
+}
+func (m *EndpointInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EndpointInfo.Merge(m, src)
+}
+func (m *EndpointInfo) XXX_Size() int {
+	return xxx_messageInfo_EndpointInfo.Size(m)
+}
+func (m *EndpointInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_EndpointInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EndpointInfo proto.InternalMessageInfo
+
+func (m *EndpointInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EndpointInfo) GetNetworkService() string {
+	if m != nil {
+		return m.NetworkService
+	}
+	return ""
+}
+
+func (m *EndpointInfo) GetNetworkServiceManager() string {
+	if m != nil {
+		return m.NetworkServiceManager
+	}
+	return ""
+}
+
+func (m *EndpointInfo) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type Endpoints struct {
+	Endpoints            []*EndpointInfo `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *Endpoints) Reset()         { *m = Endpoints{} }
+func (m *Endpoints) String() string { return proto.CompactTextString(m) }
+func (*Endpoints) ProtoMessage()    {}
+func (*Endpoints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{3}
+}
+
+func (m *Endpoints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Endpoints.Unmarshal(m, b)
+}
+func (m *Endpoints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Endpoints.Marshal(b, m, deterministic)
+}
+func (m *Endpoints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Endpoints.Merge(m, src)
+}
+func (m *Endpoints) XXX_Size() int {
+	return xxx_messageInfo_Endpoints.Size(m)
+}
+func (m *Endpoints) XXX_DiscardUnknown() {
+	xxx_messageInfo_Endpoints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Endpoints proto.InternalMessageInfo
+
+func (m *Endpoints) GetEndpoints() []*EndpointInfo {
+	if m != nil {
+		return m.Endpoints
+	}
+	return nil
+}
+
+// DataplaneInfo - a dataplane registered in NSM with types of mechanisms it supports.
+type DataplaneInfo struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SocketLocation       string   `protobuf:"bytes,2,opt,name=socket_location,json=socketLocation,proto3" json:"socket_location,omitempty"`
+	LocalMechanisms      []string `protobuf:"bytes,3,rep,name=local_mechanisms,json=localMechanisms,proto3" json:"local_mechanisms,omitempty"`
+	RemoteMechanisms     []string `protobuf:"bytes,4,rep,name=remote_mechanisms,json=remoteMechanisms,proto3" json:"remote_mechanisms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DataplaneInfo) Reset()         { *m = DataplaneInfo{} }
+func (m *DataplaneInfo) String() string { return proto.CompactTextString(m) }
+func (*DataplaneInfo) ProtoMessage()    {}
+func (*DataplaneInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{4}
+}
+
+func (m *DataplaneInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DataplaneInfo.Unmarshal(m, b)
+}
+func (m *DataplaneInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DataplaneInfo.Marshal(b, m, deterministic)
+}
+func (m *DataplaneInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataplaneInfo.Merge(m, src)
+}
+func (m *DataplaneInfo) XXX_Size() int {
+	return xxx_messageInfo_DataplaneInfo.Size(m)
+}
+func (m *DataplaneInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataplaneInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataplaneInfo proto.InternalMessageInfo
+
+func (m *DataplaneInfo) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DataplaneInfo) GetSocketLocation() string {
+	if m != nil {
+		return m.SocketLocation
+	}
+	return ""
+}
+
+func (m *DataplaneInfo) GetLocalMechanisms() []string {
+	if m != nil {
+		return m.LocalMechanisms
+	}
+	return nil
+}
+
+func (m *DataplaneInfo) GetRemoteMechanisms() []string {
+	if m != nil {
+		return m.RemoteMechanisms
+	}
+	return nil
+}
+
+type Dataplanes struct {
+	Dataplanes           []*DataplaneInfo `protobuf:"bytes,1,rep,name=dataplanes,proto3" json:"dataplanes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Dataplanes) Reset()         { *m = Dataplanes{} }
+func (m *Dataplanes) String() string { return proto.CompactTextString(m) }
+func (*Dataplanes) ProtoMessage()    {}
+func (*Dataplanes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{5}
+}
+
+func (m *Dataplanes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Dataplanes.Unmarshal(m, b)
+}
+func (m *Dataplanes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Dataplanes.Marshal(b, m, deterministic)
+}
+func (m *Dataplanes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Dataplanes.Merge(m, src)
+}
+func (m *Dataplanes) XXX_Size() int {
+	return xxx_messageInfo_Dataplanes.Size(m)
+}
+func (m *Dataplanes) XXX_DiscardUnknown() {
+	xxx_messageInfo_Dataplanes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Dataplanes proto.InternalMessageInfo
+
+func (m *Dataplanes) GetDataplanes() []*DataplaneInfo {
+	if m != nil {
+		return m.Dataplanes
+	}
+	return nil
+}
+
+type Workspaces struct {
+	Workspaces           []string `protobuf:"bytes,1,rep,name=workspaces,proto3" json:"workspaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Workspaces) Reset()         { *m = Workspaces{} }
+func (m *Workspaces) String() string { return proto.CompactTextString(m) }
+func (*Workspaces) ProtoMessage()    {}
+func (*Workspaces) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{6}
+}
+
+func (m *Workspaces) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Workspaces.Unmarshal(m, b)
+}
+func (m *Workspaces) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Workspaces.Marshal(b, m, deterministic)
+}
+func (m *Workspaces) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Workspaces.Merge(m, src)
+}
+func (m *Workspaces) XXX_Size() int {
+	return xxx_messageInfo_Workspaces.Size(m)
+}
+func (m *Workspaces) XXX_DiscardUnknown() {
+	xxx_messageInfo_Workspaces.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Workspaces proto.InternalMessageInfo
+
+func (m *Workspaces) GetWorkspaces() []string {
+	if m != nil {
+		return m.Workspaces
+	}
+	return nil
+}
+
+// ModelDump - JSON dump of NSM model.
+type ModelDump struct {
+	Json                 string   `protobuf:"bytes,1,opt,name=json,proto3" json:"json,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ModelDump) Reset()         { *m = ModelDump{} }
+func (m *ModelDump) String() string { return proto.CompactTextString(m) }
+func (*ModelDump) ProtoMessage()    {}
+func (*ModelDump) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{7}
+}
+
+func (m *ModelDump) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ModelDump.Unmarshal(m, b)
+}
+func (m *ModelDump) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ModelDump.Marshal(b, m, deterministic)
+}
+func (m *ModelDump) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModelDump.Merge(m, src)
+}
+func (m *ModelDump) XXX_Size() int {
+	return xxx_messageInfo_ModelDump.Size(m)
+}
+func (m *ModelDump) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModelDump.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModelDump proto.InternalMessageInfo
+
+func (m *ModelDump) GetJson() string {
+	if m != nil {
+		return m.Json
+	}
+	return ""
+}
+
+type ConnectionId struct {
+	ConnectionId         string   `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConnectionId) Reset()         { *m = ConnectionId{} }
+func (m *ConnectionId) String() string { return proto.CompactTextString(m) }
+func (*ConnectionId) ProtoMessage()    {}
+func (*ConnectionId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{8}
+}
+
+func (m *ConnectionId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConnectionId.Unmarshal(m, b)
+}
+func (m *ConnectionId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConnectionId.Marshal(b, m, deterministic)
+}
+func (m *ConnectionId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionId.Merge(m, src)
+}
+func (m *ConnectionId) XXX_Size() int {
+	return xxx_messageInfo_ConnectionId.Size(m)
+}
+func (m *ConnectionId) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConnectionId proto.InternalMessageInfo
+
+func (m *ConnectionId) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ClientConnectionInfo)(nil), "admin.ClientConnectionInfo")
+	proto.RegisterType((*ClientConnections)(nil), "admin.ClientConnections")
+	proto.RegisterType((*EndpointInfo)(nil), "admin.EndpointInfo")
+	proto.RegisterMapType((map[string]string)(nil), "admin.EndpointInfo.LabelsEntry")
+	proto.RegisterType((*Endpoints)(nil), "admin.Endpoints")
+	proto.RegisterType((*DataplaneInfo)(nil), "admin.DataplaneInfo")
+	proto.RegisterType((*Dataplanes)(nil), "admin.Dataplanes")
+	proto.RegisterType((*Workspaces)(nil), "admin.Workspaces")
+	proto.RegisterType((*ModelDump)(nil), "admin.ModelDump")
+	proto.RegisterType((*ConnectionId)(nil), "admin.ConnectionId")
+}
+
+func init() { proto.RegisterFile("admin.proto", fileDescriptor_73a7fc70dcc2027c) }
+
+var fileDescriptor_73a7fc70dcc2027c = []byte{
+	// 712 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xdd, 0x4e, 0xdb, 0x4c,
+	0x10, 0x55, 0x48, 0xe0, 0xc3, 0x93, 0x10, 0xc2, 0x7e, 0xb4, 0xb5, 0xd2, 0x1f, 0x22, 0xb7, 0x52,
+	0x53, 0xb5, 0x72, 0x54, 0xe8, 0x0f, 0x45, 0x02, 0xa9, 0x04, 0x44, 0x91, 0x08, 0x17, 0x46, 0x55,
+	0x2f, 0xad, 0x8d, 0xb3, 0x18, 0x37, 0xeb, 0x5d, 0xcb, 0xbb, 0xa1, 0xcd, 0xfb, 0xf4, 0x19, 0xfa,
+	0x0a, 0x7d, 0xa4, 0xde, 0x56, 0xde, 0xf5, 0x5f, 0x22, 0x52, 0x50, 0x6f, 0x22, 0xcf, 0x99, 0x33,
+	0x9b, 0x99, 0x33, 0x67, 0x17, 0xea, 0x78, 0x14, 0x06, 0xcc, 0x8e, 0x62, 0x2e, 0x39, 0x5a, 0x56,
+	0x41, 0x7b, 0xc7, 0x0f, 0xe4, 0xd5, 0x64, 0x68, 0x7b, 0x3c, 0xec, 0xf9, 0x9c, 0x62, 0xe6, 0xf7,
+	0x54, 0x7e, 0x38, 0xb9, 0xec, 0x45, 0x72, 0x1a, 0x11, 0xd1, 0x23, 0x61, 0x24, 0xa7, 0xfa, 0x57,
+	0xd7, 0xb6, 0x2f, 0x4b, 0x45, 0x8c, 0xc8, 0x6f, 0x3c, 0x1e, 0x0b, 0x12, 0x5f, 0x07, 0x1e, 0x09,
+	0x89, 0xb8, 0xba, 0x09, 0xf2, 0x38, 0x93, 0x31, 0xa7, 0x11, 0xc5, 0x8c, 0xf4, 0xa2, 0xb1, 0xdf,
+	0xc3, 0x51, 0x20, 0x7a, 0x5e, 0xcc, 0x85, 0xf0, 0x38, 0x63, 0xc4, 0x93, 0x33, 0x81, 0xfe, 0x1f,
+	0xeb, 0xd7, 0x12, 0x6c, 0xf6, 0x69, 0x40, 0x98, 0xec, 0x6b, 0x3c, 0xe0, 0xec, 0x94, 0x5d, 0x72,
+	0xf4, 0x14, 0xd6, 0xbc, 0x1c, 0x71, 0x83, 0x91, 0x59, 0xe9, 0x54, 0xba, 0x86, 0xd3, 0x28, 0xc0,
+	0xd3, 0x11, 0x7a, 0x0e, 0xeb, 0x69, 0x27, 0x6e, 0xda, 0x8a, 0xb9, 0xa4, 0x68, 0xcd, 0x14, 0xbe,
+	0xd0, 0x28, 0xda, 0x84, 0x65, 0x21, 0xb1, 0x24, 0x66, 0x55, 0xa5, 0x75, 0x80, 0xda, 0xb0, 0x4a,
+	0xd8, 0x28, 0xe2, 0x01, 0x93, 0x66, 0x4d, 0x25, 0xf2, 0x18, 0x3d, 0x02, 0x63, 0x84, 0x25, 0x56,
+	0xe3, 0x98, 0xcb, 0x2a, 0x59, 0x00, 0xe8, 0x31, 0x40, 0x4c, 0x42, 0x2e, 0x89, 0xcb, 0x44, 0x68,
+	0xae, 0xe8, 0xb4, 0x46, 0xce, 0x45, 0x98, 0x14, 0x2b, 0x79, 0x22, 0xec, 0x11, 0xf3, 0x3f, 0x9d,
+	0xcd, 0x01, 0xf4, 0x0c, 0x9a, 0x45, 0xb1, 0x3b, 0x89, 0xa9, 0xb9, 0xaa, 0x67, 0xcb, 0x0f, 0xf8,
+	0x1c, 0x53, 0x64, 0x43, 0xed, 0xbb, 0xc7, 0x99, 0x69, 0x74, 0x2a, 0xdd, 0xfa, 0x76, 0xdb, 0x9e,
+	0x11, 0xaf, 0x9f, 0x04, 0xa9, 0x62, 0x8e, 0xe2, 0x59, 0x0e, 0x6c, 0xcc, 0x0b, 0x29, 0xd0, 0x3e,
+	0xd4, 0x0b, 0xc1, 0x84, 0x59, 0xe9, 0x54, 0xbb, 0xf5, 0xed, 0x87, 0xb6, 0x76, 0xc9, 0x4d, 0xba,
+	0x3b, 0x65, 0xbe, 0xf5, 0xbb, 0x02, 0x8d, 0xe3, 0x54, 0x11, 0xb5, 0x15, 0x04, 0x35, 0x86, 0x43,
+	0x92, 0x2e, 0x43, 0x7d, 0xdf, 0x7d, 0x09, 0xef, 0xe0, 0xc1, 0x1c, 0xd1, 0x0d, 0x31, 0xc3, 0x3e,
+	0x89, 0xd3, 0xb5, 0xdc, 0x9b, 0x2d, 0x18, 0xe8, 0x24, 0x7a, 0x0f, 0x2b, 0x14, 0x0f, 0x09, 0x15,
+	0x66, 0x4d, 0xf5, 0xbf, 0x95, 0xf6, 0x5f, 0xee, 0xcc, 0x3e, 0x53, 0x8c, 0x63, 0x26, 0xe3, 0xa9,
+	0x93, 0xd2, 0xdb, 0x1f, 0xa0, 0x5e, 0x82, 0x51, 0x0b, 0xaa, 0x63, 0x32, 0x4d, 0x7b, 0x4f, 0x3e,
+	0x13, 0x5b, 0x5c, 0x63, 0x3a, 0xc9, 0x1a, 0xd6, 0xc1, 0xde, 0xd2, 0x6e, 0xc5, 0x3a, 0x00, 0x23,
+	0x3b, 0x5e, 0xa0, 0xd7, 0x60, 0x64, 0xbe, 0xc8, 0x34, 0xfc, 0xff, 0x86, 0x1e, 0x9c, 0x82, 0x65,
+	0xfd, 0xa8, 0xc0, 0xda, 0x51, 0x66, 0x97, 0xbf, 0x49, 0x27, 0xb8, 0x37, 0x26, 0xd2, 0xa5, 0xdc,
+	0xc3, 0x89, 0xe6, 0x99, 0x74, 0x1a, 0x3e, 0x4b, 0x51, 0xf4, 0x02, 0x5a, 0x09, 0x83, 0xba, 0x21,
+	0xf1, 0xae, 0x30, 0x0b, 0x44, 0x28, 0xcc, 0x6a, 0xa7, 0xda, 0x35, 0x9c, 0x75, 0x85, 0x0f, 0x72,
+	0x18, 0xbd, 0x84, 0x8d, 0xd4, 0x5d, 0x25, 0x6e, 0x4d, 0x71, 0x5b, 0x3a, 0x51, 0x90, 0xad, 0x43,
+	0x80, 0xbc, 0x4b, 0x81, 0xde, 0x00, 0xe4, 0x16, 0xcf, 0x06, 0xdd, 0x4c, 0x07, 0x9d, 0x19, 0xc6,
+	0x29, 0xf1, 0xac, 0x57, 0x00, 0x5f, 0x32, 0x6f, 0x0b, 0xf4, 0x04, 0x20, 0x77, 0xba, 0x3e, 0xc3,
+	0x70, 0x4a, 0x88, 0xb5, 0x05, 0xc6, 0x80, 0x8f, 0x08, 0x3d, 0x9a, 0x84, 0x51, 0xa2, 0xc9, 0x57,
+	0xc1, 0x59, 0xa6, 0x49, 0xf2, 0x6d, 0xed, 0x40, 0xa3, 0x5f, 0xbe, 0xe3, 0x77, 0x79, 0x08, 0xb6,
+	0x7f, 0x56, 0x61, 0xf5, 0xfc, 0x62, 0xf0, 0x31, 0x69, 0x15, 0x1d, 0x42, 0xf3, 0x84, 0xcc, 0x5c,
+	0x83, 0xfb, 0xb6, 0xcf, 0xb9, 0x4f, 0x89, 0x9d, 0x3d, 0x7c, 0xf6, 0x71, 0xf2, 0xd6, 0xb5, 0xcd,
+	0x05, 0x37, 0x41, 0xa0, 0x5d, 0x68, 0x9c, 0x10, 0x59, 0x58, 0x60, 0xd1, 0x09, 0xad, 0x39, 0x1f,
+	0x08, 0xb4, 0x07, 0x6b, 0x27, 0x44, 0x96, 0x54, 0x5d, 0x54, 0xba, 0x31, 0xaf, 0x6c, 0x56, 0x5b,
+	0x52, 0xf3, 0xb6, 0xda, 0x12, 0xf5, 0x2d, 0x18, 0x89, 0xa6, 0x4a, 0xdc, 0x5b, 0xdb, 0x2d, 0x56,
+	0x70, 0x00, 0xeb, 0x7d, 0xca, 0x05, 0x29, 0x86, 0x47, 0x99, 0xb7, 0xcb, 0x6b, 0x68, 0x2f, 0x38,
+	0x11, 0xed, 0x43, 0xf3, 0x13, 0xc1, 0xf4, 0x1f, 0xcb, 0x87, 0x2b, 0x2a, 0xde, 0xf9, 0x33, 0x00,
+	0xcd, 0xcb, 0xd7, 0xf0, 0xb9, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// NSMAdminClient is the client API for NSMAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NSMAdminClient interface {
+	GetConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClientConnections, error)
+	GetEndpoints(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Endpoints, error)
+	GetDataplanes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Dataplanes, error)
+	GetWorkspaces(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Workspaces, error)
+	DumpModel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ModelDump, error)
+	// CloseConnection - closes connection and releases all its resources.
+	CloseConnection(ctx context.Context, in *ConnectionId, opts ...grpc.CallOption) (*empty.Empty, error)
+	// HealConnection - re-requests connection as if its destination is down.
+	HealConnection(ctx context.Context, in *ConnectionId, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type nSMAdminClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNSMAdminClient(cc *grpc.ClientConn) NSMAdminClient {
+	return &nSMAdminClient{cc}
+}
+
+func (c *nSMAdminClient) GetConnections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClientConnections, error) {
+	out := new(ClientConnections)
+	err := c.cc.Invoke(ctx, "/admin.NSMAdmin/GetConnections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMAdminClient) GetEndpoints(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Endpoints, error) {
+	out := new(Endpoints)
+	err := c.cc.Invoke(ctx, "/admin.NSMAdmin/GetEndpoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMAdminClient) GetDataplanes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Dataplanes, error) {
+	out := new(Dataplanes)
+	err := c.cc.Invoke(ctx, "/admin.NSMAdmin/GetDataplanes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMAdminClient) GetWorkspaces(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Workspaces, error) {
+	out := new(Workspaces)
+	err := c.cc.Invoke(ctx, "/admin.NSMAdmin/GetWorkspaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMAdminClient) DumpModel(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ModelDump, error) {
+	out := new(ModelDump)
+	err := c.cc.Invoke(ctx, "/admin.NSMAdmin/DumpModel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMAdminClient) CloseConnection(ctx context.Context, in *ConnectionId, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/admin.NSMAdmin/CloseConnection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nSMAdminClient) HealConnection(ctx context.Context, in *ConnectionId, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/admin.NSMAdmin/HealConnection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NSMAdminServer is the server API for NSMAdmin service.
+type NSMAdminServer interface {
+	GetConnections(context.Context, *empty.Empty) (*ClientConnections, error)
+	GetEndpoints(context.Context, *empty.Empty) (*Endpoints, error)
+	GetDataplanes(context.Context, *empty.Empty) (*Dataplanes, error)
+	GetWorkspaces(context.Context, *empty.Empty) (*Workspaces, error)
+	DumpModel(context.Context, *empty.Empty) (*ModelDump, error)
+	// CloseConnection - closes connection and releases all its resources.
+	CloseConnection(context.Context, *ConnectionId) (*empty.Empty, error)
+	// HealConnection - re-requests connection as if its destination is down.
+	HealConnection(context.Context, *ConnectionId) (*empty.Empty, error)
+}
+
+func RegisterNSMAdminServer(s *grpc.Server, srv NSMAdminServer) {
+	s.RegisterService(&_NSMAdmin_serviceDesc, srv)
+}
+
+func _NSMAdmin_GetConnections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMAdminServer).GetConnections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.NSMAdmin/GetConnections",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMAdminServer).GetConnections(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMAdmin_GetEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMAdminServer).GetEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.NSMAdmin/GetEndpoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMAdminServer).GetEndpoints(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMAdmin_GetDataplanes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMAdminServer).GetDataplanes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.NSMAdmin/GetDataplanes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMAdminServer).GetDataplanes(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMAdmin_GetWorkspaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMAdminServer).GetWorkspaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.NSMAdmin/GetWorkspaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMAdminServer).GetWorkspaces(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMAdmin_DumpModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMAdminServer).DumpModel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.NSMAdmin/DumpModel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMAdminServer).DumpModel(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMAdmin_CloseConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectionId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMAdminServer).CloseConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.NSMAdmin/CloseConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMAdminServer).CloseConnection(ctx, req.(*ConnectionId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NSMAdmin_HealConnection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectionId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NSMAdminServer).HealConnection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.NSMAdmin/HealConnection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NSMAdminServer).HealConnection(ctx, req.(*ConnectionId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _NSMAdmin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.NSMAdmin",
+	HandlerType: (*NSMAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetConnections",
+			Handler:    _NSMAdmin_GetConnections_Handler,
+		},
+		{
+			MethodName: "GetEndpoints",
+			Handler:    _NSMAdmin_GetEndpoints_Handler,
+		},
+		{
+			MethodName: "GetDataplanes",
+			Handler:    _NSMAdmin_GetDataplanes_Handler,
+		},
+		{
+			MethodName: "GetWorkspaces",
+			Handler:    _NSMAdmin_GetWorkspaces_Handler,
+		},
+		{
+			MethodName: "DumpModel",
+			Handler:    _NSMAdmin_DumpModel_Handler,
+		},
+		{
+			MethodName: "CloseConnection",
+			Handler:    _NSMAdmin_CloseConnection_Handler,
+		},
+		{
+			MethodName: "HealConnection",
+			Handler:    _NSMAdmin_HealConnection_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin.proto",
+}
@@ -0,0 +1,3 @@
+package admin
+
+//go:generate protoc -I . admin.proto --go_out=plugins=grpc:. --proto_path=$GOPATH/src
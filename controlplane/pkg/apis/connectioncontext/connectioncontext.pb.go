@@ -243,6 +243,8 @@ type ConnectionContext struct {
 	IpNeighbors          []*IpNeighbor         `protobuf:"bytes,7,rep,name=ip_neighbors,json=ipNeighbors,proto3" json:"ip_neighbors,omitempty"`
 	ExtraPrefixRequest   []*ExtraPrefixRequest `protobuf:"bytes,8,rep,name=extra_prefix_request,json=extraPrefixRequest,proto3" json:"extra_prefix_request,omitempty"`
 	ExtraPrefixes        []string              `protobuf:"bytes,9,rep,name=extra_prefixes,json=extraPrefixes,proto3" json:"extra_prefixes,omitempty"`
+	SrcIpv6Addr          string                `protobuf:"bytes,10,opt,name=src_ipv6_addr,json=srcIpv6Addr,proto3" json:"src_ipv6_addr,omitempty"`
+	DstIpv6Addr          string                `protobuf:"bytes,11,opt,name=dst_ipv6_addr,json=dstIpv6Addr,proto3" json:"dst_ipv6_addr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -336,6 +338,20 @@ func (m *ConnectionContext) GetExtraPrefixes() []string {
 	return nil
 }
 
+func (m *ConnectionContext) GetSrcIpv6Addr() string {
+	if m != nil {
+		return m.SrcIpv6Addr
+	}
+	return ""
+}
+
+func (m *ConnectionContext) GetDstIpv6Addr() string {
+	if m != nil {
+		return m.DstIpv6Addr
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterEnum("connectioncontext.IpFamily_Family", IpFamily_Family_name, IpFamily_Family_value)
 	proto.RegisterType((*IpNeighbor)(nil), "connectioncontext.IpNeighbor")
@@ -348,35 +364,37 @@ func init() {
 func init() { proto.RegisterFile("connectioncontext.proto", fileDescriptor_c30b3f1555e8b686) }
 
 var fileDescriptor_c30b3f1555e8b686 = []byte{
-	// 473 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xdf, 0x8b, 0xd3, 0x40,
-	0x10, 0xb6, 0x3f, 0x2e, 0x36, 0x13, 0xfb, 0x6b, 0x15, 0x5d, 0xd0, 0xd3, 0x12, 0x38, 0xad, 0x08,
-	0x45, 0xaa, 0xf8, 0x20, 0x3e, 0xa8, 0x87, 0x4a, 0x41, 0x8e, 0xb2, 0x0f, 0x0a, 0xbe, 0x84, 0x34,
-	0x3b, 0xe7, 0x2d, 0xf4, 0x92, 0xed, 0xee, 0x06, 0xeb, 0xff, 0xa7, 0xff, 0x97, 0x64, 0x77, 0x13,
-	0x0b, 0x29, 0xf7, 0x94, 0xc9, 0x37, 0xdf, 0x7e, 0xf3, 0xed, 0xcc, 0x2c, 0x3c, 0xc8, 0x8a, 0x3c,
-	0xc7, 0xcc, 0x88, 0x22, 0xcf, 0x8a, 0xdc, 0xe0, 0xde, 0x2c, 0xa4, 0x2a, 0x4c, 0x41, 0xa6, 0xad,
-	0x44, 0xfc, 0x05, 0x60, 0x25, 0x2f, 0x50, 0xfc, 0xbc, 0xda, 0x14, 0x8a, 0x8c, 0xa0, 0x2b, 0x24,
-	0xed, 0xcc, 0x3a, 0xf3, 0x90, 0x75, 0x85, 0x24, 0xcf, 0x61, 0x72, 0x95, 0x2a, 0xfe, 0x2b, 0x55,
-	0x98, 0xa4, 0x9c, 0x2b, 0xd4, 0x9a, 0x76, 0x6d, 0x76, 0x5c, 0xe3, 0x1f, 0x1c, 0x1c, 0x3f, 0x81,
-	0x13, 0x56, 0x94, 0x06, 0xc9, 0x7d, 0x08, 0xa4, 0xc2, 0x4b, 0xb1, 0xf7, 0x3a, 0xfe, 0x2f, 0xe6,
-	0x30, 0x58, 0xc9, 0xcf, 0xe9, 0xb5, 0xd8, 0xfe, 0x26, 0x6f, 0x21, 0xb8, 0xb4, 0x91, 0xe5, 0x8c,
-	0x96, 0xf1, 0xa2, 0x6d, 0xb9, 0x26, 0x2f, 0xdc, 0x87, 0xf9, 0x13, 0xf1, 0x23, 0x08, 0xbc, 0xca,
-	0x00, 0xfa, 0xab, 0xf5, 0xb7, 0xd7, 0x93, 0x5b, 0x3e, 0x7a, 0x33, 0xe9, 0xc4, 0x7f, 0x3b, 0x40,
-	0x3e, 0xed, 0x8d, 0x4a, 0xd7, 0xb6, 0x2a, 0xc3, 0x5d, 0x89, 0xda, 0x90, 0x77, 0x10, 0x55, 0xfe,
-	0x93, 0x83, 0xaa, 0xd1, 0xf2, 0xe1, 0x0d, 0x55, 0x19, 0x54, 0x7c, 0x5f, 0xe8, 0x14, 0xc0, 0x5d,
-	0x22, 0xd9, 0x62, 0x6e, 0x1b, 0x30, 0x64, 0xa1, 0x43, 0xbe, 0x62, 0x4e, 0x9e, 0xc1, 0x58, 0xe1,
-	0xae, 0x14, 0x0a, 0x79, 0x92, 0x97, 0xd7, 0x1b, 0x54, 0xb4, 0x67, 0x39, 0xa3, 0x1a, 0xbe, 0xb0,
-	0x68, 0xd5, 0x4e, 0xe5, 0x0c, 0xfd, 0x67, 0xf6, 0x2d, 0x73, 0xdc, 0xe0, 0x8e, 0x1a, 0xff, 0xe9,
-	0xc1, 0xf4, 0xbc, 0x71, 0x77, 0xee, 0xdc, 0x91, 0xc7, 0x10, 0x69, 0x95, 0x25, 0x42, 0xda, 0x69,
-	0xf8, 0x06, 0x87, 0x5a, 0x65, 0x2b, 0x59, 0xcd, 0xa1, 0xca, 0x73, 0x6d, 0x9a, 0xbc, 0x1b, 0x55,
-	0xc8, 0xb5, 0xf1, 0xf9, 0xa7, 0x30, 0xf6, 0xe7, 0x6b, 0x67, 0xd6, 0xe9, 0x80, 0x0d, 0xad, 0x06,
-	0xf3, 0x60, 0xc5, 0xf3, 0x3a, 0x0d, 0xaf, 0xef, 0x78, 0x56, 0xab, 0xe1, 0xbd, 0x84, 0x40, 0x55,
-	0x43, 0xd7, 0xf4, 0x64, 0xd6, 0x9b, 0x47, 0x4b, 0x7a, 0xa4, 0xa3, 0x76, 0x2b, 0x98, 0xe7, 0x91,
-	0x17, 0x30, 0xc5, 0x7d, 0xb6, 0x2d, 0x39, 0xf2, 0xc4, 0x75, 0x10, 0x35, 0x0d, 0x66, 0xbd, 0x79,
-	0xc8, 0x26, 0x75, 0x62, 0xed, 0x71, 0xf2, 0x1e, 0xee, 0x08, 0x99, 0xe4, 0x7e, 0x3b, 0x35, 0xbd,
-	0x6d, 0x8b, 0x9c, 0x1e, 0x1d, 0x5b, 0xbd, 0xc3, 0x2c, 0x12, 0x4d, 0xac, 0xc9, 0x77, 0xb8, 0x87,
-	0xd5, 0x36, 0xf8, 0x5a, 0x89, 0x6f, 0x33, 0x1d, 0x58, 0xa5, 0xb3, 0x23, 0x4a, 0xed, 0xe5, 0x61,
-	0x04, 0xdb, 0x0b, 0x75, 0x06, 0xa3, 0x43, 0x61, 0xd4, 0x34, 0xb4, 0x97, 0x18, 0x1e, 0x70, 0x51,
-	0x7f, 0xbc, 0xfb, 0xa3, 0xfd, 0xe6, 0x36, 0x81, 0x7d, 0x8d, 0xaf, 0xfe, 0x05, 0x00, 0x00, 0xff,
-	0xff, 0x01, 0xa6, 0xaf, 0xfc, 0xa8, 0x03, 0x00, 0x00,
+	// 501 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x8b, 0xd3, 0x40,
+	0x10, 0xc7, 0xed, 0x8f, 0x8b, 0xcd, 0xc4, 0xf4, 0xc7, 0x2a, 0x1a, 0xd0, 0xd3, 0x12, 0x38, 0xad,
+	0x08, 0x45, 0xaa, 0xf4, 0x41, 0x7c, 0x50, 0x0f, 0x95, 0x82, 0x1c, 0x65, 0x1f, 0x14, 0x7c, 0x09,
+	0x69, 0x32, 0xe7, 0x2d, 0xf4, 0x92, 0xdc, 0xee, 0xa6, 0xd6, 0xff, 0xc1, 0x7f, 0xcb, 0xff, 0x4b,
+	0x32, 0xbb, 0x89, 0x85, 0x14, 0x9f, 0xba, 0xfd, 0xce, 0x27, 0x33, 0xb3, 0xdf, 0x99, 0x85, 0x07,
+	0x49, 0x9e, 0x65, 0x98, 0x68, 0x91, 0x67, 0x49, 0x9e, 0x69, 0xdc, 0xeb, 0x79, 0x21, 0x73, 0x9d,
+	0xb3, 0x49, 0x2b, 0x10, 0x7e, 0x06, 0x58, 0x15, 0x17, 0x28, 0x7e, 0x5c, 0x6d, 0x72, 0xc9, 0x86,
+	0xd0, 0x15, 0x45, 0xd0, 0x99, 0x76, 0x66, 0x2e, 0xef, 0x8a, 0x82, 0x3d, 0x87, 0xf1, 0x55, 0x2c,
+	0xd3, 0x9f, 0xb1, 0xc4, 0x28, 0x4e, 0x53, 0x89, 0x4a, 0x05, 0x5d, 0x8a, 0x8e, 0x6a, 0xfd, 0xbd,
+	0x91, 0xc3, 0x27, 0x70, 0xc2, 0xf3, 0x52, 0x23, 0xbb, 0x0f, 0x4e, 0x21, 0xf1, 0x52, 0xec, 0x6d,
+	0x1e, 0xfb, 0x2f, 0x4c, 0x61, 0xb0, 0x2a, 0x3e, 0xc5, 0xd7, 0x62, 0xfb, 0x8b, 0xbd, 0x01, 0xe7,
+	0x92, 0x4e, 0xc4, 0x0c, 0x17, 0xe1, 0xbc, 0xdd, 0x72, 0x0d, 0xcf, 0xcd, 0x0f, 0xb7, 0x5f, 0x84,
+	0x8f, 0xc0, 0xb1, 0x59, 0x06, 0xd0, 0x5f, 0xad, 0xbf, 0xbe, 0x1e, 0xdf, 0xb2, 0xa7, 0xe5, 0xb8,
+	0x13, 0xfe, 0xe9, 0x00, 0xfb, 0xb8, 0xd7, 0x32, 0x5e, 0x53, 0x55, 0x8e, 0x37, 0x25, 0x2a, 0xcd,
+	0xde, 0x82, 0x57, 0xf5, 0x1f, 0x1d, 0x54, 0xf5, 0x16, 0x0f, 0xff, 0x53, 0x95, 0x43, 0xc5, 0xdb,
+	0x42, 0xa7, 0x00, 0xe6, 0x12, 0xd1, 0x16, 0x33, 0x32, 0xc0, 0xe7, 0xae, 0x51, 0xbe, 0x60, 0xc6,
+	0x9e, 0xc1, 0x48, 0xe2, 0x4d, 0x29, 0x24, 0xa6, 0x51, 0x56, 0x5e, 0x6f, 0x50, 0x06, 0x3d, 0x62,
+	0x86, 0xb5, 0x7c, 0x41, 0x6a, 0x65, 0xa7, 0x34, 0x0d, 0xfd, 0x23, 0xfb, 0x44, 0x8e, 0x1a, 0xdd,
+	0xa0, 0xe1, 0xef, 0x3e, 0x4c, 0xce, 0x9b, 0xee, 0xce, 0x4d, 0x77, 0xec, 0x31, 0x78, 0x4a, 0x26,
+	0x91, 0x28, 0x68, 0x1a, 0xd6, 0x60, 0x57, 0xc9, 0x64, 0x55, 0x54, 0x73, 0xa8, 0xe2, 0xa9, 0xd2,
+	0x4d, 0xdc, 0x8c, 0xca, 0x4d, 0x95, 0xb6, 0xf1, 0xa7, 0x30, 0xb2, 0xdf, 0xd7, 0x9d, 0x51, 0xa7,
+	0x03, 0xee, 0x53, 0x0e, 0x6e, 0xc5, 0x8a, 0xb3, 0x79, 0x1a, 0xae, 0x6f, 0x38, 0xca, 0xd5, 0x70,
+	0x2f, 0xc1, 0x91, 0xd5, 0xd0, 0x55, 0x70, 0x32, 0xed, 0xcd, 0xbc, 0x45, 0x70, 0xc4, 0x51, 0xda,
+	0x0a, 0x6e, 0x39, 0xf6, 0x02, 0x26, 0xb8, 0x4f, 0xb6, 0x65, 0x8a, 0x69, 0x64, 0x1c, 0x44, 0x15,
+	0x38, 0xd3, 0xde, 0xcc, 0xe5, 0xe3, 0x3a, 0xb0, 0xb6, 0x3a, 0x7b, 0x07, 0x77, 0x44, 0x11, 0x65,
+	0x76, 0x3b, 0x55, 0x70, 0x9b, 0x8a, 0x9c, 0x1e, 0x1d, 0x5b, 0xbd, 0xc3, 0xdc, 0x13, 0xcd, 0x59,
+	0xb1, 0x6f, 0x70, 0x0f, 0xab, 0x6d, 0xb0, 0xb5, 0x22, 0x6b, 0x73, 0x30, 0xa0, 0x4c, 0x67, 0x47,
+	0x32, 0xb5, 0x97, 0x87, 0x33, 0x6c, 0x69, 0xec, 0x0c, 0x86, 0x87, 0x89, 0x51, 0x05, 0x2e, 0x5d,
+	0xc2, 0x3f, 0x60, 0x51, 0xb1, 0x10, 0x7c, 0x63, 0xf8, 0x6e, 0x69, 0x46, 0x02, 0x34, 0x12, 0x8f,
+	0xec, 0xde, 0x2d, 0x69, 0x28, 0x21, 0xf8, 0xc6, 0xec, 0x9a, 0xf1, 0x0c, 0x43, 0x56, 0x1b, 0xe6,
+	0xc3, 0xdd, 0xef, 0xed, 0xb7, 0xbb, 0x71, 0xe8, 0x55, 0xbf, 0xfa, 0x3b, 0x00, 0x90, 0xfc, 0x8d,
+	0x1a, 0xf0, 0x03, 0x00, 0x00,
 }
@@ -24,9 +24,48 @@ func (c *ConnectionContext) IsComplete() error {
 			return fmt.Errorf("ConnectionContext.IpNeighbors.Ip is required and cannot be empty/nil: %v", c)
 		}
 	}
+
+	for _, addr := range []string{c.GetSrcIpv6Addr(), c.GetDstIpv6Addr()} {
+		if addr == "" {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(addr)
+		if err != nil || ip.To4() != nil {
+			return fmt.Errorf("ConnectionContext IPv6 address should be a valid IPv6 CIDR address: %v", c)
+		}
+	}
 	return nil
 }
 
+// SrcIpAddrs - returns source addresses of all families connection has.
+func (c *ConnectionContext) SrcIpAddrs() []string {
+	return nonEmpty(c.GetSrcIpAddr(), c.GetSrcIpv6Addr())
+}
+
+// DstIpAddrs - returns destination addresses of all families connection has.
+func (c *ConnectionContext) DstIpAddrs() []string {
+	return nonEmpty(c.GetDstIpAddr(), c.GetDstIpv6Addr())
+}
+
+// RouteGateway - returns destination address of the same family as route prefix, it is a gateway of route.
+func (c *ConnectionContext) RouteGateway(prefix string) string {
+	ip, _, err := net.ParseCIDR(prefix)
+	if err == nil && ip.To4() == nil && c.GetDstIpv6Addr() != "" {
+		return c.GetDstIpv6Addr()
+	}
+	return c.GetDstIpAddr()
+}
+
+func nonEmpty(values ...string) []string {
+	result := []string{}
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
 func (c *ConnectionContext) MeetsRequirements(original *ConnectionContext) error {
 	if c == nil {
 		return fmt.Errorf("ConnectionContext should not be nil...")
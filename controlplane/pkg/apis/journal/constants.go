@@ -0,0 +1,17 @@
+package journal
+
+// Connection lifecycle event types.
+const (
+	EventRequestReceived     = "REQUEST_RECEIVED"
+	EventRequestFailed       = "REQUEST_FAILED"
+	EventRequestDenied       = "REQUEST_DENIED"
+	EventEndpointSelected    = "ENDPOINT_SELECTED"
+	EventEndpointFailed      = "ENDPOINT_FAILED"
+	EventMechanismSelected   = "MECHANISM_SELECTED"
+	EventDataplaneProgrammed = "DATAPLANE_PROGRAMMED"
+	EventHealStarted         = "HEAL_STARTED"
+	EventHealFinished        = "HEAL_FINISHED"
+	EventAdded               = "ADDED"
+	EventClosed              = "CLOSED"
+	EventDeleted             = "DELETED"
+)
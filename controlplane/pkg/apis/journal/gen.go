@@ -0,0 +1,3 @@
+package journal
+
+//go:generate protoc -I . journal.proto --go_out=plugins=grpc:. --proto_path=$GOPATH/src
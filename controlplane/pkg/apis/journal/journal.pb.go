@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: journal.proto
+
+package journal
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// JournalEvent - a lifecycle event of connection, type is one of journal.Event* constants.
+type JournalEvent struct {
+	ConnectionId         string               `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Type                 string               `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Reason               string               `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *JournalEvent) Reset()         { *m = JournalEvent{} }
+func (m *JournalEvent) String() string { return proto.CompactTextString(m) }
+func (*JournalEvent) ProtoMessage()    {}
+func (*JournalEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{0}
+}
+
+func (m *JournalEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JournalEvent.Unmarshal(m, b)
+}
+func (m *JournalEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JournalEvent.Marshal(b, m, deterministic)
+}
+func (m *JournalEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JournalEvent.Merge(m, src)
+}
+func (m *JournalEvent) XXX_Size() int {
+	return xxx_messageInfo_JournalEvent.Size(m)
+}
+func (m *JournalEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_JournalEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JournalEvent proto.InternalMessageInfo
+
+func (m *JournalEvent) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+func (m *JournalEvent) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *JournalEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *JournalEvent) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+// JournalQuery - selects events of connection, all connections are selected if connection_id is empty.
+// Only last limit events are returned if limit is set.
+type JournalQuery struct {
+	ConnectionId         string   `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	Limit                uint32   `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JournalQuery) Reset()         { *m = JournalQuery{} }
+func (m *JournalQuery) String() string { return proto.CompactTextString(m) }
+func (*JournalQuery) ProtoMessage()    {}
+func (*JournalQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{1}
+}
+
+func (m *JournalQuery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JournalQuery.Unmarshal(m, b)
+}
+func (m *JournalQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JournalQuery.Marshal(b, m, deterministic)
+}
+func (m *JournalQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JournalQuery.Merge(m, src)
+}
+func (m *JournalQuery) XXX_Size() int {
+	return xxx_messageInfo_JournalQuery.Size(m)
+}
+func (m *JournalQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_JournalQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JournalQuery proto.InternalMessageInfo
+
+func (m *JournalQuery) GetConnectionId() string {
+	if m != nil {
+		return m.ConnectionId
+	}
+	return ""
+}
+
+func (m *JournalQuery) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type JournalEvents struct {
+	Events               []*JournalEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *JournalEvents) Reset()         { *m = JournalEvents{} }
+func (m *JournalEvents) String() string { return proto.CompactTextString(m) }
+func (*JournalEvents) ProtoMessage()    {}
+func (*JournalEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{2}
+}
+
+func (m *JournalEvents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JournalEvents.Unmarshal(m, b)
+}
+func (m *JournalEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JournalEvents.Marshal(b, m, deterministic)
+}
+func (m *JournalEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JournalEvents.Merge(m, src)
+}
+func (m *JournalEvents) XXX_Size() int {
+	return xxx_messageInfo_JournalEvents.Size(m)
+}
+func (m *JournalEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_JournalEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JournalEvents proto.InternalMessageInfo
+
+func (m *JournalEvents) GetEvents() []*JournalEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*JournalEvent)(nil), "journal.JournalEvent")
+	proto.RegisterType((*JournalQuery)(nil), "journal.JournalQuery")
+	proto.RegisterType((*JournalEvents)(nil), "journal.JournalEvents")
+}
+
+func init() { proto.RegisterFile("journal.proto", fileDescriptor_04fd98cceb1b9191) }
+
+var fileDescriptor_04fd98cceb1b9191 = []byte{
+	// 272 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x41, 0x4b, 0xfc, 0x30,
+	0x14, 0xc4, 0xe9, 0x7f, 0xfb, 0xaf, 0xec, 0xdb, 0xed, 0xc1, 0xa0, 0x4b, 0xe8, 0xa9, 0xd4, 0x4b,
+	0x2f, 0xa6, 0x50, 0x6f, 0x0a, 0x5e, 0x44, 0x64, 0xbd, 0x88, 0xc5, 0xbb, 0xb4, 0xdd, 0x18, 0x23,
+	0x6d, 0x5e, 0x69, 0x52, 0x61, 0x3f, 0x85, 0x5f, 0x59, 0x36, 0x4d, 0x77, 0x17, 0xf4, 0xe0, 0x6d,
+	0x26, 0x0c, 0xbf, 0x99, 0x3c, 0x08, 0x3f, 0x70, 0xe8, 0x55, 0xd9, 0xb0, 0xae, 0x47, 0x83, 0xe4,
+	0xc4, 0xd9, 0xe8, 0x46, 0x48, 0xf3, 0x3e, 0x54, 0xac, 0xc6, 0x36, 0x13, 0xd8, 0x94, 0x4a, 0x64,
+	0x36, 0x51, 0x0d, 0x6f, 0x59, 0x67, 0xb6, 0x1d, 0xd7, 0x99, 0x91, 0x2d, 0xd7, 0xa6, 0x6c, 0xbb,
+	0x83, 0x1a, 0x29, 0xc9, 0x97, 0x07, 0xcb, 0xc7, 0x11, 0x74, 0xff, 0xc9, 0x95, 0x21, 0x17, 0x10,
+	0xd6, 0xa8, 0x14, 0xaf, 0x8d, 0x44, 0xf5, 0x2a, 0x37, 0xd4, 0x8b, 0xbd, 0x74, 0x5e, 0x2c, 0x0f,
+	0x8f, 0xeb, 0x0d, 0x61, 0xe0, 0xef, 0x40, 0xf4, 0x5f, 0xec, 0xa5, 0x8b, 0x3c, 0x62, 0x02, 0x51,
+	0x34, 0x9c, 0x4d, 0xb5, 0xec, 0x65, 0x6a, 0x29, 0x6c, 0x8e, 0x10, 0xf0, 0x77, 0x43, 0xe8, 0xcc,
+	0xb2, 0xac, 0x26, 0x2b, 0x08, 0x7a, 0x5e, 0x6a, 0x54, 0xd4, 0xb7, 0xaf, 0xce, 0x25, 0xeb, 0xfd,
+	0xa0, 0xe7, 0x81, 0xf7, 0xdb, 0xbf, 0x0d, 0x3a, 0x83, 0xff, 0x8d, 0x6c, 0xa5, 0xb1, 0x8b, 0xc2,
+	0x62, 0x34, 0xc9, 0x2d, 0x84, 0xc7, 0x7f, 0xd3, 0xe4, 0x12, 0x02, 0x6e, 0x15, 0xf5, 0xe2, 0x59,
+	0xba, 0xc8, 0xcf, 0xd9, 0x74, 0xd3, 0xe3, 0x5c, 0xe1, 0x42, 0xf9, 0x13, 0x9c, 0xde, 0xed, 0x5b,
+	0x5c, 0x82, 0x5c, 0xc3, 0xfc, 0x81, 0x1b, 0x07, 0xfc, 0x01, 0xb0, 0x9b, 0xa3, 0xd5, 0xaf, 0x5c,
+	0x5d, 0x05, 0xf6, 0x42, 0x57, 0xdf, 0x03, 0x00, 0xdb, 0x85, 0x7f, 0xc4, 0xcb, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ConnectionJournalClient is the client API for ConnectionJournal service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ConnectionJournalClient interface {
+	GetEvents(ctx context.Context, in *JournalQuery, opts ...grpc.CallOption) (*JournalEvents, error)
+}
+
+type connectionJournalClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewConnectionJournalClient(cc *grpc.ClientConn) ConnectionJournalClient {
+	return &connectionJournalClient{cc}
+}
+
+func (c *connectionJournalClient) GetEvents(ctx context.Context, in *JournalQuery, opts ...grpc.CallOption) (*JournalEvents, error) {
+	out := new(JournalEvents)
+	err := c.cc.Invoke(ctx, "/journal.ConnectionJournal/GetEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConnectionJournalServer is the server API for ConnectionJournal service.
+type ConnectionJournalServer interface {
+	GetEvents(context.Context, *JournalQuery) (*JournalEvents, error)
+}
+
+func RegisterConnectionJournalServer(s *grpc.Server, srv ConnectionJournalServer) {
+	s.RegisterService(&_ConnectionJournal_serviceDesc, srv)
+}
+
+func _ConnectionJournal_GetEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JournalQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConnectionJournalServer).GetEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/journal.ConnectionJournal/GetEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConnectionJournalServer).GetEvents(ctx, req.(*JournalQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ConnectionJournal_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "journal.ConnectionJournal",
+	HandlerType: (*ConnectionJournalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEvents",
+			Handler:    _ConnectionJournal_GetEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "journal.proto",
+}
@@ -8,6 +8,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	empty "github.com/golang/protobuf/ptypes/empty"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	connectioncontext "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	grpc "google.golang.org/grpc"
 	math "math"
@@ -168,6 +169,7 @@ type Connection struct {
 	Context              *connectioncontext.ConnectionContext `protobuf:"bytes,4,opt,name=context,proto3" json:"context,omitempty"`
 	Labels               map[string]string                    `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	State                State                                `protobuf:"varint,6,opt,name=state,proto3,enum=local.connection.State" json:"state,omitempty"`
+	Expires              *timestamp.Timestamp                 `protobuf:"bytes,7,opt,name=expires,proto3" json:"expires,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
 	XXX_unrecognized     []byte                               `json:"-"`
 	XXX_sizecache        int32                                `json:"-"`
@@ -240,6 +242,13 @@ func (m *Connection) GetState() State {
 	return State_UP
 }
 
+func (m *Connection) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
 type ConnectionEvent struct {
 	Type                 ConnectionEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=local.connection.ConnectionEventType" json:"type,omitempty"`
 	Connections          map[string]*Connection `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -302,47 +311,49 @@ func init() {
 func init() { proto.RegisterFile("connection.proto", fileDescriptor_51baa40a1cc6b48b) }
 
 var fileDescriptor_51baa40a1cc6b48b = []byte{
-	// 638 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xef, 0x4f, 0xd3, 0x50,
-	0x14, 0xa5, 0xdd, 0x0f, 0xe5, 0x0e, 0x58, 0x79, 0x20, 0xd6, 0x6a, 0x22, 0x12, 0x8d, 0x0b, 0xc6,
-	0xd6, 0x94, 0x2f, 0x62, 0xa2, 0x71, 0xb2, 0x47, 0x58, 0xd8, 0x06, 0xb6, 0x05, 0x12, 0x63, 0xb2,
-	0x74, 0xe5, 0x51, 0x1a, 0xda, 0xbe, 0xa6, 0x7d, 0xa0, 0xfb, 0xe6, 0x77, 0xff, 0x35, 0xff, 0x14,
-	0xff, 0x08, 0xd3, 0x1f, 0xa3, 0x0f, 0x46, 0x46, 0xfc, 0xb2, 0xac, 0xe7, 0x9e, 0x73, 0xef, 0xb9,
-	0xe7, 0xbd, 0x16, 0x24, 0x87, 0x86, 0x21, 0x71, 0x98, 0x47, 0x43, 0x35, 0x8a, 0x29, 0xa3, 0x48,
-	0xf2, 0xa9, 0x63, 0xfb, 0x6a, 0x89, 0x2b, 0x5b, 0xae, 0xc7, 0xce, 0x2f, 0x47, 0xaa, 0x43, 0x03,
-	0xcd, 0xa5, 0xbe, 0x1d, 0xba, 0x5a, 0x46, 0x1d, 0x5d, 0x9e, 0x69, 0x11, 0x1b, 0x47, 0x24, 0xd1,
-	0x48, 0x10, 0xb1, 0x71, 0xfe, 0x9b, 0xb7, 0x51, 0x22, 0x4e, 0x14, 0x12, 0xf6, 0x83, 0xc6, 0x17,
-	0x09, 0x89, 0xaf, 0x3c, 0x87, 0x04, 0x24, 0x39, 0xbf, 0x0b, 0x72, 0x68, 0xc8, 0x62, 0xea, 0x47,
-	0xbe, 0x1d, 0x12, 0x2d, 0xba, 0x70, 0x35, 0x3b, 0xf2, 0x12, 0xad, 0xf4, 0x91, 0xd6, 0xc9, 0x4f,
-	0x36, 0x8d, 0xe4, 0x13, 0x37, 0xfe, 0x08, 0x30, 0xdf, 0x27, 0xce, 0xb9, 0x1d, 0x7a, 0x49, 0x80,
-	0xb6, 0xa0, 0x9a, 0x5a, 0x93, 0x85, 0x75, 0xa1, 0xb5, 0xa4, 0x3f, 0x57, 0x6f, 0x6f, 0xa5, 0x5e,
-	0x53, 0xad, 0x71, 0x44, 0x8c, 0x8c, 0x8c, 0xf6, 0x01, 0x22, 0x3b, 0xb6, 0x03, 0xc2, 0x48, 0x9c,
-	0xc8, 0xe2, 0x7a, 0xa5, 0xd5, 0xd0, 0xdf, 0xcc, 0x90, 0xaa, 0x87, 0xd7, 0x6c, 0x1c, 0xb2, 0x78,
-	0x6c, 0x70, 0x72, 0xe5, 0x23, 0x34, 0x6f, 0x95, 0x91, 0x04, 0x95, 0x0b, 0x32, 0xce, 0x3c, 0xcd,
-	0x1b, 0xe9, 0x5f, 0xb4, 0x0a, 0xb5, 0x2b, 0xdb, 0xbf, 0x24, 0xb2, 0x98, 0x61, 0xf9, 0xc3, 0x07,
-	0xf1, 0xbd, 0xb0, 0xf1, 0x57, 0x04, 0xd8, 0xb9, 0x9e, 0x89, 0x96, 0x40, 0xf4, 0x4e, 0x0b, 0xa5,
-	0xe8, 0x9d, 0xa2, 0xd7, 0xd0, 0x2c, 0x32, 0x1c, 0x16, 0x21, 0x16, 0x2d, 0x96, 0x0a, 0xd8, 0xcc,
-	0x51, 0xb4, 0x0d, 0xf3, 0xc1, 0xc4, 0xaf, 0x5c, 0x59, 0x17, 0x5a, 0x0d, 0xfd, 0xe9, 0x8c, 0x95,
-	0x8c, 0x92, 0x8d, 0x3e, 0xc1, 0x83, 0x22, 0x62, 0xb9, 0x9a, 0x09, 0x5f, 0xaa, 0xd3, 0xe1, 0x97,
-	0x1e, 0x77, 0x72, 0xc4, 0x98, 0x88, 0xd0, 0x67, 0xa8, 0xfb, 0xf6, 0x88, 0xf8, 0x89, 0x5c, 0xcb,
-	0xa2, 0x6c, 0x4d, 0xcf, 0x2d, 0xd5, 0x6a, 0x2f, 0xa3, 0xe6, 0x39, 0x16, 0x3a, 0xf4, 0x16, 0x6a,
-	0x09, 0xb3, 0x19, 0x91, 0xeb, 0xd9, 0x31, 0x3e, 0x9e, 0x6e, 0x60, 0xa6, 0x65, 0x23, 0x67, 0x29,
-	0xdb, 0xd0, 0xe0, 0xba, 0xfc, 0x57, 0xdc, 0xbf, 0x44, 0x68, 0x96, 0x66, 0xf0, 0x15, 0x09, 0x19,
-	0xda, 0xbe, 0x71, 0x87, 0x5e, 0xcd, 0x72, 0x9f, 0x09, 0xb8, 0x9b, 0x64, 0x41, 0xa3, 0xe4, 0x4d,
-	0xae, 0x92, 0x7e, 0x6f, 0x07, 0xee, 0xb9, 0x48, 0x82, 0x6f, 0xa3, 0x7c, 0x07, 0xe9, 0x36, 0xe1,
-	0x8e, 0x25, 0x75, 0x7e, 0xc9, 0x86, 0xfe, 0x6c, 0xd6, 0x54, 0x2e, 0x82, 0xcd, 0xdf, 0x02, 0x2c,
-	0xde, 0x78, 0x2b, 0xd0, 0x23, 0x58, 0xee, 0xe0, 0xdd, 0xf6, 0x51, 0xcf, 0x1a, 0x76, 0x07, 0x16,
-	0x36, 0x76, 0xdb, 0x3b, 0x58, 0x9a, 0x43, 0xab, 0x20, 0xed, 0x63, 0x63, 0x80, 0x7b, 0x1c, 0x2a,
-	0xa0, 0x15, 0x68, 0x1e, 0xef, 0x1d, 0x98, 0x3c, 0x55, 0x44, 0xcb, 0xb0, 0xd8, 0xc7, 0x7d, 0x0e,
-	0xaa, 0xa4, 0x3c, 0xd3, 0xe8, 0x1e, 0x1c, 0x73, 0x60, 0x15, 0x49, 0xb0, 0xb0, 0x77, 0xc2, 0x21,
-	0xb5, 0xcd, 0x27, 0x50, 0xcb, 0xce, 0x16, 0xd5, 0x41, 0x3c, 0x3a, 0x94, 0xe6, 0xd0, 0x43, 0xa8,
-	0x76, 0x0e, 0x4e, 0x06, 0x92, 0xb0, 0xd9, 0x85, 0x95, 0x3b, 0x92, 0x47, 0x0a, 0xac, 0x75, 0x07,
-	0x5d, 0xab, 0xdb, 0xee, 0x0d, 0x4d, 0xab, 0x6d, 0xe1, 0xa1, 0x65, 0xb4, 0x07, 0xe6, 0x2e, 0x36,
-	0xa4, 0x39, 0x04, 0x50, 0x3f, 0x3a, 0xec, 0xb4, 0xad, 0xd4, 0x28, 0x40, 0xbd, 0x83, 0x7b, 0xd8,
-	0xc2, 0x92, 0xa8, 0x9f, 0xc1, 0x72, 0x9f, 0x86, 0x1e, 0xa3, 0x31, 0xf7, 0xae, 0x7d, 0x05, 0x34,
-	0x05, 0x26, 0x68, 0x4d, 0x75, 0x29, 0x75, 0x7d, 0xa2, 0x4e, 0x3e, 0x7e, 0x2a, 0x4e, 0xbf, 0x77,
-	0xca, 0x8b, 0x7b, 0x4f, 0xf5, 0x9d, 0xf0, 0x65, 0xe1, 0x1b, 0x94, 0xf5, 0x51, 0x3d, 0x6b, 0xb1,
-	0xf5, 0x2f, 0x00, 0x00, 0xff, 0xff, 0xa2, 0x60, 0xd3, 0xf2, 0x7e, 0x05, 0x00, 0x00,
+	// 670 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5d, 0x4f, 0xd3, 0x50,
+	0x18, 0xa6, 0xdd, 0x07, 0xf2, 0x0e, 0x58, 0x39, 0x20, 0xd6, 0x6a, 0x22, 0x12, 0x8d, 0x0b, 0xc6,
+	0xd6, 0x14, 0x2f, 0x44, 0xa3, 0x71, 0xb2, 0x43, 0x58, 0xd8, 0x06, 0x76, 0x05, 0x12, 0x63, 0xb2,
+	0x74, 0xe5, 0x30, 0x1a, 0xda, 0x9e, 0xa6, 0x3d, 0x20, 0xbb, 0xf3, 0xde, 0x7f, 0xe1, 0xef, 0xf1,
+	0x47, 0x99, 0x7e, 0x8c, 0x1e, 0x28, 0x19, 0xf1, 0x66, 0xe9, 0x79, 0xde, 0xe7, 0x79, 0x3f, 0x9e,
+	0xf3, 0x9e, 0x81, 0x64, 0x53, 0xdf, 0x27, 0x36, 0x73, 0xa8, 0xaf, 0x06, 0x21, 0x65, 0x14, 0x49,
+	0x2e, 0xb5, 0x2d, 0x57, 0xcd, 0x71, 0x65, 0x73, 0xe4, 0xb0, 0xb3, 0x8b, 0xa1, 0x6a, 0x53, 0x4f,
+	0x1b, 0x51, 0xd7, 0xf2, 0x47, 0x5a, 0x42, 0x1d, 0x5e, 0x9c, 0x6a, 0x01, 0x1b, 0x07, 0x24, 0xd2,
+	0x88, 0x17, 0xb0, 0x71, 0xfa, 0x9b, 0xa6, 0x51, 0x3e, 0xde, 0x2f, 0x62, 0x8e, 0x47, 0x22, 0x66,
+	0x79, 0x41, 0xfe, 0x95, 0x89, 0x03, 0x4e, 0xec, 0x13, 0xf6, 0x93, 0x86, 0xe7, 0x11, 0x09, 0x2f,
+	0x1d, 0x9b, 0x78, 0x24, 0x3a, 0xbb, 0x0b, 0xb2, 0xa9, 0xcf, 0x42, 0xea, 0x06, 0xae, 0xe5, 0x13,
+	0x2d, 0x38, 0x1f, 0x69, 0x56, 0xe0, 0x44, 0x5a, 0x3e, 0x44, 0x1c, 0x27, 0x57, 0xac, 0x88, 0xa4,
+	0x15, 0xd7, 0xff, 0x0a, 0x30, 0xd7, 0x25, 0xf6, 0x99, 0xe5, 0x3b, 0x91, 0x87, 0x36, 0xa1, 0x1c,
+	0xb7, 0x28, 0x0b, 0x6b, 0x42, 0x63, 0x51, 0x7f, 0xa6, 0xde, 0xb6, 0x44, 0xbd, 0xa6, 0x9a, 0xe3,
+	0x80, 0x18, 0x09, 0x19, 0xed, 0x01, 0x04, 0x56, 0x68, 0x79, 0x84, 0x91, 0x30, 0x92, 0xc5, 0xb5,
+	0x52, 0xa3, 0xa6, 0xbf, 0x9e, 0x22, 0x55, 0x0f, 0xae, 0xd9, 0xd8, 0x67, 0xe1, 0xd8, 0xe0, 0xe4,
+	0xca, 0x27, 0xa8, 0xdf, 0x0a, 0x23, 0x09, 0x4a, 0xe7, 0x64, 0x9c, 0xf4, 0x34, 0x67, 0xc4, 0x9f,
+	0x68, 0x05, 0x2a, 0x97, 0x96, 0x7b, 0x41, 0x64, 0x31, 0xc1, 0xd2, 0xc3, 0x07, 0xf1, 0xbd, 0xb0,
+	0xfe, 0xa7, 0x04, 0xb0, 0x7d, 0x5d, 0x13, 0x2d, 0x82, 0xe8, 0x9c, 0x64, 0x4a, 0xd1, 0x39, 0x41,
+	0xaf, 0xa0, 0x9e, 0x79, 0x38, 0xc8, 0x4c, 0xcc, 0x52, 0x2c, 0x66, 0x70, 0x3f, 0x45, 0xd1, 0x16,
+	0xcc, 0x79, 0x93, 0x7e, 0xe5, 0xd2, 0x9a, 0xd0, 0xa8, 0xe9, 0x4f, 0xa6, 0x8c, 0x64, 0xe4, 0x6c,
+	0xf4, 0x19, 0x66, 0x33, 0x8b, 0xe5, 0x72, 0x22, 0x7c, 0xa1, 0x16, 0xcd, 0xcf, 0x7b, 0xdc, 0x4e,
+	0x11, 0x63, 0x22, 0x42, 0x5f, 0xa0, 0xea, 0x5a, 0x43, 0xe2, 0x46, 0x72, 0x25, 0xb1, 0xb2, 0x51,
+	0xac, 0x9b, 0xab, 0xd5, 0x4e, 0x42, 0x4d, 0x7d, 0xcc, 0x74, 0xe8, 0x0d, 0x54, 0x22, 0x66, 0x31,
+	0x22, 0x57, 0x93, 0x6b, 0x7c, 0x54, 0x4c, 0xd0, 0x8f, 0xc3, 0x46, 0xca, 0x42, 0xef, 0x60, 0x96,
+	0x5c, 0x05, 0x4e, 0x48, 0x22, 0x79, 0x36, 0x69, 0x58, 0x51, 0x47, 0x94, 0x8e, 0x5c, 0xa2, 0x4e,
+	0x16, 0x57, 0x35, 0x27, 0x7b, 0x6a, 0x4c, 0xa8, 0xca, 0x16, 0xd4, 0xb8, 0xda, 0xff, 0x75, 0x49,
+	0xbf, 0x44, 0xa8, 0xe7, 0x23, 0xe0, 0x4b, 0xe2, 0x33, 0xb4, 0x75, 0x63, 0xf3, 0x5e, 0x4e, 0x9b,
+	0x39, 0x11, 0x70, 0xfb, 0x67, 0x42, 0x2d, 0xe7, 0x4d, 0x16, 0x50, 0xbf, 0x37, 0x03, 0x77, 0xce,
+	0xfc, 0xe3, 0xd3, 0x28, 0x3f, 0x40, 0xba, 0x4d, 0xb8, 0x63, 0x48, 0x9d, 0x1f, 0xb2, 0xa6, 0x3f,
+	0x9d, 0x56, 0x95, 0xb3, 0x60, 0xe3, 0xb7, 0x00, 0x0b, 0x37, 0xde, 0x12, 0x7a, 0x08, 0x4b, 0x2d,
+	0xbc, 0xd3, 0x3c, 0xec, 0x98, 0x83, 0x76, 0xcf, 0xc4, 0xc6, 0x4e, 0x73, 0x1b, 0x4b, 0x33, 0x68,
+	0x05, 0xa4, 0x3d, 0x6c, 0xf4, 0x70, 0x87, 0x43, 0x05, 0xb4, 0x0c, 0xf5, 0xa3, 0xdd, 0xfd, 0x3e,
+	0x4f, 0x15, 0xd1, 0x12, 0x2c, 0x74, 0x71, 0x97, 0x83, 0x4a, 0x31, 0xaf, 0x6f, 0xb4, 0xf7, 0x8f,
+	0x38, 0xb0, 0x8c, 0x24, 0x98, 0xdf, 0x3d, 0xe6, 0x90, 0xca, 0xc6, 0x63, 0xa8, 0x24, 0x1b, 0x81,
+	0xaa, 0x20, 0x1e, 0x1e, 0x48, 0x33, 0xe8, 0x01, 0x94, 0x5b, 0xfb, 0xc7, 0x3d, 0x49, 0xd8, 0x68,
+	0xc3, 0xf2, 0x1d, 0xce, 0x23, 0x05, 0x56, 0xdb, 0xbd, 0xb6, 0xd9, 0x6e, 0x76, 0x06, 0x7d, 0xb3,
+	0x69, 0xe2, 0x81, 0x69, 0x34, 0x7b, 0xfd, 0x1d, 0x6c, 0x48, 0x33, 0x08, 0xa0, 0x7a, 0x78, 0xd0,
+	0x6a, 0x9a, 0x71, 0xa3, 0x00, 0xd5, 0x16, 0xee, 0x60, 0x13, 0x4b, 0xa2, 0x7e, 0x0a, 0x4b, 0x5d,
+	0xea, 0x3b, 0x8c, 0x86, 0xdc, 0x0b, 0xfd, 0x06, 0xa8, 0x00, 0x46, 0x68, 0xb5, 0xb0, 0x81, 0x38,
+	0xfe, 0x8b, 0x55, 0x9e, 0xdf, 0x7b, 0xab, 0x6f, 0x85, 0xaf, 0xf3, 0xdf, 0x21, 0x8f, 0x0f, 0xab,
+	0x49, 0x8a, 0xcd, 0x7f, 0x03, 0x00, 0xda, 0xd1, 0x27, 0x07, 0xf1, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1,6 +1,10 @@
 package nsm
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	"golang.org/x/net/context"
 )
@@ -31,6 +35,7 @@ type NSMConnection interface {
 	GetLabels() map[string]string
 	GetNetworkServiceEndpointName() string
 	SetNetworkServiceName(service string)
+	GetExpires() *timestamp.Timestamp
 }
 
 type NSMClientConnection interface {
@@ -50,13 +55,51 @@ type HealState int32
 
 const (
 	HealState_DstDown       HealState = 1 // Destination is down, we need to restore it and re-program local Datplane.
-	HealState_SrcDown       HealState = 2 // Source is down, most probable will not happen yet.
+	HealState_SrcDown       HealState = 2 // Source is down, connection is closed on both sides.
 	HealState_DataplaneDown HealState = 3 // In case local Dataplane is down, we need to heal NSE/Remote NSM and Dataplane.
 	HealState_DstUpdate     HealState = 4 // Destination is updated, most probable because of Remote Dataplane is down, we need to re-program local dataplane.
 )
 
+var healStateNames = map[HealState]string{
+	HealState_DstDown:       "DstDown",
+	HealState_SrcDown:       "SrcDown",
+	HealState_DataplaneDown: "DataplaneDown",
+	HealState_DstUpdate:     "DstUpdate",
+}
+
+func (s HealState) String() string {
+	if name, ok := healStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("HealState(%d)", s)
+}
+
 type NetworkServiceManager interface {
 	Request(ctx context.Context, request NSMRequest) (NSMConnection, error)
 	Close(ctx context.Context, clientConnection NSMClientConnection) error
 	Heal(connection NSMClientConnection, healState HealState)
+	RestoreConnections(connections []NSMClientConnection)
+	// ReapExpiredConnections - closes connections which leases are expired at passed time.
+	ReapExpiredConnections(now time.Time)
+	// RefreshRemoteLeases - requests remote NSMs again for connections which remote leases are expired before deadline
+	// or in less than half of lease granted.
+	RefreshRemoteLeases(ctx context.Context, deadline time.Time)
+	// DrainEndpoint - stops using local endpoint for new connections and migrates its connections to other endpoints.
+	DrainEndpoint(ctx context.Context, endpointName string) error
+	// UpdateSettings - replaces settings used for requests and heals started after update.
+	UpdateSettings(settings Settings)
+	// UpdateClusterPrefixes - replaces prefixes used by cluster, they are excluded in addition to configured ones.
+	UpdateClusterPrefixes(prefixes []string)
+}
+
+// Settings - parameters of NetworkServiceManager which could be changed without restart.
+type Settings struct {
+	// ExcludedPrefixes - prefixes endpoints should not allocate addresses from.
+	ExcludedPrefixes []string
+	// LeaseDuration - time connection is alive without refresh by its source, leases are not used if it is zero.
+	LeaseDuration time.Duration
+	// HealTimeout - time to recover connection before it is closed.
+	HealTimeout time.Duration
+	// NseConnectionTimeout - time for NSM to succeed connection to NSE.
+	NseConnectionTimeout time.Duration
 }
@@ -2,4 +2,7 @@ package registry
 
 const (
 	NsmUrlKey = "nsmurl"
+
+	// EndpointStateDraining - endpoint is going to be removed, its connections are migrated to other endpoints.
+	EndpointStateDraining = "DRAINING"
 )
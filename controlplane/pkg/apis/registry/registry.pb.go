@@ -104,12 +104,14 @@ func (m *NetworkServiceEndpoint) GetState() string {
 }
 
 type NetworkService struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Payload              string   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	Matches              []*Match `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name                 string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Payload              string       `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Matches              []*Match     `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	SelectionStrategy    string       `protobuf:"bytes,4,opt,name=selection_strategy,json=selectionStrategy,proto3" json:"selection_strategy,omitempty"`
+	RetryPolicy          *RetryPolicy `protobuf:"bytes,5,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *NetworkService) Reset()         { *m = NetworkService{} }
@@ -158,6 +160,99 @@ func (m *NetworkService) GetMatches() []*Match {
 	return nil
 }
 
+func (m *NetworkService) GetSelectionStrategy() string {
+	if m != nil {
+		return m.SelectionStrategy
+	}
+	return ""
+}
+
+func (m *NetworkService) GetRetryPolicy() *RetryPolicy {
+	if m != nil {
+		return m.RetryPolicy
+	}
+	return nil
+}
+
+type RetryPolicy struct {
+	MaxAttempts          uint32   `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	InitialBackoffMs     uint32   `protobuf:"varint,2,opt,name=initial_backoff_ms,json=initialBackoffMs,proto3" json:"initial_backoff_ms,omitempty"`
+	MaxBackoffMs         uint32   `protobuf:"varint,3,opt,name=max_backoff_ms,json=maxBackoffMs,proto3" json:"max_backoff_ms,omitempty"`
+	BackoffMultiplier    float64  `protobuf:"fixed64,4,opt,name=backoff_multiplier,json=backoffMultiplier,proto3" json:"backoff_multiplier,omitempty"`
+	Jitter               float64  `protobuf:"fixed64,5,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	RetryableCodes       []string `protobuf:"bytes,6,rep,name=retryable_codes,json=retryableCodes,proto3" json:"retryable_codes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RetryPolicy) Reset()         { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()    {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{2}
+}
+
+func (m *RetryPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetryPolicy.Unmarshal(m, b)
+}
+func (m *RetryPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetryPolicy.Marshal(b, m, deterministic)
+}
+func (m *RetryPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetryPolicy.Merge(m, src)
+}
+func (m *RetryPolicy) XXX_Size() int {
+	return xxx_messageInfo_RetryPolicy.Size(m)
+}
+func (m *RetryPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetryPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RetryPolicy proto.InternalMessageInfo
+
+func (m *RetryPolicy) GetMaxAttempts() uint32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetInitialBackoffMs() uint32 {
+	if m != nil {
+		return m.InitialBackoffMs
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetMaxBackoffMs() uint32 {
+	if m != nil {
+		return m.MaxBackoffMs
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBackoffMultiplier() float64 {
+	if m != nil {
+		return m.BackoffMultiplier
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetRetryableCodes() []string {
+	if m != nil {
+		return m.RetryableCodes
+	}
+	return nil
+}
+
 type Match struct {
 	SourceSelector       map[string]string `protobuf:"bytes,1,rep,name=source_selector,json=sourceSelector,proto3" json:"source_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Routes               []*Destination    `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
@@ -170,7 +265,7 @@ func (m *Match) Reset()         { *m = Match{} }
 func (m *Match) String() string { return proto.CompactTextString(m) }
 func (*Match) ProtoMessage()    {}
 func (*Match) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{2}
+	return fileDescriptor_41af05d40a615591, []int{3}
 }
 
 func (m *Match) XXX_Unmarshal(b []byte) error {
@@ -217,7 +312,7 @@ func (m *Destination) Reset()         { *m = Destination{} }
 func (m *Destination) String() string { return proto.CompactTextString(m) }
 func (*Destination) ProtoMessage()    {}
 func (*Destination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{3}
+	return fileDescriptor_41af05d40a615591, []int{4}
 }
 
 func (m *Destination) XXX_Unmarshal(b []byte) error {
@@ -257,6 +352,7 @@ type NetworkServiceManager struct {
 	Url                  string               `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
 	LastSeen             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
 	State                string               `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	RemoteMechanisms     []string             `protobuf:"bytes,5,rep,name=remote_mechanisms,json=remoteMechanisms,proto3" json:"remote_mechanisms,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -266,7 +362,7 @@ func (m *NetworkServiceManager) Reset()         { *m = NetworkServiceManager{} }
 func (m *NetworkServiceManager) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceManager) ProtoMessage()    {}
 func (*NetworkServiceManager) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{4}
+	return fileDescriptor_41af05d40a615591, []int{5}
 }
 
 func (m *NetworkServiceManager) XXX_Unmarshal(b []byte) error {
@@ -315,6 +411,13 @@ func (m *NetworkServiceManager) GetState() string {
 	return ""
 }
 
+func (m *NetworkServiceManager) GetRemoteMechanisms() []string {
+	if m != nil {
+		return m.RemoteMechanisms
+	}
+	return nil
+}
+
 type RemoveNSERequest struct {
 	EndpointName         string   `protobuf:"bytes,1,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -326,7 +429,7 @@ func (m *RemoveNSERequest) Reset()         { *m = RemoveNSERequest{} }
 func (m *RemoveNSERequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveNSERequest) ProtoMessage()    {}
 func (*RemoveNSERequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{5}
+	return fileDescriptor_41af05d40a615591, []int{6}
 }
 
 func (m *RemoveNSERequest) XXX_Unmarshal(b []byte) error {
@@ -354,6 +457,45 @@ func (m *RemoveNSERequest) GetEndpointName() string {
 	return ""
 }
 
+type DrainNSERequest struct {
+	EndpointName         string   `protobuf:"bytes,1,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DrainNSERequest) Reset()         { *m = DrainNSERequest{} }
+func (m *DrainNSERequest) String() string { return proto.CompactTextString(m) }
+func (*DrainNSERequest) ProtoMessage()    {}
+func (*DrainNSERequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{7}
+}
+
+func (m *DrainNSERequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainNSERequest.Unmarshal(m, b)
+}
+func (m *DrainNSERequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainNSERequest.Marshal(b, m, deterministic)
+}
+func (m *DrainNSERequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainNSERequest.Merge(m, src)
+}
+func (m *DrainNSERequest) XXX_Size() int {
+	return xxx_messageInfo_DrainNSERequest.Size(m)
+}
+func (m *DrainNSERequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainNSERequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainNSERequest proto.InternalMessageInfo
+
+func (m *DrainNSERequest) GetEndpointName() string {
+	if m != nil {
+		return m.EndpointName
+	}
+	return ""
+}
+
 type FindNetworkServiceRequest struct {
 	NetworkServiceName   string   `protobuf:"bytes,1,opt,name=network_service_name,json=networkServiceName,proto3" json:"network_service_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -365,7 +507,7 @@ func (m *FindNetworkServiceRequest) Reset()         { *m = FindNetworkServiceReq
 func (m *FindNetworkServiceRequest) String() string { return proto.CompactTextString(m) }
 func (*FindNetworkServiceRequest) ProtoMessage()    {}
 func (*FindNetworkServiceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{6}
+	return fileDescriptor_41af05d40a615591, []int{8}
 }
 
 func (m *FindNetworkServiceRequest) XXX_Unmarshal(b []byte) error {
@@ -407,7 +549,7 @@ func (m *FindNetworkServiceResponse) Reset()         { *m = FindNetworkServiceRe
 func (m *FindNetworkServiceResponse) String() string { return proto.CompactTextString(m) }
 func (*FindNetworkServiceResponse) ProtoMessage()    {}
 func (*FindNetworkServiceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{7}
+	return fileDescriptor_41af05d40a615591, []int{9}
 }
 
 func (m *FindNetworkServiceResponse) XXX_Unmarshal(b []byte) error {
@@ -456,6 +598,218 @@ func (m *FindNetworkServiceResponse) GetNetworkServiceEndpoints() []*NetworkServ
 	return nil
 }
 
+type NetworkServiceList struct {
+	NetworkServices      []*NetworkService `protobuf:"bytes,1,rep,name=network_services,json=networkServices,proto3" json:"network_services,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *NetworkServiceList) Reset()         { *m = NetworkServiceList{} }
+func (m *NetworkServiceList) String() string { return proto.CompactTextString(m) }
+func (*NetworkServiceList) ProtoMessage()    {}
+func (*NetworkServiceList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{10}
+}
+
+func (m *NetworkServiceList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NetworkServiceList.Unmarshal(m, b)
+}
+func (m *NetworkServiceList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NetworkServiceList.Marshal(b, m, deterministic)
+}
+func (m *NetworkServiceList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NetworkServiceList.Merge(m, src)
+}
+func (m *NetworkServiceList) XXX_Size() int {
+	return xxx_messageInfo_NetworkServiceList.Size(m)
+}
+func (m *NetworkServiceList) XXX_DiscardUnknown() {
+	xxx_messageInfo_NetworkServiceList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NetworkServiceList proto.InternalMessageInfo
+
+func (m *NetworkServiceList) GetNetworkServices() []*NetworkService {
+	if m != nil {
+		return m.NetworkServices
+	}
+	return nil
+}
+
+type ExcludedPrefixes struct {
+	Prefixes             []string `protobuf:"bytes,1,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExcludedPrefixes) Reset()         { *m = ExcludedPrefixes{} }
+func (m *ExcludedPrefixes) String() string { return proto.CompactTextString(m) }
+func (*ExcludedPrefixes) ProtoMessage()    {}
+func (*ExcludedPrefixes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{11}
+}
+
+func (m *ExcludedPrefixes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExcludedPrefixes.Unmarshal(m, b)
+}
+func (m *ExcludedPrefixes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExcludedPrefixes.Marshal(b, m, deterministic)
+}
+func (m *ExcludedPrefixes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExcludedPrefixes.Merge(m, src)
+}
+func (m *ExcludedPrefixes) XXX_Size() int {
+	return xxx_messageInfo_ExcludedPrefixes.Size(m)
+}
+func (m *ExcludedPrefixes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExcludedPrefixes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExcludedPrefixes proto.InternalMessageInfo
+
+func (m *ExcludedPrefixes) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+type PrefixLeaseRequest struct {
+	NetworkServiceName   string   `protobuf:"bytes,1,opt,name=network_service_name,json=networkServiceName,proto3" json:"network_service_name,omitempty"`
+	Owner                string   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Prefixes             []string `protobuf:"bytes,3,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Ipv4PrefixLen        uint32   `protobuf:"varint,4,opt,name=ipv4_prefix_len,json=ipv4PrefixLen,proto3" json:"ipv4_prefix_len,omitempty"`
+	Ipv6PrefixLen        uint32   `protobuf:"varint,5,opt,name=ipv6_prefix_len,json=ipv6PrefixLen,proto3" json:"ipv6_prefix_len,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrefixLeaseRequest) Reset()         { *m = PrefixLeaseRequest{} }
+func (m *PrefixLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*PrefixLeaseRequest) ProtoMessage()    {}
+func (*PrefixLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{12}
+}
+
+func (m *PrefixLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefixLeaseRequest.Unmarshal(m, b)
+}
+func (m *PrefixLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefixLeaseRequest.Marshal(b, m, deterministic)
+}
+func (m *PrefixLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefixLeaseRequest.Merge(m, src)
+}
+func (m *PrefixLeaseRequest) XXX_Size() int {
+	return xxx_messageInfo_PrefixLeaseRequest.Size(m)
+}
+func (m *PrefixLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefixLeaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefixLeaseRequest proto.InternalMessageInfo
+
+func (m *PrefixLeaseRequest) GetNetworkServiceName() string {
+	if m != nil {
+		return m.NetworkServiceName
+	}
+	return ""
+}
+
+func (m *PrefixLeaseRequest) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *PrefixLeaseRequest) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+func (m *PrefixLeaseRequest) GetIpv4PrefixLen() uint32 {
+	if m != nil {
+		return m.Ipv4PrefixLen
+	}
+	return 0
+}
+
+func (m *PrefixLeaseRequest) GetIpv6PrefixLen() uint32 {
+	if m != nil {
+		return m.Ipv6PrefixLen
+	}
+	return 0
+}
+
+type PrefixLease struct {
+	NetworkServiceName   string               `protobuf:"bytes,1,opt,name=network_service_name,json=networkServiceName,proto3" json:"network_service_name,omitempty"`
+	Owner                string               `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Prefixes             []string             `protobuf:"bytes,3,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=expires,proto3" json:"expires,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PrefixLease) Reset()         { *m = PrefixLease{} }
+func (m *PrefixLease) String() string { return proto.CompactTextString(m) }
+func (*PrefixLease) ProtoMessage()    {}
+func (*PrefixLease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{13}
+}
+
+func (m *PrefixLease) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrefixLease.Unmarshal(m, b)
+}
+func (m *PrefixLease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrefixLease.Marshal(b, m, deterministic)
+}
+func (m *PrefixLease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefixLease.Merge(m, src)
+}
+func (m *PrefixLease) XXX_Size() int {
+	return xxx_messageInfo_PrefixLease.Size(m)
+}
+func (m *PrefixLease) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefixLease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefixLease proto.InternalMessageInfo
+
+func (m *PrefixLease) GetNetworkServiceName() string {
+	if m != nil {
+		return m.NetworkServiceName
+	}
+	return ""
+}
+
+func (m *PrefixLease) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *PrefixLease) GetPrefixes() []string {
+	if m != nil {
+		return m.Prefixes
+	}
+	return nil
+}
+
+func (m *PrefixLease) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
 type NSERegistration struct {
 	NetworkService         *NetworkService         `protobuf:"bytes,1,opt,name=network_service,json=networkService,proto3" json:"network_service,omitempty"`
 	NetworkServiceManager  *NetworkServiceManager  `protobuf:"bytes,2,opt,name=network_service_manager,json=networkServiceManager,proto3" json:"network_service_manager,omitempty"`
@@ -469,7 +823,7 @@ func (m *NSERegistration) Reset()         { *m = NSERegistration{} }
 func (m *NSERegistration) String() string { return proto.CompactTextString(m) }
 func (*NSERegistration) ProtoMessage()    {}
 func (*NSERegistration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{8}
+	return fileDescriptor_41af05d40a615591, []int{14}
 }
 
 func (m *NSERegistration) XXX_Unmarshal(b []byte) error {
@@ -515,71 +869,105 @@ func init() {
 	proto.RegisterType((*NetworkServiceEndpoint)(nil), "registry.NetworkServiceEndpoint")
 	proto.RegisterMapType((map[string]string)(nil), "registry.NetworkServiceEndpoint.LabelsEntry")
 	proto.RegisterType((*NetworkService)(nil), "registry.NetworkService")
+	proto.RegisterType((*RetryPolicy)(nil), "registry.RetryPolicy")
 	proto.RegisterType((*Match)(nil), "registry.Match")
 	proto.RegisterMapType((map[string]string)(nil), "registry.Match.SourceSelectorEntry")
 	proto.RegisterType((*Destination)(nil), "registry.Destination")
 	proto.RegisterMapType((map[string]string)(nil), "registry.Destination.DestinationSelectorEntry")
 	proto.RegisterType((*NetworkServiceManager)(nil), "registry.NetworkServiceManager")
 	proto.RegisterType((*RemoveNSERequest)(nil), "registry.RemoveNSERequest")
+	proto.RegisterType((*DrainNSERequest)(nil), "registry.DrainNSERequest")
 	proto.RegisterType((*FindNetworkServiceRequest)(nil), "registry.FindNetworkServiceRequest")
 	proto.RegisterType((*FindNetworkServiceResponse)(nil), "registry.FindNetworkServiceResponse")
 	proto.RegisterMapType((map[string]*NetworkServiceManager)(nil), "registry.FindNetworkServiceResponse.NetworkServiceManagersEntry")
+	proto.RegisterType((*NetworkServiceList)(nil), "registry.NetworkServiceList")
+	proto.RegisterType((*ExcludedPrefixes)(nil), "registry.ExcludedPrefixes")
+	proto.RegisterType((*PrefixLeaseRequest)(nil), "registry.PrefixLeaseRequest")
+	proto.RegisterType((*PrefixLease)(nil), "registry.PrefixLease")
 	proto.RegisterType((*NSERegistration)(nil), "registry.NSERegistration")
 }
 
 func init() { proto.RegisterFile("registry.proto", fileDescriptor_41af05d40a615591) }
 
 var fileDescriptor_41af05d40a615591 = []byte{
-	// 770 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcf, 0x4e, 0xdb, 0x4e,
-	0x10, 0x96, 0x93, 0x10, 0x60, 0xf2, 0x23, 0x41, 0x0b, 0x04, 0xc7, 0xbf, 0x4a, 0x45, 0xa1, 0x07,
-	0x2a, 0xb5, 0x4e, 0x15, 0x54, 0xd1, 0x3f, 0x07, 0x8a, 0x4a, 0x38, 0x41, 0x0e, 0x4e, 0xa5, 0xaa,
-	0x52, 0xa5, 0xc8, 0x49, 0xa6, 0xc6, 0xc5, 0xde, 0x75, 0xbd, 0x9b, 0x20, 0xf3, 0x04, 0x3d, 0xf4,
-	0x19, 0xfa, 0x2a, 0x3d, 0xf6, 0xda, 0x57, 0xe8, 0x9b, 0x54, 0x59, 0xdb, 0xc4, 0x76, 0x1c, 0x20,
-	0x97, 0x68, 0xff, 0xcc, 0x7c, 0x33, 0xf3, 0xed, 0x7c, 0x13, 0x43, 0xd5, 0x47, 0xcb, 0xe6, 0xc2,
-	0x0f, 0x74, 0xcf, 0x67, 0x82, 0x91, 0xb5, 0x78, 0xaf, 0x1d, 0x5a, 0xb6, 0xb8, 0x1c, 0x0f, 0xf4,
-	0x21, 0x73, 0x5b, 0x16, 0x73, 0x4c, 0x6a, 0xb5, 0xa4, 0xc9, 0x60, 0xfc, 0xa5, 0xe5, 0x89, 0xc0,
-	0x43, 0xde, 0x42, 0xd7, 0x13, 0x41, 0xf8, 0x1b, 0xba, 0x6b, 0x6f, 0xef, 0x77, 0x12, 0xb6, 0x8b,
-	0x5c, 0x98, 0xae, 0x37, 0x5b, 0x85, 0xce, 0xcd, 0xbf, 0x05, 0xa8, 0x77, 0x51, 0x5c, 0x33, 0xff,
-	0xaa, 0x87, 0xfe, 0xc4, 0x1e, 0x62, 0x87, 0x8e, 0x3c, 0x66, 0x53, 0x41, 0x5e, 0xc0, 0x36, 0x0d,
-	0x6f, 0xfa, 0x3c, 0xbc, 0xea, 0x53, 0xd3, 0x45, 0x55, 0xd9, 0x53, 0x0e, 0xd6, 0x0d, 0x42, 0x53,
-	0x5e, 0x5d, 0xd3, 0x45, 0xa2, 0xc2, 0xaa, 0x67, 0x06, 0x0e, 0x33, 0x47, 0x6a, 0x41, 0x1a, 0xc5,
-	0x5b, 0x72, 0x0c, 0x8f, 0xb2, 0x58, 0xae, 0x49, 0x4d, 0x0b, 0xfd, 0x10, 0xb3, 0x28, 0xcd, 0x1b,
-	0x69, 0xcc, 0x8b, 0xd0, 0x42, 0x42, 0xef, 0xc3, 0x06, 0x46, 0x89, 0x85, 0x1e, 0x25, 0xe9, 0xf1,
-	0x5f, 0x7c, 0x28, 0x8d, 0x4e, 0xa1, 0xec, 0x98, 0x03, 0x74, 0xb8, 0xba, 0xb2, 0x57, 0x3c, 0xa8,
-	0xb4, 0x9f, 0xe9, 0xb7, 0x4c, 0xe7, 0xd7, 0xa8, 0x9f, 0x4b, 0xf3, 0x0e, 0x15, 0x7e, 0x60, 0x44,
-	0xbe, 0x64, 0x1b, 0x56, 0xb8, 0x30, 0x05, 0xaa, 0x65, 0x19, 0x22, 0xdc, 0x68, 0xaf, 0xa1, 0x92,
-	0x30, 0x26, 0x9b, 0x50, 0xbc, 0xc2, 0x20, 0xe2, 0x62, 0xba, 0x9c, 0xba, 0x4d, 0x4c, 0x67, 0x8c,
-	0x51, 0xe9, 0xe1, 0xe6, 0x4d, 0xe1, 0x95, 0xd2, 0xb4, 0xa1, 0x9a, 0x0e, 0x4f, 0x08, 0x94, 0x12,
-	0x54, 0xca, 0xf5, 0x1d, 0xe4, 0x3d, 0x85, 0x55, 0xd7, 0x14, 0xc3, 0x4b, 0xe4, 0x6a, 0x51, 0xd6,
-	0x55, 0x9b, 0xd5, 0x75, 0x31, 0xbd, 0x30, 0xe2, 0xfb, 0xe6, 0x6f, 0x05, 0x56, 0xe4, 0x11, 0x39,
-	0x87, 0x1a, 0x67, 0x63, 0x7f, 0x88, 0x7d, 0x8e, 0x0e, 0x0e, 0x05, 0xf3, 0x55, 0x45, 0x3a, 0xef,
-	0x67, 0x9c, 0xf5, 0x9e, 0x34, 0xeb, 0x45, 0x56, 0x21, 0x17, 0x55, 0x9e, 0x3a, 0x24, 0xcf, 0xa1,
-	0xec, 0xb3, 0xb1, 0x40, 0xae, 0x16, 0x24, 0xc8, 0xce, 0x0c, 0xe4, 0x14, 0xb9, 0xb0, 0xa9, 0x29,
-	0x6c, 0x46, 0x8d, 0xc8, 0x48, 0x3b, 0x81, 0xad, 0x1c, 0xd4, 0xa5, 0x48, 0xfb, 0xa3, 0x40, 0x25,
-	0x01, 0x4d, 0x4c, 0xd8, 0x1e, 0xcd, 0xb6, 0xd9, 0xa2, 0xf4, 0xdc, 0x7c, 0x92, 0xeb, 0x74, 0x7d,
-	0x5b, 0xa3, 0xf9, 0x1b, 0x52, 0x87, 0xf2, 0x35, 0xda, 0xd6, 0xa5, 0x90, 0xd9, 0x6c, 0x18, 0xd1,
-	0x4e, 0x3b, 0x03, 0x75, 0x11, 0xd0, 0x52, 0x25, 0xfd, 0x50, 0x60, 0xa7, 0x9b, 0xd7, 0xe1, 0xb9,
-	0xfd, 0xb0, 0x09, 0xc5, 0xb1, 0xef, 0x44, 0x28, 0xd3, 0x25, 0x39, 0x82, 0x75, 0xc7, 0xe4, 0xa2,
-	0xcf, 0x11, 0xa9, 0x54, 0x4c, 0xa5, 0xad, 0xe9, 0x16, 0x63, 0x96, 0x83, 0x7a, 0xac, 0x78, 0xfd,
-	0x43, 0x2c, 0x70, 0x63, 0x6d, 0x6a, 0xdc, 0x43, 0xa4, 0xb3, 0x8e, 0x2e, 0x25, 0x3a, 0xba, 0x79,
-	0x04, 0x9b, 0x06, 0xba, 0x6c, 0x82, 0xdd, 0x5e, 0xc7, 0xc0, 0x6f, 0x63, 0xe4, 0x62, 0x5e, 0x66,
-	0xca, 0xbc, 0xcc, 0x9a, 0x17, 0xd0, 0x38, 0xb3, 0xe9, 0x28, 0x5d, 0x4a, 0x8c, 0xb0, 0xf4, 0xd4,
-	0x68, 0xfe, 0x2a, 0x82, 0x96, 0x87, 0xc7, 0x3d, 0x46, 0x79, 0x4a, 0x17, 0x4a, 0x5a, 0x17, 0x27,
-	0x50, 0xcb, 0x84, 0x92, 0x6c, 0x55, 0xda, 0xea, 0x22, 0xdd, 0x1b, 0xd5, 0x74, 0x7c, 0x72, 0x03,
-	0xea, 0x82, 0xb9, 0x14, 0x6b, 0xed, 0xdd, 0x0c, 0x6b, 0x71, 0x92, 0x7a, 0xee, 0xb3, 0x46, 0x73,
-	0xa5, 0x9e, 0x3b, 0xd5, 0x38, 0xf9, 0x0c, 0x8d, 0x6c, 0xec, 0x98, 0x66, 0xae, 0x96, 0x64, 0xf0,
-	0xbd, 0xfb, 0x06, 0x98, 0xb1, 0x4b, 0x73, 0xcf, 0xb9, 0xf6, 0x15, 0xfe, 0xbf, 0x23, 0xa9, 0x9c,
-	0xbe, 0x7d, 0x99, 0xec, 0xdb, 0x4a, 0xfb, 0xf1, 0xa2, 0xd0, 0x11, 0x4e, 0xb2, 0xb1, 0xbf, 0x17,
-	0xa0, 0x26, 0x9b, 0x48, 0x3a, 0x84, 0x7a, 0xcd, 0x79, 0x1c, 0x65, 0xc9, 0xc7, 0xf9, 0x08, 0xbb,
-	0x0b, 0x1e, 0xe7, 0xa1, 0x39, 0xee, 0xe4, 0x52, 0x4f, 0x3e, 0xdd, 0x02, 0x67, 0x89, 0x8f, 0x64,
-	0x75, 0x3f, 0xef, 0xf5, 0x34, 0x40, 0x7c, 0xde, 0xfe, 0xa9, 0x64, 0xff, 0x4f, 0x23, 0x56, 0x02,
-	0xf2, 0x1e, 0x2a, 0xe1, 0x1a, 0xfd, 0x6e, 0xaf, 0x43, 0x1a, 0x89, 0x18, 0x69, 0xee, 0xb4, 0xc5,
-	0x57, 0xe4, 0x18, 0xd6, 0x6f, 0x45, 0x4b, 0xb4, 0x99, 0x5d, 0x56, 0xc9, 0x5a, 0x7d, 0x6e, 0x32,
-	0x74, 0xa6, 0xdf, 0x0c, 0xed, 0x1b, 0xd8, 0x4d, 0xe7, 0x77, 0x6a, 0xf3, 0x21, 0x9b, 0xa0, 0x1f,
-	0x90, 0x3e, 0x90, 0xf9, 0x16, 0x27, 0xfb, 0x77, 0x0b, 0x20, 0x8c, 0xf6, 0xe4, 0x21, 0x2a, 0x19,
-	0x94, 0x65, 0x2e, 0x87, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa4, 0xae, 0x18, 0x5a, 0x01, 0x09,
-	0x00, 0x00,
+	// 1230 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0xdf, 0x6e, 0x1b, 0xc5,
+	0x17, 0xd6, 0xc6, 0x49, 0x9a, 0x9c, 0x6d, 0x62, 0x77, 0xd2, 0x24, 0x9b, 0xfd, 0x55, 0xfa, 0x05,
+	0xb7, 0x82, 0x20, 0x5a, 0xa7, 0x72, 0x4b, 0x5b, 0x40, 0xa8, 0x2d, 0x49, 0x8a, 0x2a, 0xe2, 0x50,
+	0xad, 0x91, 0x50, 0x25, 0xa4, 0xd5, 0xd8, 0x3e, 0x71, 0xa6, 0xdd, 0x9d, 0x5d, 0x66, 0xc6, 0x69,
+	0xcc, 0x13, 0xf0, 0x12, 0x5c, 0x23, 0x1e, 0x81, 0x2b, 0x2e, 0xcb, 0x25, 0xe2, 0x9e, 0x0b, 0xde,
+	0x81, 0x07, 0x40, 0x3b, 0xfb, 0xc7, 0xbb, 0xf6, 0x6e, 0xd3, 0x20, 0xc4, 0x8d, 0xb5, 0x33, 0xe7,
+	0x3b, 0xdf, 0x99, 0x73, 0xce, 0x9c, 0x6f, 0x0c, 0xab, 0x02, 0x87, 0x4c, 0x2a, 0x31, 0x6e, 0x85,
+	0x22, 0x50, 0x01, 0x59, 0x4a, 0xd7, 0xf6, 0x9d, 0x21, 0x53, 0x27, 0xa3, 0x5e, 0xab, 0x1f, 0xf8,
+	0xbb, 0xc3, 0xc0, 0xa3, 0x7c, 0xb8, 0xab, 0x21, 0xbd, 0xd1, 0xf1, 0x6e, 0xa8, 0xc6, 0x21, 0xca,
+	0x5d, 0xf4, 0x43, 0x35, 0x8e, 0x7f, 0x63, 0x77, 0xfb, 0x93, 0xf3, 0x9d, 0x14, 0xf3, 0x51, 0x2a,
+	0xea, 0x87, 0x93, 0xaf, 0xd8, 0xb9, 0xf9, 0xe7, 0x1c, 0x6c, 0x1c, 0xa1, 0x7a, 0x15, 0x88, 0x97,
+	0x5d, 0x14, 0xa7, 0xac, 0x8f, 0x07, 0x7c, 0x10, 0x06, 0x8c, 0x2b, 0x72, 0x1b, 0xae, 0xf2, 0xd8,
+	0xe2, 0xca, 0xd8, 0xe4, 0x72, 0xea, 0xa3, 0x65, 0x6c, 0x1b, 0x3b, 0xcb, 0x0e, 0xe1, 0x05, 0xaf,
+	0x23, 0xea, 0x23, 0xb1, 0xe0, 0x52, 0x48, 0xc7, 0x5e, 0x40, 0x07, 0xd6, 0x9c, 0x06, 0xa5, 0x4b,
+	0xf2, 0x10, 0xae, 0x4d, 0x73, 0xf9, 0x94, 0xd3, 0x21, 0x8a, 0x98, 0xb3, 0xa6, 0xe1, 0x5b, 0x45,
+	0xce, 0x4e, 0x8c, 0xd0, 0xd4, 0xd7, 0x61, 0x05, 0x93, 0x83, 0xc5, 0x1e, 0xf3, 0xda, 0xe3, 0x72,
+	0xba, 0xa9, 0x41, 0xfb, 0xb0, 0xe8, 0xd1, 0x1e, 0x7a, 0xd2, 0x5a, 0xd8, 0xae, 0xed, 0x98, 0xed,
+	0x9b, 0xad, 0xac, 0xd2, 0xe5, 0x39, 0xb6, 0x0e, 0x35, 0xfc, 0x80, 0x2b, 0x31, 0x76, 0x12, 0x5f,
+	0x72, 0x15, 0x16, 0xa4, 0xa2, 0x0a, 0xad, 0x45, 0x1d, 0x22, 0x5e, 0xd8, 0x1f, 0x81, 0x99, 0x03,
+	0x93, 0x06, 0xd4, 0x5e, 0xe2, 0x38, 0xa9, 0x45, 0xf4, 0x19, 0xb9, 0x9d, 0x52, 0x6f, 0x84, 0x49,
+	0xea, 0xf1, 0xe2, 0xe3, 0xb9, 0x07, 0x46, 0xf3, 0x77, 0x03, 0x56, 0x8b, 0xf1, 0x09, 0x81, 0xf9,
+	0x5c, 0x2d, 0xe7, 0xf9, 0x9b, 0xab, 0xf7, 0x3e, 0x5c, 0xf2, 0xa9, 0xea, 0x9f, 0xa0, 0xb4, 0x6a,
+	0x3a, 0xb1, 0xfa, 0x24, 0xb1, 0x4e, 0x64, 0x70, 0x52, 0x3b, 0xb9, 0x05, 0x44, 0xa2, 0x87, 0x7d,
+	0xc5, 0x02, 0xee, 0x4a, 0x25, 0xa8, 0xc2, 0xe1, 0x38, 0x29, 0xd6, 0x95, 0xcc, 0xd2, 0x4d, 0x0c,
+	0xe4, 0x01, 0x5c, 0x16, 0xa8, 0xc4, 0xd8, 0x0d, 0x03, 0x8f, 0xf5, 0xc7, 0xd6, 0xc2, 0xb6, 0xb1,
+	0x63, 0xb6, 0xd7, 0x27, 0xf4, 0x4e, 0x64, 0x7d, 0xa6, 0x8d, 0x8e, 0x29, 0x26, 0x8b, 0xe6, 0x5f,
+	0x06, 0x98, 0x39, 0x23, 0x79, 0x07, 0x2e, 0xfb, 0xf4, 0xcc, 0xa5, 0x4a, 0x45, 0x77, 0x53, 0xea,
+	0xcc, 0x56, 0x1c, 0xd3, 0xa7, 0x67, 0x8f, 0x93, 0x2d, 0x72, 0x13, 0x08, 0xe3, 0x4c, 0x31, 0xea,
+	0xb9, 0x3d, 0xda, 0x7f, 0x19, 0x1c, 0x1f, 0xbb, 0xbe, 0xd4, 0xb9, 0xae, 0x38, 0x8d, 0xc4, 0xf2,
+	0x59, 0x6c, 0xe8, 0x48, 0x72, 0x03, 0x56, 0x23, 0xc2, 0x1c, 0xb2, 0xa6, 0x91, 0x51, 0x98, 0x09,
+	0xea, 0x16, 0x90, 0x0c, 0x31, 0xf2, 0x14, 0x0b, 0x3d, 0x86, 0x42, 0xe7, 0x6b, 0x38, 0x57, 0x12,
+	0x4b, 0x27, 0x33, 0x90, 0x0d, 0x58, 0x7c, 0xc1, 0x94, 0x42, 0xa1, 0x33, 0x35, 0x9c, 0x64, 0x45,
+	0xde, 0x83, 0xba, 0x4e, 0x8e, 0xf6, 0x3c, 0x74, 0xfb, 0xc1, 0x00, 0xa5, 0xb5, 0xb8, 0x5d, 0xdb,
+	0x59, 0x76, 0x56, 0xb3, 0xed, 0xbd, 0x68, 0xb7, 0xf9, 0xda, 0x80, 0x05, 0x5d, 0x72, 0x72, 0x08,
+	0x75, 0x19, 0x8c, 0x44, 0x1f, 0xdd, 0xb8, 0xac, 0x81, 0xb0, 0x0c, 0xdd, 0x9c, 0xeb, 0x53, 0xcd,
+	0x69, 0x75, 0x35, 0xac, 0x9b, 0xa0, 0xe2, 0xcb, 0xb6, 0x2a, 0x0b, 0x9b, 0xe4, 0x16, 0x2c, 0x8a,
+	0x60, 0xa4, 0x30, 0xaa, 0x47, 0xad, 0xd8, 0x82, 0x7d, 0x94, 0x8a, 0x71, 0x1a, 0xf5, 0xcd, 0x49,
+	0x40, 0xf6, 0x63, 0x58, 0x2b, 0x61, 0xbd, 0xd0, 0xad, 0xfc, 0xcd, 0x00, 0x33, 0x47, 0x4d, 0x28,
+	0x5c, 0x1d, 0x4c, 0x96, 0xd3, 0x49, 0xb5, 0x4a, 0xcf, 0x93, 0xff, 0x2e, 0xe6, 0xb7, 0x36, 0x98,
+	0xb5, 0x44, 0xd5, 0x7f, 0x85, 0x6c, 0x78, 0xa2, 0x92, 0xa6, 0x27, 0x2b, 0xfb, 0x09, 0x58, 0x55,
+	0x44, 0x17, 0x4a, 0xe9, 0x67, 0x03, 0xd6, 0x8f, 0xca, 0x24, 0xa4, 0x74, 0xde, 0x1a, 0x50, 0x1b,
+	0x09, 0x2f, 0x61, 0x89, 0x3e, 0xc9, 0x7d, 0x58, 0xf6, 0xa8, 0x54, 0xae, 0x44, 0xe4, 0xfa, 0xb6,
+	0x99, 0x6d, 0xbb, 0x35, 0x0c, 0x82, 0xa1, 0x87, 0xad, 0x54, 0x52, 0x5b, 0x5f, 0xa5, 0x0a, 0xea,
+	0x2c, 0x45, 0xe0, 0x2e, 0x22, 0x9f, 0x48, 0xc6, 0x7c, 0x4e, 0x32, 0xc8, 0x07, 0x70, 0x45, 0xa0,
+	0x1f, 0x28, 0x74, 0x7d, 0xec, 0x9f, 0x50, 0xce, 0xa4, 0x1f, 0x2b, 0xd3, 0xb2, 0xd3, 0x88, 0x0d,
+	0x9d, 0x6c, 0xbf, 0x79, 0x1f, 0x1a, 0x0e, 0xfa, 0xc1, 0x29, 0x1e, 0x75, 0x0f, 0x1c, 0xfc, 0x76,
+	0x84, 0x52, 0xcd, 0x8a, 0x9e, 0x31, 0x2b, 0x7a, 0xcd, 0x7b, 0x50, 0xdf, 0x17, 0x94, 0xf1, 0x8b,
+	0xfa, 0x75, 0x60, 0xeb, 0x09, 0xe3, 0x83, 0x62, 0xbd, 0x52, 0x86, 0x0b, 0x6b, 0x7f, 0xf3, 0x97,
+	0x1a, 0xd8, 0x65, 0x7c, 0x32, 0x0c, 0xb8, 0x2c, 0x88, 0x9b, 0x51, 0x14, 0xb7, 0xc7, 0x50, 0x9f,
+	0x0a, 0xa5, 0x5b, 0x62, 0xb6, 0xad, 0x2a, 0xf5, 0x76, 0x56, 0x8b, 0xf1, 0xc9, 0x77, 0x60, 0x55,
+	0xbc, 0x2e, 0xa9, 0x60, 0x3e, 0x9a, 0x70, 0x55, 0x1f, 0xb2, 0x55, 0x7a, 0x77, 0x92, 0xd7, 0x61,
+	0xa3, 0xf4, 0x6d, 0x92, 0xe4, 0x1b, 0xd8, 0x9a, 0x8e, 0x9d, 0x96, 0x59, 0x5a, 0xf3, 0x3a, 0xf8,
+	0xf6, 0x79, 0xcf, 0x90, 0xb3, 0xc9, 0x4b, 0xf7, 0xa5, 0xfd, 0x02, 0xfe, 0xf7, 0x86, 0x43, 0x95,
+	0x0c, 0xc7, 0x87, 0xf9, 0xe1, 0x30, 0xdb, 0xff, 0xaf, 0x0a, 0x9d, 0xf0, 0xe4, 0xa7, 0xe7, 0x39,
+	0x90, 0x22, 0xe6, 0x90, 0x49, 0x45, 0xf6, 0xa0, 0x31, 0x95, 0x9f, 0x4c, 0x24, 0xa1, 0xba, 0x3f,
+	0xf5, 0x62, 0x3a, 0xb2, 0xd9, 0x82, 0xc6, 0xc1, 0x59, 0xdf, 0x1b, 0x0d, 0x70, 0xf0, 0x4c, 0xe0,
+	0x31, 0x3b, 0x43, 0x49, 0x6c, 0x58, 0x0a, 0x93, 0x6f, 0x4d, 0xb8, 0xec, 0x64, 0xeb, 0xe6, 0xaf,
+	0x06, 0x90, 0x18, 0x78, 0x88, 0x54, 0xfe, 0xf3, 0x5b, 0x19, 0x0d, 0x66, 0xf0, 0x8a, 0xa3, 0x48,
+	0xb5, 0x42, 0x2f, 0x0a, 0xa1, 0x6b, 0xc5, 0xd0, 0xe4, 0x5d, 0xa8, 0xb3, 0xf0, 0xf4, 0xae, 0x1b,
+	0x6f, 0xb8, 0x1e, 0x72, 0x3d, 0xd4, 0x2b, 0xce, 0x4a, 0xb4, 0x9d, 0x1e, 0x8a, 0x27, 0xb8, 0x7b,
+	0x79, 0xdc, 0x42, 0x86, 0xbb, 0x97, 0xe1, 0x9a, 0x3f, 0x1a, 0x60, 0xe6, 0x52, 0xf9, 0x4f, 0x72,
+	0xb8, 0x0b, 0x97, 0xf0, 0x2c, 0x64, 0x02, 0xa5, 0x35, 0x7f, 0xae, 0x8a, 0xa5, 0xd0, 0xe6, 0xf7,
+	0x73, 0x50, 0xd7, 0x22, 0xa2, 0x9b, 0x1a, 0x3f, 0x0a, 0x25, 0xc3, 0x69, 0x5c, 0x70, 0x38, 0xbf,
+	0x86, 0xcd, 0x8a, 0xe1, 0x7c, 0xdb, 0x3b, 0xba, 0x5e, 0x3a, 0x7a, 0xe4, 0x79, 0x46, 0x3c, 0x3d,
+	0x78, 0x89, 0x76, 0x9f, 0x3f, 0x77, 0x1b, 0x45, 0x82, 0x74, 0xbf, 0xfd, 0x87, 0x31, 0xfd, 0xaf,
+	0x38, 0xa9, 0xca, 0x98, 0xec, 0x81, 0x19, 0x7f, 0xa3, 0x38, 0xea, 0x1e, 0x90, 0xad, 0x5c, 0x8c,
+	0x62, 0xed, 0xec, 0x6a, 0x13, 0x79, 0x08, 0xcb, 0x99, 0xd8, 0x13, 0x3b, 0xff, 0x6f, 0xab, 0xf8,
+	0x02, 0xd8, 0x1b, 0x33, 0x8d, 0x3b, 0x88, 0xfe, 0xf9, 0x93, 0x4f, 0x61, 0x29, 0x15, 0xfd, 0xfc,
+	0x11, 0xa6, 0x1e, 0x82, 0x2a, 0xf7, 0xf6, 0x6b, 0x03, 0x36, 0x8b, 0xf9, 0xed, 0x33, 0xd9, 0x0f,
+	0x4e, 0x51, 0x8c, 0x89, 0x0b, 0x64, 0x56, 0x22, 0xc9, 0xf5, 0x37, 0x0b, 0x68, 0x1c, 0xee, 0xc6,
+	0xdb, 0xa8, 0x2c, 0xf9, 0x02, 0xd6, 0x22, 0x65, 0x29, 0x5a, 0x25, 0xa9, 0x38, 0xab, 0x7d, 0xad,
+	0xaa, 0x8b, 0x11, 0x49, 0xfb, 0x27, 0x03, 0xcc, 0x3d, 0x6f, 0x14, 0xb5, 0xe3, 0x29, 0x3f, 0x0e,
+	0xc8, 0x53, 0x58, 0xfb, 0x1c, 0xd5, 0x8c, 0xd8, 0x54, 0x91, 0xe7, 0x6a, 0x3f, 0xe3, 0xf3, 0x25,
+	0x6c, 0x76, 0x02, 0xce, 0xa2, 0x7f, 0x22, 0xff, 0x02, 0xdd, 0x6d, 0xa3, 0xfd, 0x83, 0x01, 0x6b,
+	0x05, 0x55, 0x4b, 0xae, 0xd4, 0x3e, 0xac, 0xe8, 0x8d, 0x8c, 0x3e, 0x97, 0xf2, 0xac, 0x0a, 0xda,
+	0xeb, 0xa5, 0x56, 0xf2, 0x08, 0xea, 0x0e, 0x7a, 0x05, 0x9e, 0x72, 0x64, 0xd5, 0xad, 0xe8, 0x2d,
+	0xea, 0xf5, 0x9d, 0xbf, 0x07, 0x00, 0xbf, 0xc1, 0xff, 0xe8, 0xa0, 0x0e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -596,6 +984,7 @@ const _ = grpc.SupportPackageIsVersion4
 type NetworkServiceRegistryClient interface {
 	RegisterNSE(ctx context.Context, in *NSERegistration, opts ...grpc.CallOption) (*NSERegistration, error)
 	RemoveNSE(ctx context.Context, in *RemoveNSERequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DrainNSE(ctx context.Context, in *DrainNSERequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type networkServiceRegistryClient struct {
@@ -624,10 +1013,20 @@ func (c *networkServiceRegistryClient) RemoveNSE(ctx context.Context, in *Remove
 	return out, nil
 }
 
+func (c *networkServiceRegistryClient) DrainNSE(ctx context.Context, in *DrainNSERequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/registry.NetworkServiceRegistry/DrainNSE", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServiceRegistryServer is the server API for NetworkServiceRegistry service.
 type NetworkServiceRegistryServer interface {
 	RegisterNSE(context.Context, *NSERegistration) (*NSERegistration, error)
 	RemoveNSE(context.Context, *RemoveNSERequest) (*empty.Empty, error)
+	DrainNSE(context.Context, *DrainNSERequest) (*empty.Empty, error)
 }
 
 func RegisterNetworkServiceRegistryServer(s *grpc.Server, srv NetworkServiceRegistryServer) {
@@ -670,6 +1069,24 @@ func _NetworkServiceRegistry_RemoveNSE_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServiceRegistry_DrainNSE_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainNSERequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceRegistryServer).DrainNSE(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/registry.NetworkServiceRegistry/DrainNSE",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceRegistryServer).DrainNSE(ctx, req.(*DrainNSERequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkServiceRegistry_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "registry.NetworkServiceRegistry",
 	HandlerType: (*NetworkServiceRegistryServer)(nil),
@@ -682,6 +1099,10 @@ var _NetworkServiceRegistry_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveNSE",
 			Handler:    _NetworkServiceRegistry_RemoveNSE_Handler,
 		},
+		{
+			MethodName: "DrainNSE",
+			Handler:    _NetworkServiceRegistry_DrainNSE_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "registry.proto",
@@ -692,6 +1113,7 @@ var _NetworkServiceRegistry_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type NetworkServiceDiscoveryClient interface {
 	FindNetworkService(ctx context.Context, in *FindNetworkServiceRequest, opts ...grpc.CallOption) (*FindNetworkServiceResponse, error)
+	ListNetworkServices(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*NetworkServiceList, error)
 }
 
 type networkServiceDiscoveryClient struct {
@@ -711,9 +1133,19 @@ func (c *networkServiceDiscoveryClient) FindNetworkService(ctx context.Context,
 	return out, nil
 }
 
+func (c *networkServiceDiscoveryClient) ListNetworkServices(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*NetworkServiceList, error) {
+	out := new(NetworkServiceList)
+	err := c.cc.Invoke(ctx, "/registry.NetworkServiceDiscovery/ListNetworkServices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServiceDiscoveryServer is the server API for NetworkServiceDiscovery service.
 type NetworkServiceDiscoveryServer interface {
 	FindNetworkService(context.Context, *FindNetworkServiceRequest) (*FindNetworkServiceResponse, error)
+	ListNetworkServices(context.Context, *empty.Empty) (*NetworkServiceList, error)
 }
 
 func RegisterNetworkServiceDiscoveryServer(s *grpc.Server, srv NetworkServiceDiscoveryServer) {
@@ -738,6 +1170,24 @@ func _NetworkServiceDiscovery_FindNetworkService_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServiceDiscovery_ListNetworkServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceDiscoveryServer).ListNetworkServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/registry.NetworkServiceDiscovery/ListNetworkServices",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceDiscoveryServer).ListNetworkServices(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkServiceDiscovery_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "registry.NetworkServiceDiscovery",
 	HandlerType: (*NetworkServiceDiscoveryServer)(nil),
@@ -746,6 +1196,232 @@ var _NetworkServiceDiscovery_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FindNetworkService",
 			Handler:    _NetworkServiceDiscovery_FindNetworkService_Handler,
 		},
+		{
+			MethodName: "ListNetworkServices",
+			Handler:    _NetworkServiceDiscovery_ListNetworkServices_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "registry.proto",
+}
+
+// ClusterInfoClient is the client API for ClusterInfo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ClusterInfoClient interface {
+	GetExcludedPrefixes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ExcludedPrefixes, error)
+	MonitorExcludedPrefixes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (ClusterInfo_MonitorExcludedPrefixesClient, error)
+}
+
+type clusterInfoClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewClusterInfoClient(cc *grpc.ClientConn) ClusterInfoClient {
+	return &clusterInfoClient{cc}
+}
+
+func (c *clusterInfoClient) GetExcludedPrefixes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ExcludedPrefixes, error) {
+	out := new(ExcludedPrefixes)
+	err := c.cc.Invoke(ctx, "/registry.ClusterInfo/GetExcludedPrefixes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterInfoClient) MonitorExcludedPrefixes(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (ClusterInfo_MonitorExcludedPrefixesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ClusterInfo_serviceDesc.Streams[0], "/registry.ClusterInfo/MonitorExcludedPrefixes", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &clusterInfoMonitorExcludedPrefixesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ClusterInfo_MonitorExcludedPrefixesClient interface {
+	Recv() (*ExcludedPrefixes, error)
+	grpc.ClientStream
+}
+
+type clusterInfoMonitorExcludedPrefixesClient struct {
+	grpc.ClientStream
+}
+
+func (x *clusterInfoMonitorExcludedPrefixesClient) Recv() (*ExcludedPrefixes, error) {
+	m := new(ExcludedPrefixes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ClusterInfoServer is the server API for ClusterInfo service.
+type ClusterInfoServer interface {
+	GetExcludedPrefixes(context.Context, *empty.Empty) (*ExcludedPrefixes, error)
+	MonitorExcludedPrefixes(*empty.Empty, ClusterInfo_MonitorExcludedPrefixesServer) error
+}
+
+func RegisterClusterInfoServer(s *grpc.Server, srv ClusterInfoServer) {
+	s.RegisterService(&_ClusterInfo_serviceDesc, srv)
+}
+
+func _ClusterInfo_GetExcludedPrefixes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterInfoServer).GetExcludedPrefixes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/registry.ClusterInfo/GetExcludedPrefixes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterInfoServer).GetExcludedPrefixes(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterInfo_MonitorExcludedPrefixes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ClusterInfoServer).MonitorExcludedPrefixes(m, &clusterInfoMonitorExcludedPrefixesServer{stream})
+}
+
+type ClusterInfo_MonitorExcludedPrefixesServer interface {
+	Send(*ExcludedPrefixes) error
+	grpc.ServerStream
+}
+
+type clusterInfoMonitorExcludedPrefixesServer struct {
+	grpc.ServerStream
+}
+
+func (x *clusterInfoMonitorExcludedPrefixesServer) Send(m *ExcludedPrefixes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ClusterInfo_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "registry.ClusterInfo",
+	HandlerType: (*ClusterInfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetExcludedPrefixes",
+			Handler:    _ClusterInfo_GetExcludedPrefixes_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "MonitorExcludedPrefixes",
+			Handler:       _ClusterInfo_MonitorExcludedPrefixes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "registry.proto",
+}
+
+// PrefixLeaseRegistryClient is the client API for PrefixLeaseRegistry service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PrefixLeaseRegistryClient interface {
+	LeasePrefixes(ctx context.Context, in *PrefixLeaseRequest, opts ...grpc.CallOption) (*PrefixLease, error)
+	ReleasePrefixes(ctx context.Context, in *PrefixLease, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type prefixLeaseRegistryClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPrefixLeaseRegistryClient(cc *grpc.ClientConn) PrefixLeaseRegistryClient {
+	return &prefixLeaseRegistryClient{cc}
+}
+
+func (c *prefixLeaseRegistryClient) LeasePrefixes(ctx context.Context, in *PrefixLeaseRequest, opts ...grpc.CallOption) (*PrefixLease, error) {
+	out := new(PrefixLease)
+	err := c.cc.Invoke(ctx, "/registry.PrefixLeaseRegistry/LeasePrefixes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prefixLeaseRegistryClient) ReleasePrefixes(ctx context.Context, in *PrefixLease, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/registry.PrefixLeaseRegistry/ReleasePrefixes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrefixLeaseRegistryServer is the server API for PrefixLeaseRegistry service.
+type PrefixLeaseRegistryServer interface {
+	LeasePrefixes(context.Context, *PrefixLeaseRequest) (*PrefixLease, error)
+	ReleasePrefixes(context.Context, *PrefixLease) (*empty.Empty, error)
+}
+
+func RegisterPrefixLeaseRegistryServer(s *grpc.Server, srv PrefixLeaseRegistryServer) {
+	s.RegisterService(&_PrefixLeaseRegistry_serviceDesc, srv)
+}
+
+func _PrefixLeaseRegistry_LeasePrefixes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefixLeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrefixLeaseRegistryServer).LeasePrefixes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/registry.PrefixLeaseRegistry/LeasePrefixes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrefixLeaseRegistryServer).LeasePrefixes(ctx, req.(*PrefixLeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PrefixLeaseRegistry_ReleasePrefixes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrefixLease)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrefixLeaseRegistryServer).ReleasePrefixes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/registry.PrefixLeaseRegistry/ReleasePrefixes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrefixLeaseRegistryServer).ReleasePrefixes(ctx, req.(*PrefixLease))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PrefixLeaseRegistry_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "registry.PrefixLeaseRegistry",
+	HandlerType: (*PrefixLeaseRegistryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "LeasePrefixes",
+			Handler:    _PrefixLeaseRegistry_LeasePrefixes_Handler,
+		},
+		{
+			MethodName: "ReleasePrefixes",
+			Handler:    _PrefixLeaseRegistry_ReleasePrefixes_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "registry.proto",
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	connectioncontext "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	grpc "google.golang.org/grpc"
 	math "math"
@@ -176,6 +177,7 @@ type Connection struct {
 	DestinationNetworkServiceManagerName string                               `protobuf:"bytes,7,opt,name=destination_network_service_manager_name,json=destinationNetworkServiceManagerName,proto3" json:"destination_network_service_manager_name,omitempty"`
 	NetworkServiceEndpointName           string                               `protobuf:"bytes,8,opt,name=network_service_endpoint_name,json=networkServiceEndpointName,proto3" json:"network_service_endpoint_name,omitempty"`
 	State                                State                                `protobuf:"varint,9,opt,name=state,proto3,enum=remote.connection.State" json:"state,omitempty"`
+	Expires                              *timestamp.Timestamp                 `protobuf:"bytes,10,opt,name=expires,proto3" json:"expires,omitempty"`
 	XXX_NoUnkeyedLiteral                 struct{}                             `json:"-"`
 	XXX_unrecognized                     []byte                               `json:"-"`
 	XXX_sizecache                        int32                                `json:"-"`
@@ -269,6 +271,13 @@ func (m *Connection) GetState() State {
 	return State_UP
 }
 
+func (m *Connection) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
 type ConnectionEvent struct {
 	Type                 ConnectionEventType    `protobuf:"varint,1,opt,name=type,proto3,enum=remote.connection.ConnectionEventType" json:"type,omitempty"`
 	Connections          map[string]*Connection `protobuf:"bytes,2,rep,name=connections,proto3" json:"connections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
@@ -371,52 +380,56 @@ func init() {
 func init() { proto.RegisterFile("connection.proto", fileDescriptor_51baa40a1cc6b48b) }
 
 var fileDescriptor_51baa40a1cc6b48b = []byte{
-	// 716 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x6d, 0x6b, 0xdb, 0x48,
-	0x10, 0x8e, 0xe4, 0xb7, 0x78, 0x9c, 0x97, 0xcd, 0x5e, 0x38, 0x14, 0x93, 0x70, 0x26, 0x17, 0x2e,
-	0xbe, 0x70, 0xc8, 0x87, 0x73, 0x1c, 0x77, 0x81, 0xb6, 0xb8, 0xb1, 0x1a, 0x0c, 0xb6, 0x62, 0x24,
-	0x3b, 0x29, 0x85, 0x62, 0x14, 0x79, 0x89, 0x45, 0xac, 0x5d, 0x21, 0xad, 0xd3, 0xf8, 0x73, 0xff,
-	0x5f, 0x3f, 0xf7, 0xe7, 0x14, 0xad, 0x14, 0x4b, 0x7e, 0x89, 0x43, 0xbf, 0xed, 0xce, 0x3c, 0xf3,
-	0xcc, 0xcc, 0xb3, 0xb3, 0x03, 0xc8, 0x66, 0x94, 0x12, 0x9b, 0x3b, 0x8c, 0xaa, 0x9e, 0xcf, 0x38,
-	0xc3, 0x7b, 0x3e, 0x71, 0x19, 0x27, 0x6a, 0xe2, 0x28, 0x7b, 0xf7, 0x0e, 0x1f, 0x4d, 0xee, 0x54,
-	0x9b, 0xb9, 0x35, 0x4a, 0xf8, 0x17, 0xe6, 0x3f, 0x04, 0xc4, 0x7f, 0x74, 0x6c, 0xe2, 0x92, 0x60,
-	0xb4, 0xca, 0x64, 0x33, 0xca, 0x7d, 0x36, 0xf6, 0xc6, 0x16, 0x25, 0x35, 0xef, 0xe1, 0xbe, 0x66,
-	0x79, 0x4e, 0x50, 0x4b, 0x28, 0x43, 0x3f, 0x79, 0xe2, 0xcb, 0x96, 0xa8, 0x88, 0xe3, 0x6f, 0x12,
-	0x14, 0x3b, 0xc4, 0x1e, 0x59, 0xd4, 0x09, 0x5c, 0xfc, 0x0f, 0x64, 0xf9, 0xd4, 0x23, 0x8a, 0x54,
-	0x91, 0xaa, 0x3b, 0xf5, 0x8a, 0xba, 0x54, 0xa1, 0x3a, 0xc3, 0xf6, 0xa6, 0x1e, 0x31, 0x04, 0x1a,
-	0xb7, 0x01, 0x3c, 0xcb, 0xb7, 0x5c, 0xc2, 0x89, 0x1f, 0x28, 0x72, 0x25, 0x53, 0x2d, 0xd5, 0xff,
-	0x5a, 0x17, 0xab, 0x76, 0x67, 0x70, 0x8d, 0x72, 0x7f, 0x6a, 0xa4, 0xe2, 0xcb, 0x6f, 0x60, 0x77,
-	0xc1, 0x8d, 0x11, 0x64, 0x1e, 0xc8, 0x54, 0x54, 0x55, 0x34, 0xc2, 0x23, 0xde, 0x87, 0xdc, 0xa3,
-	0x35, 0x9e, 0x10, 0x45, 0x16, 0xb6, 0xe8, 0x72, 0x21, 0xff, 0x27, 0x1d, 0x7f, 0xcf, 0x02, 0x5c,
-	0xce, 0x72, 0xe2, 0x1d, 0x90, 0x9d, 0x61, 0x1c, 0x29, 0x3b, 0x43, 0x7c, 0x0a, 0xbb, 0xb1, 0x8a,
-	0x83, 0x58, 0xc6, 0x98, 0x62, 0x27, 0x36, 0x9b, 0x91, 0x15, 0x5f, 0x40, 0xd1, 0x7d, 0xae, 0x57,
-	0xc9, 0x54, 0xa4, 0x6a, 0xa9, 0x7e, 0xb8, 0xae, 0x27, 0x23, 0x81, 0xe3, 0xb7, 0x50, 0x88, 0x55,
-	0x56, 0xb2, 0x22, 0xf2, 0x44, 0x5d, 0xd6, 0x3f, 0x29, 0xf2, 0x32, 0xb2, 0x18, 0xcf, 0x41, 0xb8,
-	0x01, 0xf9, 0xb1, 0x75, 0x47, 0xc6, 0x81, 0x92, 0x13, 0x62, 0xfe, 0xb9, 0x22, 0x71, 0x12, 0xae,
-	0xb6, 0x05, 0x36, 0x52, 0x32, 0x0e, 0xc4, 0x6d, 0xf8, 0x3d, 0x60, 0x13, 0xdf, 0x26, 0x83, 0x85,
-	0x76, 0x07, 0xae, 0x45, 0xad, 0x7b, 0xe2, 0x0f, 0xa8, 0xe5, 0x12, 0x25, 0x2f, 0x7a, 0xff, 0x2d,
-	0x82, 0xea, 0x73, 0x0a, 0x74, 0x22, 0x9c, 0x6e, 0xb9, 0x04, 0xdf, 0x40, 0x75, 0x48, 0x02, 0xee,
-	0x50, 0x2b, 0x4c, 0xb8, 0x9e, 0xb2, 0x20, 0x28, 0x4f, 0x52, 0xf8, 0x97, 0x79, 0x1b, 0x70, 0xb4,
-	0xc8, 0x45, 0xe8, 0xd0, 0x63, 0x0e, 0xe5, 0x11, 0xd9, 0xa6, 0x20, 0x2b, 0xcf, 0xbf, 0x8d, 0x16,
-	0x43, 0x04, 0x85, 0x0a, 0xb9, 0x80, 0x5b, 0x9c, 0x28, 0x45, 0x31, 0xb3, 0xca, 0x0a, 0xa9, 0xcc,
-	0xd0, 0x6f, 0x44, 0xb0, 0xf2, 0xff, 0x50, 0x4a, 0xe9, 0xf5, 0x53, 0xa3, 0xf5, 0x55, 0x86, 0xdd,
-	0x44, 0x76, 0xed, 0x91, 0x50, 0x8e, 0x2f, 0xe6, 0x7e, 0xcc, 0x1f, 0x6b, 0x1f, 0x4a, 0x44, 0xa4,
-	0xfe, 0x4d, 0x1f, 0x4a, 0x09, 0xee, 0xf9, 0xe3, 0x9c, 0xbf, 0x4e, 0x91, 0xba, 0xc7, 0xaf, 0x9e,
-	0xe6, 0x29, 0x7f, 0x06, 0xb4, 0x08, 0x58, 0xd1, 0xe6, 0x79, 0xba, 0xcd, 0x52, 0xfd, 0x68, 0x6d,
-	0xda, 0xb4, 0x0a, 0xb7, 0xb0, 0xdf, 0x61, 0xd4, 0xe1, 0xcc, 0x37, 0x6d, 0xe6, 0x11, 0x93, 0x8c,
-	0x89, 0xcd, 0x99, 0x8f, 0xdf, 0xc1, 0xe1, 0xda, 0xb9, 0x88, 0x72, 0x1f, 0xd0, 0x97, 0x86, 0xe1,
-	0x6c, 0x02, 0xdb, 0x73, 0xdb, 0x05, 0x6f, 0x42, 0x56, 0xbf, 0xd6, 0x35, 0xb4, 0x81, 0x8b, 0x90,
-	0xbb, 0xf9, 0xd8, 0x6e, 0xe8, 0x48, 0xc2, 0xdb, 0x50, 0x14, 0xc7, 0xc1, 0x55, 0x57, 0x43, 0x32,
-	0x2e, 0x40, 0xe6, 0xca, 0xd0, 0x50, 0x26, 0x04, 0x9b, 0xc6, 0xcd, 0xbf, 0x28, 0x8b, 0xf7, 0x60,
-	0xbb, 0xd3, 0x6d, 0x9b, 0x4c, 0xe3, 0x23, 0xe2, 0x53, 0xc2, 0x51, 0x0e, 0x6f, 0xc1, 0xa6, 0x30,
-	0x85, 0xd0, 0xfc, 0xec, 0xd6, 0x6f, 0x76, 0x51, 0xe1, 0xec, 0x00, 0x72, 0x62, 0x40, 0x70, 0x1e,
-	0xe4, 0x7e, 0x17, 0x6d, 0x84, 0x4c, 0xcd, 0xeb, 0x5b, 0x1d, 0x49, 0x67, 0x2d, 0xf8, 0x65, 0xc5,
-	0xeb, 0xe1, 0x32, 0xfc, 0xda, 0xd2, 0x5b, 0xbd, 0x56, 0xa3, 0x3d, 0x30, 0x7b, 0x8d, 0x9e, 0x36,
-	0xe8, 0x19, 0x0d, 0xdd, 0xfc, 0xa0, 0x19, 0x68, 0x03, 0x03, 0xe4, 0xfb, 0xdd, 0x66, 0xa3, 0xa7,
-	0x21, 0x29, 0x3c, 0x37, 0xb5, 0xb6, 0xd6, 0xd3, 0x90, 0x5c, 0x7f, 0x82, 0xbd, 0x58, 0xb5, 0xd4,
-	0x72, 0xb2, 0x01, 0x2f, 0x19, 0x03, 0x7c, 0xba, 0x6a, 0xcd, 0xac, 0x50, 0xbc, 0x7c, 0xfc, 0xfa,
-	0xa8, 0xfc, 0x2d, 0xbd, 0xdf, 0xfa, 0x04, 0x89, 0xff, 0x2e, 0x2f, 0xd6, 0xfe, 0xf9, 0x8f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x58, 0xe9, 0xdf, 0x40, 0x8f, 0x06, 0x00, 0x00,
+	// 769 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x6d, 0x6f, 0xdb, 0x54,
+	0x14, 0xae, 0x9d, 0x26, 0x69, 0x4e, 0xd6, 0xd6, 0xbd, 0x4c, 0xc8, 0xb3, 0x36, 0x51, 0x95, 0x89,
+	0x95, 0x0a, 0x39, 0x28, 0x9d, 0x10, 0x14, 0x01, 0x0a, 0xab, 0x99, 0x22, 0x25, 0x5e, 0x64, 0x3b,
+	0x1d, 0x42, 0x42, 0x91, 0xe3, 0x1c, 0x12, 0xab, 0xf6, 0xbd, 0x96, 0x7d, 0x53, 0x9a, 0xcf, 0x7c,
+	0xe7, 0xa7, 0xf1, 0x9b, 0x90, 0xaf, 0x9d, 0xd8, 0x79, 0x59, 0xa6, 0x7d, 0xbb, 0xf7, 0x9c, 0xe7,
+	0x79, 0xce, 0x3d, 0x6f, 0x17, 0x14, 0x8f, 0x51, 0x8a, 0x1e, 0xf7, 0x19, 0xd5, 0xa3, 0x98, 0x71,
+	0x46, 0xce, 0x62, 0x0c, 0x19, 0x47, 0xbd, 0x70, 0x68, 0xd1, 0xd4, 0xe7, 0xb3, 0xf9, 0x58, 0xf7,
+	0x58, 0xd8, 0xa2, 0xc8, 0xff, 0x66, 0xf1, 0x7d, 0x82, 0xf1, 0x83, 0xef, 0x61, 0x88, 0xc9, 0x6c,
+	0x97, 0xc9, 0x63, 0x94, 0xc7, 0x2c, 0x88, 0x02, 0x97, 0x62, 0x2b, 0xba, 0x9f, 0xb6, 0xdc, 0xc8,
+	0x4f, 0x5a, 0x85, 0x64, 0xea, 0xc7, 0x47, 0xbe, 0x6d, 0xc9, 0x1e, 0xa1, 0xfd, 0x58, 0x8a, 0x38,
+	0x65, 0x81, 0x4b, 0xa7, 0x2d, 0xe1, 0x18, 0xcf, 0xff, 0x6a, 0x45, 0x7c, 0x11, 0x61, 0xd2, 0xe2,
+	0x7e, 0x88, 0x09, 0x77, 0xc3, 0xa8, 0x38, 0x65, 0xe4, 0x8b, 0xff, 0x24, 0x68, 0xf4, 0xd1, 0x9b,
+	0xb9, 0xd4, 0x4f, 0x42, 0xf2, 0x1a, 0x0e, 0x53, 0x82, 0x2a, 0x9d, 0x4b, 0x97, 0x27, 0xed, 0x73,
+	0x7d, 0x2b, 0x3d, 0x7d, 0x85, 0x75, 0x16, 0x11, 0x5a, 0x02, 0x4d, 0x7a, 0x00, 0x91, 0x1b, 0xbb,
+	0x21, 0x72, 0x8c, 0x13, 0x55, 0x3e, 0xaf, 0x5c, 0x36, 0xdb, 0xdf, 0xec, 0xe3, 0xea, 0x83, 0x15,
+	0xdc, 0xa0, 0x3c, 0x5e, 0x58, 0x25, 0xbe, 0xf6, 0x13, 0x9c, 0x6e, 0xb8, 0x89, 0x02, 0x95, 0x7b,
+	0x5c, 0x88, 0x57, 0x35, 0xac, 0xf4, 0x48, 0x9e, 0x42, 0xf5, 0xc1, 0x0d, 0xe6, 0xa8, 0xca, 0xc2,
+	0x96, 0x5d, 0x6e, 0xe4, 0xef, 0xa5, 0x8b, 0x7f, 0xab, 0x00, 0x6f, 0x56, 0x31, 0xc9, 0x09, 0xc8,
+	0xfe, 0x24, 0x67, 0xca, 0xfe, 0x84, 0xbc, 0x82, 0xd3, 0xbc, 0x05, 0xa3, 0xbc, 0x07, 0xb9, 0xc4,
+	0x49, 0x6e, 0xb6, 0x33, 0x2b, 0xb9, 0x81, 0x46, 0xb8, 0x7c, 0xaf, 0x5a, 0x39, 0x97, 0x2e, 0x9b,
+	0xed, 0xe7, 0xfb, 0x72, 0xb2, 0x0a, 0x38, 0xf9, 0x19, 0xea, 0x79, 0x8b, 0xd4, 0x43, 0xc1, 0x7c,
+	0xa9, 0x6f, 0x37, 0xaf, 0x78, 0xe4, 0x9b, 0xcc, 0x62, 0x2d, 0x49, 0xa4, 0x03, 0xb5, 0xc0, 0x1d,
+	0x63, 0x90, 0xa8, 0x55, 0x51, 0xcc, 0xaf, 0x77, 0x04, 0x2e, 0xe8, 0x7a, 0x4f, 0x60, 0xb3, 0x4a,
+	0xe6, 0x44, 0xd2, 0x83, 0x2f, 0x13, 0x36, 0x8f, 0x3d, 0x1c, 0x6d, 0xa4, 0x3b, 0x0a, 0x5d, 0xea,
+	0x4e, 0x31, 0x1e, 0x51, 0x37, 0x44, 0xb5, 0x26, 0x72, 0xff, 0x22, 0x83, 0x9a, 0x6b, 0x15, 0xe8,
+	0x67, 0x38, 0xd3, 0x0d, 0x91, 0xdc, 0xc1, 0xe5, 0x04, 0x13, 0xee, 0x53, 0x37, 0x0d, 0xb8, 0x5f,
+	0xb2, 0x2e, 0x24, 0x5f, 0x96, 0xf0, 0x1f, 0xd6, 0xed, 0xc0, 0x8b, 0x4d, 0x2d, 0xa4, 0x93, 0x88,
+	0xf9, 0x94, 0x67, 0x62, 0x47, 0x42, 0x4c, 0x5b, 0xef, 0x8d, 0x91, 0x43, 0x84, 0x84, 0x0e, 0xd5,
+	0x84, 0xbb, 0x1c, 0xd5, 0x86, 0x98, 0x59, 0x75, 0x47, 0xa9, 0xec, 0xd4, 0x6f, 0x65, 0x30, 0xf2,
+	0x1a, 0xea, 0xf8, 0x18, 0xf9, 0x31, 0x26, 0x2a, 0x88, 0xde, 0x68, 0xfa, 0x94, 0xb1, 0x69, 0x80,
+	0xfa, 0x72, 0x69, 0x74, 0x67, 0xb9, 0x23, 0xd6, 0x12, 0xaa, 0xfd, 0x00, 0xcd, 0x52, 0x95, 0x3f,
+	0x69, 0x20, 0xff, 0x91, 0xe1, 0xb4, 0x68, 0x96, 0xf1, 0x80, 0x94, 0x93, 0x9b, 0xb5, 0x3d, 0xfb,
+	0x6a, 0x6f, 0x7b, 0x05, 0xa3, 0xb4, 0x6d, 0x43, 0x68, 0x16, 0xb8, 0xe5, 0xba, 0x5d, 0x7f, 0x5c,
+	0xa2, 0x74, 0xcf, 0x67, 0xa5, 0xac, 0xa3, 0xfd, 0x09, 0xca, 0x26, 0x60, 0x47, 0x9a, 0xd7, 0xe5,
+	0x34, 0x9b, 0xed, 0x17, 0x7b, 0xc3, 0x96, 0xab, 0xf0, 0x1e, 0x9e, 0xf6, 0x19, 0xf5, 0x39, 0x8b,
+	0x6d, 0x8f, 0x45, 0x68, 0x63, 0x80, 0x1e, 0x67, 0x31, 0xf9, 0x05, 0x9e, 0xef, 0x9d, 0xa6, 0x2c,
+	0xf6, 0x33, 0xfa, 0xa1, 0x11, 0xba, 0x9a, 0xc3, 0xf1, 0xda, 0x9f, 0x44, 0x8e, 0xe0, 0xd0, 0x7c,
+	0x67, 0x1a, 0xca, 0x01, 0x69, 0x40, 0xf5, 0xee, 0xf7, 0x5e, 0xc7, 0x54, 0x24, 0x72, 0x0c, 0x0d,
+	0x71, 0x1c, 0xbd, 0x1d, 0x18, 0x8a, 0x4c, 0xea, 0x50, 0x79, 0x6b, 0x19, 0x4a, 0x25, 0x05, 0xdb,
+	0xd6, 0xdd, 0x77, 0xca, 0x21, 0x39, 0x83, 0xe3, 0xfe, 0xa0, 0x67, 0x33, 0x83, 0xcf, 0x30, 0xa6,
+	0xc8, 0x95, 0x2a, 0x79, 0x02, 0x47, 0xc2, 0x94, 0x42, 0x6b, 0xab, 0xdb, 0xf0, 0x76, 0xa0, 0xd4,
+	0xaf, 0x9e, 0x41, 0x55, 0x8c, 0x15, 0xa9, 0x81, 0x3c, 0x1c, 0x28, 0x07, 0xa9, 0xd2, 0xed, 0xbb,
+	0xf7, 0xa6, 0x22, 0x5d, 0x75, 0xe1, 0xb3, 0x1d, 0xdd, 0x23, 0x1a, 0x7c, 0xde, 0x35, 0xbb, 0x4e,
+	0xb7, 0xd3, 0x1b, 0xd9, 0x4e, 0xc7, 0x31, 0x46, 0x8e, 0xd5, 0x31, 0xed, 0xdf, 0x0c, 0x4b, 0x39,
+	0x20, 0x00, 0xb5, 0xe1, 0xe0, 0xb6, 0xe3, 0x18, 0x8a, 0x94, 0x9e, 0x6f, 0x8d, 0x9e, 0xe1, 0x18,
+	0x8a, 0xdc, 0x7e, 0x84, 0xb3, 0xbc, 0x6a, 0xa5, 0x2f, 0xcd, 0x03, 0xb2, 0x65, 0x4c, 0xc8, 0xab,
+	0x5d, 0x9f, 0xd3, 0x8e, 0x8a, 0x6b, 0x17, 0x1f, 0x1f, 0x95, 0x6f, 0xa5, 0x5f, 0x9f, 0xfc, 0x01,
+	0x85, 0x7f, 0x5c, 0x13, 0xbb, 0x71, 0xfd, 0xff, 0x00, 0x69, 0x24, 0x7c, 0xe8, 0x02, 0x07, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2,7 +2,22 @@ package connection
 
 // Remote connection constants
 const (
+	// Tunnel endpoints, used by VXLAN, GRE, SRv6, MPLSoGRE and MPLSoUDP mechanisms
 	VXLANSrcIP = "src_ip"
 	VXLANDstIP = "dst_ip"
 	VXLANVNI   = "vni"
+
+	// GREKey - a key of GRE tunnel
+	GREKey = "gre_key"
+
+	// SRv6SrcLocalSID - a SID of source side of connection
+	SRv6SrcLocalSID = "src_local_sid"
+	// SRv6DstLocalSID - a SID of destination side of connection
+	SRv6DstLocalSID = "dst_local_sid"
+
+	// MPLSLabel - a label used by MPLSoEthernet, MPLSoGRE and MPLSoUDP mechanisms
+	MPLSLabel = "mpls_label"
+
+	// MPLSFirstUnreservedLabel - labels below are reserved by RFC 3032
+	MPLSFirstUnreservedLabel = 16
 )
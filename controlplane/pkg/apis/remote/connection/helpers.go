@@ -79,23 +79,64 @@ func (m *Mechanism) isValid() error {
 		return fmt.Errorf("Mechanism.Parameters cannot be nil: %v", m)
 	}
 
-	if m.GetType() == MechanismType_VXLAN {
-		if _, err := m.SrcIP(); err != nil {
-			return fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s] for VXLAN tunnel, caused by: %+v", m.GetType(), VXLANSrcIP, err)
+	switch m.GetType() {
+	case MechanismType_VXLAN:
+		if err := m.isTunnelValid(); err != nil {
+			return err
 		}
-
-		if _, err := m.DstIP(); err != nil {
-			return fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s] for VXLAN tunnel, caused by: %+v", m.GetType(), VXLANDstIP, err)
-		}
-
 		if _, err := m.VNI(); err != nil {
 			return fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s] for VXLAN tunnel, caused by: %+v", m.GetType(), VXLANVNI, err)
 		}
+	case MechanismType_GRE:
+		if err := m.isTunnelValid(); err != nil {
+			return err
+		}
+		if _, ok := m.Parameters[GREKey]; ok {
+			if _, err := m.GREKey(); err != nil {
+				return err
+			}
+		}
+	case MechanismType_SRV6:
+		if err := m.isTunnelValid(); err != nil {
+			return err
+		}
+		for _, name := range []string{VXLANSrcIP, VXLANDstIP} {
+			if ip := net.ParseIP(m.Parameters[name]); ip.To4() != nil {
+				return fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s] to be an IPv6 address: %v", m.GetType(), name, m)
+			}
+		}
+		if _, err := m.SRv6SrcLocalSID(); err != nil {
+			return err
+		}
+		if _, err := m.SRv6DstLocalSID(); err != nil {
+			return err
+		}
+	case MechanismType_MPLSoGRE, MechanismType_MPLSoUDP:
+		if err := m.isTunnelValid(); err != nil {
+			return err
+		}
+		if _, err := m.MPLSLabel(); err != nil {
+			return err
+		}
+	case MechanismType_MPLSoEthernet:
+		if _, err := m.MPLSLabel(); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+func (m *Mechanism) isTunnelValid() error {
+	if _, err := m.SrcIP(); err != nil {
+		return fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s] for %s tunnel, caused by: %+v", m.GetType(), VXLANSrcIP, m.GetType(), err)
+	}
+	if _, err := m.DstIP(); err != nil {
+		return fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s] for %s tunnel, caused by: %+v", m.GetType(), VXLANDstIP, m.GetType(), err)
+	}
+	return nil
+}
+
 // SrcIP returns the source IP parameter of the Mechanism
 func (m *Mechanism) SrcIP() (string, error) {
 	return m.getIPParameter(VXLANSrcIP)
@@ -116,7 +157,7 @@ func (m *Mechanism) getIPParameter(name string) (string, error) {
 
 	ip, ok := m.Parameters[name]
 	if !ok {
-		return "", fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s] for the VXLAN tunnel", m.GetType(), name)
+		return "", fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s] for the %s tunnel", m.GetType(), name, m.GetType())
 	}
 
 	parsedIP := net.ParseIP(ip)
@@ -150,6 +191,53 @@ func (m *Mechanism) VNI() (uint32, error) {
 	return uint32(vni), nil
 }
 
+// GREKey returns the GRE key parameter of the Mechanism
+func (m *Mechanism) GREKey() (uint32, error) {
+	return m.getUintParameter(GREKey, 32)
+}
+
+// MPLSLabel returns the MPLS label parameter of the Mechanism
+func (m *Mechanism) MPLSLabel() (uint32, error) {
+	label, err := m.getUintParameter(MPLSLabel, 20)
+	if err != nil {
+		return 0, err
+	}
+	if label < MPLSFirstUnreservedLabel {
+		return 0, fmt.Errorf("Mechanism.Parameters[%s] must not be a reserved MPLS label, instead was: %d: %v", MPLSLabel, label, m)
+	}
+	return label, nil
+}
+
+// SRv6SrcLocalSID returns the SID of source side of the SRv6 Mechanism
+func (m *Mechanism) SRv6SrcLocalSID() (string, error) {
+	return m.getIPParameter(SRv6SrcLocalSID)
+}
+
+// SRv6DstLocalSID returns the SID of destination side of the SRv6 Mechanism
+func (m *Mechanism) SRv6DstLocalSID() (string, error) {
+	return m.getIPParameter(SRv6DstLocalSID)
+}
+
+func (m *Mechanism) getUintParameter(name string, bitSize int) (uint32, error) {
+	if m == nil {
+		return 0, fmt.Errorf("Mechanism cannot be nil")
+	}
+	if m.GetParameters() == nil {
+		return 0, fmt.Errorf("Mechanism.Parameters cannot be nil: %v", m)
+	}
+
+	value, ok := m.Parameters[name]
+	if !ok {
+		return 0, fmt.Errorf("Mechanism.Type %s requires Mechanism.Parameters[%s]", m.GetType(), name)
+	}
+
+	rv, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("Mechanism.Parameters[%s] must be a valid %d-bit unsigned integer, instead was: %s: %v", name, bitSize, value, m)
+	}
+	return uint32(rv), nil
+}
+
 func (c *Connection) SetId(id string) {
 	c.Id = id
 }
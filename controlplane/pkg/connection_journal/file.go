@@ -0,0 +1,79 @@
+package connection_journal
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// DefaultMaxFileSize - a size of journal file, it is rotated once it is exceeded.
+const DefaultMaxFileSize = 10 * 1024 * 1024
+
+// rotatingFile - a file rotated once its size exceeds maxSize, only one rotated file is kept with ".1" suffix, so
+// disk space used by journal is bounded by twice maxSize.
+type rotatingFile struct {
+	sync.Mutex
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+}
+
+// NewRotatingFile - opens file at path to append to, file is rotated once its size exceeds maxSize.
+func NewRotatingFile(path string, maxSize int64) (io.WriteCloser, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxFileSize
+	}
+	f := &rotatingFile{
+		path:    path,
+		maxSize: maxSize,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) Write(data []byte) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.size > 0 && f.size+int64(len(data)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *rotatingFile) Close() error {
+	f.Lock()
+	defer f.Unlock()
+	return f.file.Close()
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	f.file.Close()
+	// File is reopened even if it could not be renamed, so journal is still written.
+	renameErr := os.Rename(f.path, f.path+".1")
+	if err := f.open(); err != nil {
+		return err
+	}
+	return renameErr
+}
@@ -0,0 +1,139 @@
+package connection_journal
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultCapacity - a number of events kept by journal by default.
+const DefaultCapacity = 4096
+
+// OutputBufferSize - a number of events waiting to be written to output, events recorded while buffer is full are
+// not written to output.
+const OutputBufferSize = 1024
+
+// Journal - a bounded journal of connection lifecycle events, oldest events are dropped once capacity is reached.
+// Events could be also written to output as JSON lines, so they survive journal overflow and NSMD restart.
+// Nil journal is valid and records nothing.
+type Journal struct {
+	sync.RWMutex
+	events    []*journal.JournalEvent
+	next      int
+	full      bool
+	output    chan *journal.JournalEvent
+	written   chan struct{}
+	dropped   uint64
+	marshaler *jsonpb.Marshaler
+}
+
+// NewJournal - creates journal keeping last capacity events.
+func NewJournal(capacity int) *Journal {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Journal{
+		events:    make([]*journal.JournalEvent, capacity),
+		marshaler: &jsonpb.Marshaler{},
+	}
+}
+
+// SetOutput - writes every recorded event as JSON line to output. Events are written in background, so recording is
+// not slowed down by output, events are not written if output could not keep up with them.
+func (j *Journal) SetOutput(output io.Writer) {
+	j.Close()
+	j.Lock()
+	defer j.Unlock()
+	j.output = make(chan *journal.JournalEvent, OutputBufferSize)
+	j.written = make(chan struct{})
+	go j.writeEvents(output, j.output, j.written)
+}
+
+// Close - stops writing events to output, once events already recorded are written.
+func (j *Journal) Close() {
+	if j == nil {
+		return
+	}
+	j.Lock()
+	output, written := j.output, j.written
+	j.output, j.written = nil, nil
+	j.Unlock()
+	if output != nil {
+		close(output)
+		<-written
+	}
+}
+
+// Record - records event of eventType for connection with passed reason.
+func (j *Journal) Record(connectionId string, eventType string, reason string) {
+	if j == nil {
+		return
+	}
+	event := &journal.JournalEvent{
+		ConnectionId: connectionId,
+		Time:         ptypes.TimestampNow(),
+		Type:         eventType,
+		Reason:       reason,
+	}
+
+	j.Lock()
+	defer j.Unlock()
+	j.events[j.next] = event
+	j.next = (j.next + 1) % len(j.events)
+	if j.next == 0 {
+		j.full = true
+	}
+	if j.output != nil {
+		select {
+		case j.output <- event:
+		default:
+			atomic.AddUint64(&j.dropped, 1)
+		}
+	}
+}
+
+func (j *Journal) writeEvents(output io.Writer, events <-chan *journal.JournalEvent, written chan<- struct{}) {
+	defer close(written)
+	for event := range events {
+		if dropped := atomic.SwapUint64(&j.dropped, 0); dropped > 0 {
+			logrus.Warnf("Journal output could not keep up, %d events are not written", dropped)
+		}
+		if err := j.marshaler.Marshal(output, event); err != nil {
+			logrus.Errorf("Failed to write journal event %v: %v", event, err)
+			continue
+		}
+		if _, err := output.Write([]byte("\n")); err != nil {
+			logrus.Errorf("Failed to write journal event %v: %v", event, err)
+		}
+	}
+}
+
+// Events - returns events of connection in order they were recorded, events of all connections are returned
+// if connectionId is empty. Only last limit events are returned if limit is positive.
+func (j *Journal) Events(connectionId string, limit int) []*journal.JournalEvent {
+	if j == nil {
+		return nil
+	}
+	j.RLock()
+	defer j.RUnlock()
+
+	ordered := j.events[:j.next]
+	if j.full {
+		ordered = append(append([]*journal.JournalEvent{}, j.events[j.next:]...), j.events[:j.next]...)
+	}
+	result := []*journal.JournalEvent{}
+	for _, event := range ordered {
+		if connectionId == "" || event.GetConnectionId() == connectionId {
+			result = append(result, event)
+		}
+	}
+	if limit > 0 && len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+	return result
+}
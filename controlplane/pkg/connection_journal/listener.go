@@ -0,0 +1,29 @@
+package connection_journal
+
+import (
+	"fmt"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+type journalListener struct {
+	model.ModelListenerImpl
+	journal *Journal
+}
+
+// NewJournalListener - creates model listener recording connections added to and deleted from model.
+func NewJournalListener(j *Journal) model.ModelListener {
+	return &journalListener{
+		journal: j,
+	}
+}
+
+func (l *journalListener) ClientConnectionAdded(clientConnection *model.ClientConnection) {
+	l.journal.Record(clientConnection.GetId(), journal.EventAdded, fmt.Sprintf("connection to endpoint %s is stored",
+		clientConnection.Endpoint.GetNetworkserviceEndpoint().GetEndpointName()))
+}
+
+func (l *journalListener) ClientConnectionDeleted(clientConnection *model.ClientConnection) {
+	l.journal.Record(clientConnection.GetId(), journal.EventDeleted, "connection is removed")
+}
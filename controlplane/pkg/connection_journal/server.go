@@ -0,0 +1,23 @@
+package connection_journal
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"golang.org/x/net/context"
+)
+
+type journalServer struct {
+	journal *Journal
+}
+
+// NewJournalServer - creates gRPC server to query journal events.
+func NewJournalServer(j *Journal) journal.ConnectionJournalServer {
+	return &journalServer{
+		journal: j,
+	}
+}
+
+func (srv *journalServer) GetEvents(ctx context.Context, query *journal.JournalQuery) (*journal.JournalEvents, error) {
+	return &journal.JournalEvents{
+		Events: srv.journal.Events(query.GetConnectionId(), int(query.GetLimit())),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package interdomain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DomainSeparator - separates Network Service or NSM name from a domain it belongs to, e.g. "secure-intranet@cluster-b".
+const DomainSeparator = "@"
+
+// Domain - describes how to reach another domain(cluster).
+type Domain struct {
+	// Name - a name of domain used in Network Service names.
+	Name string
+	// RegistryUrl - an address of domain Network Service Registry.
+	RegistryUrl string
+	// ProxyNsmdUrl - an address of domain proxy NSMD, which relays remote connections to domain NSMs.
+	ProxyNsmdUrl string
+}
+
+// Resolver - resolves domain name to domain registry and proxy NSMD.
+type Resolver interface {
+	Resolve(domain string) (*Domain, error)
+}
+
+type staticResolver struct {
+	domains map[string]*Domain
+}
+
+// NewStaticResolver - creates a resolver for fixed set of domains.
+func NewStaticResolver(domains []*Domain) Resolver {
+	resolver := &staticResolver{
+		domains: map[string]*Domain{},
+	}
+	for _, domain := range domains {
+		resolver.domains[domain.Name] = domain
+	}
+	return resolver
+}
+
+func (r *staticResolver) Resolve(domain string) (*Domain, error) {
+	if d, ok := r.domains[domain]; ok {
+		return d, nil
+	}
+	return nil, fmt.Errorf("unknown domain %s", domain)
+}
+
+// ParseDomains - parses a list of domains in format "name=registry_url,proxy_nsmd_url;name2=...".
+func ParseDomains(value string) ([]*Domain, error) {
+	domains := []*Domain{}
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		nameUrls := strings.SplitN(item, "=", 2)
+		if len(nameUrls) != 2 {
+			return nil, fmt.Errorf("invalid domain %s, expected name=registry_url,proxy_nsmd_url", item)
+		}
+		urls := strings.Split(nameUrls[1], ",")
+		if len(urls) != 2 {
+			return nil, fmt.Errorf("invalid domain %s, expected name=registry_url,proxy_nsmd_url", item)
+		}
+		domain := &Domain{
+			Name:         strings.TrimSpace(nameUrls[0]),
+			RegistryUrl:  strings.TrimSpace(urls[0]),
+			ProxyNsmdUrl: strings.TrimSpace(urls[1]),
+		}
+		if domain.Name == "" || domain.RegistryUrl == "" || domain.ProxyNsmdUrl == "" {
+			return nil, fmt.Errorf("invalid domain %s, expected name=registry_url,proxy_nsmd_url", item)
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// SplitDomain - splits name into local part and domain, domain is empty for names without domain.
+func SplitDomain(name string) (string, string) {
+	if idx := strings.LastIndex(name, DomainSeparator); idx >= 0 {
+		return name[:idx], name[idx+len(DomainSeparator):]
+	}
+	return name, ""
+}
+
+// JoinDomain - appends domain to name, name is returned as is if domain is empty.
+func JoinDomain(name string, domain string) string {
+	if domain == "" {
+		return name
+	}
+	return name + DomainSeparator + domain
+}
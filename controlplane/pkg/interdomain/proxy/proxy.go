@@ -0,0 +1,211 @@
+package proxy
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/remote_connection_monitor"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProxyNetworkServiceServer - relays remote connections of another domain NSMs to NSMs of our domain.
+// Network Service and destination NSM names are received with domain suffix, the suffix is removed before request
+// is passed to our NSM and restored in responses, so another domain sees consistent names.
+type ProxyNetworkServiceServer struct {
+	serviceRegistry serviceregistry.ServiceRegistry
+	managers        map[string]*registry.NetworkServiceManager
+	sync.RWMutex
+}
+
+// NewProxyNetworkServiceServer - creates a proxy relaying requests to NSMs found in serviceRegistry.
+func NewProxyNetworkServiceServer(serviceRegistry serviceregistry.ServiceRegistry) *ProxyNetworkServiceServer {
+	return &ProxyNetworkServiceServer{
+		serviceRegistry: serviceRegistry,
+		managers:        map[string]*registry.NetworkServiceManager{},
+	}
+}
+
+func (srv *ProxyNetworkServiceServer) Request(ctx context.Context, request *remote_networkservice.NetworkServiceRequest) (*remote_connection.Connection, error) {
+	ctx, requestId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("Proxy NSMD:(%v) Received request from another domain %v", requestId, request)
+	if err := srv.verifyPeer(ctx, request.GetConnection()); err != nil {
+		log.Errorf("Proxy NSMD:(%v) %v", requestId, err)
+		return nil, err
+	}
+	domain, localRequest := srv.fromDomainRequest(request)
+	remoteNsm, err := srv.findNsm(ctx, localRequest.GetConnection())
+	if err != nil {
+		log.Errorf("Proxy NSMD:(%v) %v", requestId, err)
+		return nil, err
+	}
+	client, conn, err := srv.serviceRegistry.RemoteNetworkServiceClient(remoteNsm)
+	if err != nil {
+		log.Errorf("Proxy NSMD:(%v) Failed to connect NSM %s: %v", requestId, remoteNsm.GetName(), err)
+		srv.forgetNsm(remoteNsm)
+		return nil, err
+	}
+	if conn != nil {
+		defer conn.Close()
+	}
+	response, err := client.Request(ctx, localRequest)
+	if err != nil {
+		log.Errorf("Proxy NSMD:(%v) NSM %s respond with error: %v", requestId, remoteNsm.GetName(), err)
+		srv.forgetNsm(remoteNsm)
+		return nil, err
+	}
+	return toDomain(response, domain), nil
+}
+
+func (srv *ProxyNetworkServiceServer) Close(ctx context.Context, connection *remote_connection.Connection) (*empty.Empty, error) {
+	ctx, closeId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("Proxy NSMD:(%v) Received close from another domain %v", closeId, connection)
+	if err := srv.verifyPeer(ctx, connection); err != nil {
+		log.Errorf("Proxy NSMD:(%v) %v", closeId, err)
+		return nil, err
+	}
+	_, localConnection := fromDomain(connection)
+	remoteNsm, err := srv.findNsm(ctx, localConnection)
+	if err != nil {
+		log.Errorf("Proxy NSMD:(%v) %v", closeId, err)
+		return nil, err
+	}
+	client, conn, err := srv.serviceRegistry.RemoteNetworkServiceClient(remoteNsm)
+	if err != nil {
+		log.Errorf("Proxy NSMD:(%v) Failed to connect NSM %s: %v", closeId, remoteNsm.GetName(), err)
+		srv.forgetNsm(remoteNsm)
+		return nil, err
+	}
+	if conn != nil {
+		defer conn.Close()
+	}
+	response, err := client.Close(ctx, localConnection)
+	if err != nil {
+		log.Errorf("Proxy NSMD:(%v) NSM %s respond with error: %v", closeId, remoteNsm.GetName(), err)
+		srv.forgetNsm(remoteNsm)
+		return nil, err
+	}
+	return response, nil
+}
+
+// MonitorConnections - relays connection events of our NSM, selected by name with domain suffix. Only connections
+// requested by the peer are relayed.
+func (srv *ProxyNetworkServiceServer) MonitorConnections(selector *remote_connection.MonitorScopeSelector, recipient remote_connection.MonitorConnection_MonitorConnectionsServer) error {
+	recipient = remote_connection_monitor.NewPeerConnectionFilter(srv.serviceRegistry.SecurityProvider(), recipient)
+	name, domain := interdomain.SplitDomain(selector.GetNetworkServiceManagerName())
+	srv.RLock()
+	remoteNsm := srv.managers[name]
+	srv.RUnlock()
+	if remoteNsm == nil {
+		return fmt.Errorf("NSM %s is not known by proxy, no connections were requested to it", name)
+	}
+
+	conn, err := grpc.DialContext(recipient.Context(), remoteNsm.GetUrl(), srv.serviceRegistry.SecurityProvider().DialOption(remoteNsm.GetName()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stream, err := remote_connection.NewMonitorConnectionClient(conn).MonitorConnections(recipient.Context(), &remote_connection.MonitorScopeSelector{
+		NetworkServiceManagerName: name,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		for key, connection := range event.GetConnections() {
+			event.Connections[key] = toDomain(connection, domain)
+		}
+		if err := recipient.Send(event); err != nil {
+			return err
+		}
+	}
+}
+
+// verifyPeer - checks connection is relayed on behalf of its source NSM, NSMs of our domain trust the proxy to do it.
+func (srv *ProxyNetworkServiceServer) verifyPeer(ctx context.Context, connection *remote_connection.Connection) error {
+	if err := srv.serviceRegistry.SecurityProvider().VerifyPeer(ctx, connection.GetSourceNetworkServiceManagerName()); err != nil {
+		return status.Errorf(codes.Unauthenticated, "connection is refused: %v", err)
+	}
+	return nil
+}
+
+// findNsm - finds our NSM to relay connection to, NSMs are remembered to relay their connection events later.
+func (srv *ProxyNetworkServiceServer) findNsm(ctx context.Context, connection *remote_connection.Connection) (*registry.NetworkServiceManager, error) {
+	name := connection.GetDestinationNetworkServiceManagerName()
+	srv.RLock()
+	remoteNsm := srv.managers[name]
+	srv.RUnlock()
+	if remoteNsm != nil {
+		return remoteNsm, nil
+	}
+
+	discoveryClient, err := srv.serviceRegistry.NetworkServiceDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	response, err := discoveryClient.FindNetworkService(ctx, &registry.FindNetworkServiceRequest{
+		NetworkServiceName: connection.GetNetworkService(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	remoteNsm = response.GetNetworkServiceManagers()[name]
+	if remoteNsm == nil {
+		return nil, fmt.Errorf("NSM %s is not found for Network Service %s", name, connection.GetNetworkService())
+	}
+	srv.Lock()
+	srv.managers[name] = remoteNsm
+	srv.Unlock()
+	return remoteNsm, nil
+}
+
+// forgetNsm - evicts NSM failed to serve relayed connection, so it is discovered again on next request, i.e. NSM
+// restarted with another URL is found.
+func (srv *ProxyNetworkServiceServer) forgetNsm(remoteNsm *registry.NetworkServiceManager) {
+	srv.Lock()
+	defer srv.Unlock()
+	// NSM could be already discovered again by concurrent request.
+	if srv.managers[remoteNsm.GetName()] == remoteNsm {
+		delete(srv.managers, remoteNsm.GetName())
+	}
+}
+
+func (srv *ProxyNetworkServiceServer) fromDomainRequest(request *remote_networkservice.NetworkServiceRequest) (string, *remote_networkservice.NetworkServiceRequest) {
+	localRequest := proto.Clone(request).(*remote_networkservice.NetworkServiceRequest)
+	domain, localConnection := fromDomain(request.GetConnection())
+	localRequest.Connection = localConnection
+	return domain, localRequest
+}
+
+// fromDomain - removes domain suffix from Network Service and destination NSM names.
+func fromDomain(connection *remote_connection.Connection) (string, *remote_connection.Connection) {
+	localConnection := proto.Clone(connection).(*remote_connection.Connection)
+	var domain string
+	localConnection.NetworkService, domain = interdomain.SplitDomain(connection.GetNetworkService())
+	localConnection.DestinationNetworkServiceManagerName, _ = interdomain.SplitDomain(connection.GetDestinationNetworkServiceManagerName())
+	return domain, localConnection
+}
+
+// toDomain - adds domain suffix to Network Service and destination NSM names.
+func toDomain(connection *remote_connection.Connection, domain string) *remote_connection.Connection {
+	domainConnection := proto.Clone(connection).(*remote_connection.Connection)
+	domainConnection.NetworkService = interdomain.JoinDomain(connection.GetNetworkService(), domain)
+	domainConnection.DestinationNetworkServiceManagerName = interdomain.JoinDomain(connection.GetDestinationNetworkServiceManagerName(), domain)
+	return domainConnection
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+type connectionLabels struct {
+	dataplane string
+	endpoint  string
+}
+
+type activeConnectionsListener struct {
+	model.ModelListenerImpl
+	sync.Mutex
+	connections map[string]connectionLabels
+}
+
+// NewActiveConnectionsListener - creates model listener counting active connections per dataplane and endpoint.
+func NewActiveConnectionsListener() model.ModelListener {
+	return &activeConnectionsListener{
+		connections: map[string]connectionLabels{},
+	}
+}
+
+func (l *activeConnectionsListener) ClientConnectionAdded(clientConnection *model.ClientConnection) {
+	l.update(clientConnection)
+}
+
+func (l *activeConnectionsListener) ClientConnectionUpdated(clientConnection *model.ClientConnection) {
+	// Dataplane or endpoint of connection could be changed during heal.
+	l.update(clientConnection)
+}
+
+func (l *activeConnectionsListener) ClientConnectionDeleted(clientConnection *model.ClientConnection) {
+	l.Lock()
+	defer l.Unlock()
+	if labels, ok := l.connections[clientConnection.GetId()]; ok {
+		ActiveConnections.WithLabelValues(labels.dataplane, labels.endpoint).Dec()
+		delete(l.connections, clientConnection.GetId())
+	}
+}
+
+func (l *activeConnectionsListener) update(clientConnection *model.ClientConnection) {
+	labels := connectionLabels{
+		endpoint: clientConnection.Endpoint.GetNetworkserviceEndpoint().GetEndpointName(),
+	}
+	if clientConnection.Dataplane != nil {
+		labels.dataplane = clientConnection.Dataplane.RegisteredName
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	if previous, ok := l.connections[clientConnection.GetId()]; ok {
+		if previous == labels {
+			return
+		}
+		ActiveConnections.WithLabelValues(previous.dataplane, previous.endpoint).Dec()
+	}
+	ActiveConnections.WithLabelValues(labels.dataplane, labels.endpoint).Inc()
+	l.connections[clientConnection.GetId()] = labels
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+
+	HealResultRecovered = "recovered"
+	HealResultClosed    = "closed"
+
+	OperationRequest = "request"
+	OperationUpdate  = "update"
+	OperationClose   = "close"
+)
+
+var (
+	// RequestsTotal - number of connection requests per Network Service and result.
+	RequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "nsm_requests_total",
+		Help: "Number of connection requests per Network Service and result.",
+	}, []string{"network_service", "result"})
+	// RequestDuration - latency of connection requests per Network Service.
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "nsm_request_duration_seconds",
+		Help:    "Latency of connection requests per Network Service.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"network_service"})
+	// ClosesTotal - number of connection closes per Network Service and result.
+	ClosesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "nsm_closes_total",
+		Help: "Number of connection closes per Network Service and result.",
+	}, []string{"network_service", "result"})
+	// CloseDuration - latency of connection closes per Network Service.
+	CloseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "nsm_close_duration_seconds",
+		Help:    "Latency of connection closes per Network Service.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"network_service"})
+	// HealsTotal - number of heal attempts per heal state and outcome.
+	HealsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "nsm_heals_total",
+		Help: "Number of heal attempts per heal state and outcome.",
+	}, []string{"heal_state", "result"})
+	// ActiveConnections - number of connections per dataplane and endpoint.
+	ActiveConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "nsm_active_connections",
+		Help: "Number of connections per dataplane and endpoint.",
+	}, []string{"dataplane", "endpoint"})
+	// DataplaneErrorsTotal - number of failed dataplane programming operations per dataplane.
+	DataplaneErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "nsm_dataplane_errors_total",
+		Help: "Number of failed dataplane programming operations per dataplane and operation.",
+	}, []string{"dataplane", "operation"})
+	// PolicyDenialsTotal - number of requests denied by authorization policy per Network Service.
+	PolicyDenialsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "nsm_policy_denials_total",
+		Help: "Number of requests denied by authorization policy per Network Service.",
+	}, []string{"network_service"})
+)
+
+func init() {
+	prometheus.MustRegister(RequestsTotal, RequestDuration, ClosesTotal, CloseDuration, HealsTotal, ActiveConnections, DataplaneErrorsTotal, PolicyDenialsTotal)
+}
+
+// ObserveRequest - counts request of networkService started at start, err is a request result.
+func ObserveRequest(networkService string, start time.Time, err error) {
+	RequestsTotal.WithLabelValues(networkService, result(err)).Inc()
+	RequestDuration.WithLabelValues(networkService).Observe(time.Since(start).Seconds())
+}
+
+// ObserveClose - counts close of networkService connection started at start, err is a close result.
+func ObserveClose(networkService string, start time.Time, err error) {
+	ClosesTotal.WithLabelValues(networkService, result(err)).Inc()
+	CloseDuration.WithLabelValues(networkService).Observe(time.Since(start).Seconds())
+}
+
+func result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
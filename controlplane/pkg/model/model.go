@@ -2,9 +2,12 @@ package model
 
 import (
 	"fmt"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
 
@@ -40,6 +43,25 @@ type ClientConnection struct {
 	Dataplane       *Dataplane
 	ConnectionState ClientConnectionState
 	Request         nsm.NSMRequest
+	// RemoteLease - lease granted by remote NSM on last request, remote connection is refreshed once less than half
+	// of it is left. It is zero if remote NSM does not use leases or lease is not known, i.e. after restore.
+	RemoteLease time.Duration
+}
+
+func (s ClientConnectionState) String() string {
+	switch s {
+	case ClientConnection_Ready:
+		return "ready"
+	case ClientConnection_Requesting:
+		return "requesting"
+	case ClientConnection_Healing:
+		return "healing"
+	case ClientConnection_Closing:
+		return "closing"
+	case ClientConnection_Closed:
+		return "closed"
+	}
+	return fmt.Sprintf("unknown(%d)", s)
 }
 
 func (cc *ClientConnection) GetId() string {
@@ -56,6 +78,46 @@ func (cc *ClientConnection) GetNetworkService() string {
 	return cc.Endpoint.GetNetworkService().GetName()
 }
 
+// Clone - returns a deep copy of client connection, copy could be read while connection is being changed.
+func (cc *ClientConnection) Clone() *ClientConnection {
+	rv := &ClientConnection{
+		ConnectionId:    cc.ConnectionId,
+		ConnectionState: cc.ConnectionState,
+		RemoteLease:     cc.RemoteLease,
+	}
+	if cc.Xcon != nil {
+		rv.Xcon = proto.Clone(cc.Xcon).(*crossconnect.CrossConnect)
+	}
+	if cc.RemoteNsm != nil {
+		rv.RemoteNsm = proto.Clone(cc.RemoteNsm).(*registry.NetworkServiceManager)
+	}
+	if cc.Endpoint != nil {
+		rv.Endpoint = proto.Clone(cc.Endpoint).(*registry.NSERegistration)
+	}
+	if cc.Dataplane != nil {
+		rv.Dataplane = cc.Dataplane.Clone()
+	}
+	if cc.Request != nil {
+		rv.Request = cc.Request.Clone()
+	}
+	return rv
+}
+
+// Clone - returns a deep copy of dataplane.
+func (dp *Dataplane) Clone() *Dataplane {
+	rv := &Dataplane{
+		RegisteredName: dp.RegisteredName,
+		SocketLocation: dp.SocketLocation,
+	}
+	for _, m := range dp.LocalMechanisms {
+		rv.LocalMechanisms = append(rv.LocalMechanisms, proto.Clone(m).(*local.Mechanism))
+	}
+	for _, m := range dp.RemoteMechanisms {
+		rv.RemoteMechanisms = append(rv.RemoteMechanisms, proto.Clone(m).(*remote.Mechanism))
+	}
+	return rv
+}
+
 func (cc *ClientConnection) GetConnectionSource() nsm.NSMConnection {
 	if cc.Xcon.GetLocalSource() != nil {
 		return cc.Xcon.GetLocalSource()
@@ -68,6 +130,7 @@ func (cc *ClientConnection) GetConnectionSource() nsm.NSMConnection {
 type ModelListener interface {
 	EndpointAdded(endpoint *registry.NetworkServiceEndpoint)
 	EndpointDeleted(endpoint *registry.NetworkServiceEndpoint)
+	EndpointUpdated(endpoint *registry.NetworkServiceEndpoint)
 
 	DataplaneAdded(dataplane *Dataplane)
 	DataplaneDeleted(dataplane *Dataplane)
@@ -83,6 +146,8 @@ func (ModelListenerImpl) EndpointAdded(endpoint *registry.NetworkServiceEndpoint
 
 func (ModelListenerImpl) EndpointDeleted(endpoint *registry.NetworkServiceEndpoint) {}
 
+func (ModelListenerImpl) EndpointUpdated(endpoint *registry.NetworkServiceEndpoint) {}
+
 func (ModelListenerImpl) DataplaneAdded(dataplane *Dataplane) {}
 
 func (ModelListenerImpl) DataplaneDeleted(dataplane *Dataplane) {}
@@ -97,13 +162,19 @@ type Model interface {
 	GetNetworkServiceEndpoints(name string) []*registry.NSERegistration
 
 	GetEndpoint(name string) *registry.NSERegistration
+	GetAllEndpoints() []*registry.NSERegistration
 	AddEndpoint(endpoint *registry.NSERegistration)
 	DeleteEndpoint(name string) error
+	SetEndpointState(name string, state string) error
+	// SetEndpointHealthy - marks local endpoint as healthy or not, unhealthy endpoints are not used for new connections.
+	SetEndpointHealthy(name string, healthy bool)
+	IsEndpointHealthy(name string) bool
 
 	GetDataplane(name string) *Dataplane
+	GetAllDataplanes() []*Dataplane
 	AddDataplane(dataplane *Dataplane)
 	DeleteDataplane(name string)
-	SelectDataplane() (*Dataplane, error)
+	SelectDataplane(dataplaneSelector func(dp *Dataplane) bool) (*Dataplane, error)
 
 	AddClientConnection(clientConnection *ClientConnection)
 	GetClientConnection(connectionId string) *ClientConnection
@@ -126,6 +197,7 @@ type Model interface {
 type impl struct {
 	sync.RWMutex
 	endpoints         map[string]*registry.NSERegistration
+	unhealthy         map[string]bool
 	networkServices   map[string][]*registry.NSERegistration
 	dataplanes        map[string]*Dataplane
 	lastConnnectionId uint64
@@ -140,6 +212,10 @@ func (i *impl) AddClientConnection(clientConnection *ClientConnection) {
 	defer i.Unlock()
 
 	i.clientConnections[clientConnection.ConnectionId] = clientConnection
+	// Keep connection id generator ahead of connections restored from persistence.
+	if id, err := strconv.ParseUint(clientConnection.ConnectionId, 16, 64); err == nil && id > i.lastConnnectionId {
+		i.lastConnnectionId = id
+	}
 	for _, listener := range i.listeners {
 		listener.ClientConnectionAdded(clientConnection)
 	}
@@ -237,6 +313,16 @@ func (i *impl) GetEndpoint(name string) *registry.NSERegistration {
 	return i.endpoints[name]
 }
 
+func (i *impl) GetAllEndpoints() []*registry.NSERegistration {
+	i.RLock()
+	defer i.RUnlock()
+	var rv []*registry.NSERegistration
+	for _, endpoint := range i.endpoints {
+		rv = append(rv, endpoint)
+	}
+	return rv
+}
+
 func (i *impl) AddEndpoint(endpoint *registry.NSERegistration) {
 	i.Lock()
 	defer i.Unlock()
@@ -277,6 +363,7 @@ func (i *impl) DeleteEndpoint(name string) error {
 			delete(i.networkServices, endpoint.GetNetworkService().GetName())
 		}
 		delete(i.endpoints, name)
+		delete(i.unhealthy, name)
 
 		for _, l := range i.listeners {
 			l.EndpointDeleted(endpoint.GetNetworkserviceEndpoint())
@@ -287,6 +374,47 @@ func (i *impl) DeleteEndpoint(name string) error {
 	return fmt.Errorf("no endpoint with name: %s", name)
 }
 
+func (i *impl) SetEndpointState(name string, state string) error {
+	i.Lock()
+	defer i.Unlock()
+	endpoint := i.endpoints[name]
+	if endpoint == nil || endpoint.GetNetworkserviceEndpoint() == nil {
+		return fmt.Errorf("no endpoint with name: %s", name)
+	}
+	// Registration could be shared with readers, so it is replaced with an updated copy.
+	updated := proto.Clone(endpoint).(*registry.NSERegistration)
+	updated.NetworkserviceEndpoint.State = state
+	i.endpoints[name] = updated
+	services := i.networkServices[endpoint.GetNetworkService().GetName()]
+	for idx, e := range services {
+		if e == endpoint {
+			services[idx] = updated
+			break
+		}
+	}
+
+	for _, l := range i.listeners {
+		l.EndpointUpdated(updated.GetNetworkserviceEndpoint())
+	}
+	return nil
+}
+
+func (i *impl) SetEndpointHealthy(name string, healthy bool) {
+	i.Lock()
+	defer i.Unlock()
+	if healthy {
+		delete(i.unhealthy, name)
+	} else {
+		i.unhealthy[name] = true
+	}
+}
+
+func (i *impl) IsEndpointHealthy(name string) bool {
+	i.RLock()
+	defer i.RUnlock()
+	return !i.unhealthy[name]
+}
+
 func (i *impl) GetDataplane(name string) *Dataplane {
 	i.RLock()
 	defer i.RUnlock()
@@ -298,13 +426,47 @@ func (i *impl) GetDataplane(name string) *Dataplane {
 	return nil
 }
 
-func (i *impl) SelectDataplane() (*Dataplane, error) {
-	i.Lock()
-	defer i.Unlock()
-	for _, v := range i.dataplanes {
-		return v, nil // TODO: Return first for now
+func (i *impl) GetAllDataplanes() []*Dataplane {
+	i.RLock()
+	defer i.RUnlock()
+	var rv []*Dataplane
+	for _, dp := range i.dataplanes {
+		rv = append(rv, dp)
+	}
+	return rv
+}
+
+// SelectDataplane - selects a dataplane accepted by dataplaneSelector, if several dataplanes are accepted one with
+// the least number of client connections is returned. If dataplaneSelector is nil any dataplane is accepted.
+func (i *impl) SelectDataplane(dataplaneSelector func(dp *Dataplane) bool) (*Dataplane, error) {
+	i.RLock()
+	defer i.RUnlock()
+
+	if len(i.dataplanes) == 0 {
+		return nil, fmt.Errorf("no dataplanes registered")
+	}
+
+	load := map[string]int{}
+	for _, cc := range i.clientConnections {
+		if cc.Dataplane != nil {
+			load[cc.Dataplane.RegisteredName]++
+		}
+	}
+
+	var selected *Dataplane
+	for _, dp := range i.dataplanes {
+		if dataplaneSelector != nil && !dataplaneSelector(dp) {
+			continue
+		}
+		if selected == nil || load[dp.RegisteredName] < load[selected.RegisteredName] ||
+			(load[dp.RegisteredName] == load[selected.RegisteredName] && dp.RegisteredName < selected.RegisteredName) {
+			selected = dp
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("no appropriate dataplanes found")
 	}
-	return nil, fmt.Errorf("no dataplanes registered")
+	return selected, nil
 }
 
 func (i *impl) AddDataplane(dataplane *Dataplane) {
@@ -342,14 +504,16 @@ func (i *impl) SetNsm(nsm *registry.NetworkServiceManager) {
 }
 
 func NewModel() Model {
-	return &impl{
+	rv := &impl{
 		dataplanes:        make(map[string]*Dataplane),
 		networkServices:   make(map[string][]*registry.NSERegistration),
 		endpoints:         make(map[string]*registry.NSERegistration),
+		unhealthy:         make(map[string]bool),
 		listeners:         []ModelListener{},
-		selector:          selector.NewMatchSelector(),
 		clientConnections: make(map[string]*ClientConnection),
 	}
+	rv.selector = selector.NewStrategySelector(rv)
+	return rv
 }
 
 func (i *impl) ConnectionId() string {
@@ -362,3 +526,22 @@ func (i *impl) ConnectionId() string {
 func (i *impl) GetSelector() selector.Selector {
 	return i.selector
 }
+
+// ConnectionsCount - implements selector.SelectorContext, returns a number of client connections to endpoint.
+func (i *impl) ConnectionsCount(endpointName string) int {
+	i.RLock()
+	defer i.RUnlock()
+
+	count := 0
+	for _, cc := range i.clientConnections {
+		if cc.Endpoint.GetNetworkserviceEndpoint().GetEndpointName() == endpointName {
+			count++
+		}
+	}
+	return count
+}
+
+// NetworkServiceManagerName - implements selector.SelectorContext, returns a name of local NSM.
+func (i *impl) NetworkServiceManagerName() string {
+	return i.GetNsm().GetName()
+}
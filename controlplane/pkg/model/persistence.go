@@ -0,0 +1,270 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	local_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ModelSnapshotFile is a name of file used by file persistence to store model snapshot.
+	ModelSnapshotFile = "nsmd.model.json"
+)
+
+// PersistenceDelay - time persistence listener waits after a change before saving snapshot, so bursts of changes
+// are saved at once.
+var PersistenceDelay = 100 * time.Millisecond
+
+// Persistence is a storage backend used to keep client connections alive across NSMD restarts.
+type Persistence interface {
+	// Save stores passed client connections, replacing previously saved ones.
+	Save(clientConnections []*ClientConnection) error
+	// Load returns all previously saved client connections.
+	Load() ([]*ClientConnection, error)
+}
+
+type persistedConnection struct {
+	ConnectionId  string          `json:"connection_id"`
+	Xcon          json.RawMessage `json:"xcon"`
+	RemoteNsm     json.RawMessage `json:"remote_nsm,omitempty"`
+	Endpoint      json.RawMessage `json:"endpoint,omitempty"`
+	DataplaneName string          `json:"dataplane,omitempty"`
+	RemoteRequest bool            `json:"remote_request,omitempty"`
+	Request       json.RawMessage `json:"request,omitempty"`
+}
+
+type filePersistence struct {
+	sync.Mutex
+	fileName string
+}
+
+// NewFilePersistence - creates a persistence storing model snapshot as JSON file inside baseDir.
+func NewFilePersistence(baseDir string) Persistence {
+	return &filePersistence{
+		fileName: path.Join(baseDir, ModelSnapshotFile),
+	}
+}
+
+func (fp *filePersistence) Save(clientConnections []*ClientConnection) error {
+	records := []*persistedConnection{}
+	for _, cc := range clientConnections {
+		record, err := marshalClientConnection(cc)
+		if err != nil {
+			return err
+		}
+		records = append(records, record)
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	fp.Lock()
+	defer fp.Unlock()
+
+	// Write into temporary file and rename, so partially written snapshot will never be loaded.
+	tmpFile := fp.fileName + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, fp.fileName)
+}
+
+func (fp *filePersistence) Load() ([]*ClientConnection, error) {
+	fp.Lock()
+	data, err := ioutil.ReadFile(fp.fileName)
+	fp.Unlock()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	records := []*persistedConnection{}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse model snapshot %s: %v", fp.fileName, err)
+	}
+	result := []*ClientConnection{}
+	for _, record := range records {
+		cc, err := unmarshalClientConnection(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore connection %s: %v", record.ConnectionId, err)
+		}
+		result = append(result, cc)
+	}
+	return result, nil
+}
+
+func marshalMessage(msg proto.Message) (json.RawMessage, error) {
+	buffer := &bytes.Buffer{}
+	if err := (&jsonpb.Marshaler{}).Marshal(buffer, msg); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func unmarshalMessage(data json.RawMessage, msg proto.Message) error {
+	return jsonpb.Unmarshal(bytes.NewReader(data), msg)
+}
+
+func marshalClientConnection(cc *ClientConnection) (*persistedConnection, error) {
+	record := &persistedConnection{
+		ConnectionId: cc.ConnectionId,
+	}
+	var err error
+	if record.Xcon, err = marshalMessage(cc.Xcon); err != nil {
+		return nil, err
+	}
+	if cc.RemoteNsm != nil {
+		if record.RemoteNsm, err = marshalMessage(cc.RemoteNsm); err != nil {
+			return nil, err
+		}
+	}
+	if cc.Endpoint != nil {
+		if record.Endpoint, err = marshalMessage(cc.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+	if cc.Dataplane != nil {
+		record.DataplaneName = cc.Dataplane.RegisteredName
+	}
+	if cc.Request != nil {
+		record.RemoteRequest = cc.Request.IsRemote()
+		if record.Request, err = marshalMessage(cc.Request.(proto.Message)); err != nil {
+			return nil, err
+		}
+	}
+	return record, nil
+}
+
+func unmarshalClientConnection(record *persistedConnection) (*ClientConnection, error) {
+	cc := &ClientConnection{
+		ConnectionId:    record.ConnectionId,
+		Xcon:            &crossconnect.CrossConnect{},
+		ConnectionState: ClientConnection_Ready,
+	}
+	if err := unmarshalMessage(record.Xcon, cc.Xcon); err != nil {
+		return nil, err
+	}
+	if len(record.RemoteNsm) > 0 {
+		cc.RemoteNsm = &registry.NetworkServiceManager{}
+		if err := unmarshalMessage(record.RemoteNsm, cc.RemoteNsm); err != nil {
+			return nil, err
+		}
+	}
+	if len(record.Endpoint) > 0 {
+		cc.Endpoint = &registry.NSERegistration{}
+		if err := unmarshalMessage(record.Endpoint, cc.Endpoint); err != nil {
+			return nil, err
+		}
+	}
+	if record.DataplaneName != "" {
+		// Only dataplane name is known, actual dataplane should be resolved after it will be registered again.
+		cc.Dataplane = &Dataplane{RegisteredName: record.DataplaneName}
+	}
+	if len(record.Request) > 0 {
+		var request nsm.NSMRequest
+		if record.RemoteRequest {
+			request = &remote_networkservice.NetworkServiceRequest{}
+		} else {
+			request = &local_networkservice.NetworkServiceRequest{}
+		}
+		if err := unmarshalMessage(record.Request, request.(proto.Message)); err != nil {
+			return nil, err
+		}
+		cc.Request = request
+	}
+	return cc, nil
+}
+
+// persistenceListener is a model listener saving a snapshot of client connections into persistence on every change.
+// Listener keeps own copies of connections and saves them from background, so model changes are never blocked by
+// persistence and snapshot is never marshalled while connections are being modified.
+type persistenceListener struct {
+	ModelListenerImpl
+	sync.Mutex
+	persistence       Persistence
+	clientConnections map[string]*ClientConnection
+	changed           chan struct{}
+}
+
+// NewPersistenceListener - creates a listener keeping persistence in sync with model client connections. Listener
+// is seeded with connections restored from persistence, so they are not overwritten before they are added to model.
+func NewPersistenceListener(persistence Persistence, restored ...*ClientConnection) ModelListener {
+	clientConnections := make(map[string]*ClientConnection)
+	for _, cc := range restored {
+		clientConnections[cc.ConnectionId] = cc.Clone()
+	}
+	l := &persistenceListener{
+		persistence:       persistence,
+		clientConnections: clientConnections,
+		changed:           make(chan struct{}, 1),
+	}
+	go l.persist()
+	return l
+}
+
+func (l *persistenceListener) ClientConnectionAdded(clientConnection *ClientConnection) {
+	l.update(clientConnection.ConnectionId, clientConnection)
+}
+
+func (l *persistenceListener) ClientConnectionUpdated(clientConnection *ClientConnection) {
+	l.update(clientConnection.ConnectionId, clientConnection)
+}
+
+func (l *persistenceListener) ClientConnectionDeleted(clientConnection *ClientConnection) {
+	l.update(clientConnection.ConnectionId, nil)
+}
+
+func (l *persistenceListener) update(connectionId string, clientConnection *ClientConnection) {
+	l.Lock()
+	if clientConnection != nil {
+		l.clientConnections[connectionId] = clientConnection.Clone()
+	} else {
+		delete(l.clientConnections, connectionId)
+	}
+	l.Unlock()
+
+	// Snapshot is already scheduled if channel is full.
+	select {
+	case l.changed <- struct{}{}:
+	default:
+	}
+}
+
+// persist - saves snapshot once connections are changed, changes done within PersistenceDelay are saved at once.
+func (l *persistenceListener) persist() {
+	for range l.changed {
+		<-time.After(PersistenceDelay)
+		select {
+		case <-l.changed:
+		default:
+		}
+
+		l.Lock()
+		snapshot := []*ClientConnection{}
+		for _, cc := range l.clientConnections {
+			snapshot = append(snapshot, cc)
+		}
+		l.Unlock()
+
+		if err := l.persistence.Save(snapshot); err != nil {
+			logrus.Errorf("Failed to persist model snapshot: %v", err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package remote_connection_monitor
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+)
+
+type peerConnectionFilter struct {
+	connection.MonitorConnection_MonitorConnectionsServer
+
+	provider *security.Provider
+}
+
+// NewPeerConnectionFilter - passes to monitor only connections requested by authenticated peer, so NSM doesn't see
+// connections of other NSMs. All connections are passed if provider is nil.
+func NewPeerConnectionFilter(provider *security.Provider, monitor connection.MonitorConnection_MonitorConnectionsServer) connection.MonitorConnection_MonitorConnectionsServer {
+	if provider == nil {
+		return monitor
+	}
+	return &peerConnectionFilter{
+		provider: provider,
+		MonitorConnection_MonitorConnectionsServer: monitor,
+	}
+}
+
+func (d *peerConnectionFilter) Send(in *connection.ConnectionEvent) error {
+	return d.SendMsg(in)
+}
+
+// SendMsg - filters events sent by monitor server, since it sends messages directly to the stream.
+func (d *peerConnectionFilter) SendMsg(msg interface{}) error {
+	in, ok := msg.(*connection.ConnectionEvent)
+	if !ok {
+		return d.MonitorConnection_MonitorConnectionsServer.SendMsg(msg)
+	}
+	out := &connection.ConnectionEvent{
+		Type:        in.Type,
+		Connections: make(map[string]*connection.Connection),
+	}
+	for key, value := range in.GetConnections() {
+		if d.provider.VerifyPeer(d.Context(), value.GetSourceNetworkServiceManagerName()) == nil {
+			out.Connections[key] = value
+		}
+	}
+	if len(out.Connections) > 0 || out.Type == connection.ConnectionEventType_INITIAL_STATE_TRANSFER {
+		return d.MonitorConnection_MonitorConnectionsServer.SendMsg(out)
+	}
+	return nil
+}
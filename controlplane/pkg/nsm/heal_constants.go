@@ -3,6 +3,7 @@ package nsm
 import "time"
 
 const (
-	HealTimeout          = time.Minute * 1
 	HealDataplaneTimeout = time.Minute * 1
+	// RestoreDataplaneTimeout - time restored connections wait for dataplane to register after NSMD restart.
+	RestoreDataplaneTimeout = time.Minute * 5
 )
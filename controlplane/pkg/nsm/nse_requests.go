@@ -17,8 +17,12 @@ func (srv *networkServiceManager) createRemoteNSMRequest(endpoint *registry.NSER
 		remoteM = append(remoteM, mechanism)
 	}
 	var message *remote_networkservice.NetworkServiceRequest
+	// Connection id is known only by NSM it was requested from.
+	var remoteDst *remote_connection.Connection
 	if existingConnection != nil {
-		remoteDst := existingConnection.Xcon.GetRemoteDestination()
+		remoteDst = existingConnection.Xcon.GetRemoteDestination()
+	}
+	if remoteDst != nil && remoteDst.GetDestinationNetworkServiceManagerName() == endpoint.GetNetworkServiceManager().GetName() {
 		message = &remote_networkservice.NetworkServiceRequest{
 
 			Connection: &remote_connection.Connection{
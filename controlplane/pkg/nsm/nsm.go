@@ -14,65 +14,116 @@
 package nsm
 
 import (
-	"crypto/rand"
 	"fmt"
+	"sync"
+	"time"
+
 	"github.com/golang/protobuf/proto"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
 	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connection_journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/retry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
-	"time"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 ///// Network service manager to manage both local/remote NSE connections.
 type networkServiceManager struct {
-	serviceRegistry   serviceregistry.ServiceRegistry
-	model             model.Model
-	excluded_prefixes []string
+	serviceRegistry serviceregistry.ServiceRegistry
+	model           model.Model
+	journal         *connection_journal.Journal
+	settingsMutex   sync.RWMutex
+	settings        nsm.Settings
+	clusterPrefixes []string
 }
 
-func NewNetworkServiceManager(model model.Model, serviceRegistry serviceregistry.ServiceRegistry, excluded_prefixes []string) nsm.NetworkServiceManager {
+func NewNetworkServiceManager(model model.Model, serviceRegistry serviceregistry.ServiceRegistry, excluded_prefixes []string, leaseDuration time.Duration, journal *connection_journal.Journal) nsm.NetworkServiceManager {
 	return &networkServiceManager{
-		serviceRegistry:   serviceRegistry,
-		model:             model,
-		excluded_prefixes: excluded_prefixes,
+		serviceRegistry: serviceRegistry,
+		model:           model,
+		journal:         journal,
+		settings: nsm.Settings{
+			ExcludedPrefixes:     excluded_prefixes,
+			LeaseDuration:        leaseDuration,
+			HealTimeout:          nsmd.DefaultHealTimeout,
+			NseConnectionTimeout: nsmd.DefaultNseConnectionTimeout,
+		},
 	}
 }
 
-func (srv *networkServiceManager) Request(ctx context.Context, request nsm.NSMRequest) (nsm.NSMConnection, error) {
-	// Check if we are recovering connection, by checking passed connection Id is known to us.
-	return srv.request(ctx, request, srv.model.GetClientConnection(request.GetConnectionId()))
+// UpdateSettings - replaces settings used for requests and heals started after update.
+func (srv *networkServiceManager) UpdateSettings(settings nsm.Settings) {
+	srv.settingsMutex.Lock()
+	defer srv.settingsMutex.Unlock()
+	logrus.Infof("NSM: settings are updated: %+v", settings)
+	srv.settings = settings
 }
 
-func create_logid() (uuid string) {
-	b := make([]byte, 4)
-	_, err := rand.Read(b)
-	if err != nil {
-		logrus.Errorf("Error: %v", err)
-		return
+// UpdateClusterPrefixes - replaces prefixes used by cluster, they are excluded in addition to configured ones.
+func (srv *networkServiceManager) UpdateClusterPrefixes(prefixes []string) {
+	srv.settingsMutex.Lock()
+	defer srv.settingsMutex.Unlock()
+	logrus.Infof("NSM: cluster prefixes are updated: %v", prefixes)
+	srv.clusterPrefixes = prefixes
+}
+
+// getExcludedPrefixes - returns configured prefixes followed by cluster prefixes not configured already.
+func (srv *networkServiceManager) getExcludedPrefixes() []string {
+	srv.settingsMutex.RLock()
+	defer srv.settingsMutex.RUnlock()
+	result := append([]string{}, srv.settings.ExcludedPrefixes...)
+	configured := map[string]bool{}
+	for _, prefix := range result {
+		configured[prefix] = true
+	}
+	for _, prefix := range srv.clusterPrefixes {
+		if !configured[prefix] {
+			result = append(result, prefix)
+		}
 	}
+	return result
+}
 
-	uuid = fmt.Sprintf("%X", b[0:4])
-	return
+func (srv *networkServiceManager) getSettings() nsm.Settings {
+	srv.settingsMutex.RLock()
+	defer srv.settingsMutex.RUnlock()
+	return srv.settings
 }
 
-func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRequest, existingConnection *model.ClientConnection) (nsm.NSMConnection, error) {
-	requestId := create_logid()
-	logrus.Infof("NSM:(%v) request: %v", requestId, request)
+func (srv *networkServiceManager) Request(ctx context.Context, request nsm.NSMRequest) (nsm.NSMConnection, error) {
+	start := time.Now()
+	// Check if we are recovering connection, by checking passed connection Id is known to us.
+	nsmConnection, err := srv.request(ctx, request, srv.model.GetClientConnection(request.GetConnectionId()))
+	metrics.ObserveRequest(requestNetworkService(request), start, err)
+	return nsmConnection, err
+}
+
+func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRequest, existingConnection *model.ClientConnection) (result nsm.NSMConnection, err error) {
+	// Correlation id is created by first NSM handling request and passed to all downstream NSMs, NSEs and Dataplanes.
+	ctx, requestId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("NSM:(%v) request: %v", requestId, request)
 
 	// 0. Make sure its a valid request
-	err := request.IsValid()
+	err = request.IsValid()
 	if err != nil {
-		logrus.Error(err)
+		log.Error(err)
 		return nil, err
 	}
 
@@ -88,8 +139,32 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 		nsmConnection.SetId(existingConnection.GetId())
 	}
 
-	// 3. get dataplane
-	dp, err := srv.model.SelectDataplane()
+	// 2.2 Remember cross connect programmed into dataplane, since it will be modified during update.
+	var existingXcon *crossconnect.CrossConnect
+	if existingConnection != nil && existingConnection.Xcon != nil && existingConnection.ConnectionState != model.ClientConnection_Closed {
+		existingXcon = proto.Clone(existingConnection.Xcon).(*crossconnect.CrossConnect)
+	}
+	// 2.2.1 Remember previous destination, it is closed only after replacement is programmed into dataplane.
+	previousXcon := existingXcon
+	previousDestinationClosed := false
+
+	// 2.3 Record request and its outcome into connection journal.
+	srv.journal.Record(nsmConnection.GetId(), journal.EventRequestReceived, srv.requestReason(request, nsmConnection, existingConnection))
+	defer func() {
+		if err != nil {
+			srv.journal.Record(nsmConnection.GetId(), journal.EventRequestFailed, err.Error())
+		}
+	}()
+
+	// 3. get dataplane supporting requested mechanisms, endpoints of new connection are discovered once and used to
+	// select both dataplane and endpoint.
+	var discovery *registry.FindNetworkServiceResponse
+	if existingConnection == nil && !request.IsRemote() {
+		if discovery, err = srv.discoverNetworkService(ctx, nsmConnection); err != nil {
+			log.Warnf("NSM:(3-%v) Endpoints of network service are unknown: %v", requestId, err)
+		}
+	}
+	dp, err := srv.selectDataplane(requestId, request, existingConnection, discovery)
 	if err != nil {
 		return nil, err
 	}
@@ -106,12 +181,23 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 			requestNSEOnUpdate = true
 			closeDataplaneOnNSEFailed = true
 			// Network service is closing, we need to close remote NSM and re-programm local one.
-			if err := srv.close(ctx, existingConnection, false); err != nil {
-				logrus.Errorf("NSM:(4.1-%v) Error during close of NSE during Request.Upgrade %v Existing connection: %v error %v", requestId, request, existingConnection, err)
+			if err := srv.close(ctx, existingConnection, false, "network service is changed"); err != nil {
+				log.Errorf("NSM:(4.1-%v) Error during close of NSE during Request.Upgrade %v Existing connection: %v error %v", requestId, request, existingConnection, err)
 			}
+			previousDestinationClosed = true
 		} else {
 			// 4.2 Check if NSE is still required, if some more context requests are different.
 			requestNSEOnUpdate = srv.checkNeedNSERequest(requestId, nsmConnection, existingConnection, dp)
+			// 4.3 Remote NSM lease should be refreshed before it is expired.
+			if !requestNSEOnUpdate && srv.isRemoteLeaseRefreshRequired(existingConnection, time.Now()) {
+				log.Infof("NSM:(4.3-%v) Remote NSM connection lease will be refreshed", requestId)
+				requestNSEOnUpdate = true
+			}
+			// 4.4 Destination is down or its endpoint is drained, connection should be moved to another endpoint.
+			if !requestNSEOnUpdate && !srv.isDestinationAvailable(existingConnection) {
+				log.Infof("NSM:(4.4-%v) Destination is not available, connection will be moved", requestId)
+				requestNSEOnUpdate = true
+			}
 		}
 	}
 
@@ -120,13 +206,14 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 	if err != nil {
 		// 5.1 Close Datplane connection, if had existing one and NSE is closed.
 		if closeDataplaneOnNSEFailed {
-			srv.closeDataplaneLog(fmt.Sprintf("NSM:(5.1-%v) ", requestId), existingConnection)
+			srv.closeDataplaneLog(ctx, fmt.Sprintf("NSM:(5.1-%v) ", requestId), existingConnection)
 		}
 		return nil, err
 	}
+	srv.journal.Record(nsmConnection.GetId(), journal.EventMechanismSelected, srv.mechanismReason(nsmConnection, dp))
 
 	// 6. Prepare dataplane connection is fine.
-	logrus.Infof("NSM:(6-%v) Preparing to program dataplane: %v...", requestId, dp)
+	log.Infof("NSM:(6-%v) Preparing to program dataplane: %v...", requestId, dp)
 	dataplaneClient, dataplaneConn, err := srv.serviceRegistry.DataplaneConnection(dp)
 	if err != nil {
 		return nil, err
@@ -135,7 +222,7 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 		defer func() {
 			err := dataplaneConn.Close()
 			if err != nil {
-				logrus.Errorf("NSM:(6.1-%v) Error during close Dataplane connection: %v", requestId, err)
+				log.Errorf("NSM:(6.1-%v) Error during close Dataplane connection: %v", requestId, err)
 			}
 		}()
 	}
@@ -147,11 +234,11 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 	// 7. do a Request() on NSE and select it.
 	if existingConnection == nil || requestNSEOnUpdate {
 		//7.1 try find NSE and do a Request to it.
-		clientConnection, err = srv.findConnectNSE(requestId, ctx, ignore_endpoints, request, nsmConnection, existingConnection, dp)
+		clientConnection, err = srv.findConnectNSE(requestId, ctx, ignore_endpoints, request, nsmConnection, existingConnection, dp, discovery)
 		if err != nil {
 			if closeDataplaneOnNSEFailed {
 				// 7.1.x We are failed to find NSE, and we need to close local dataplane in case of recovery.
-				srv.closeDataplaneLog(fmt.Sprintf("NSM:(7.1-%v) ", requestId), existingConnection)
+				srv.closeDataplaneLog(ctx, fmt.Sprintf("NSM:(7.1-%v) ", requestId), existingConnection)
 			}
 			return nil, err
 		}
@@ -173,40 +260,94 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 	// 8. Remember original Request for Heal cases.
 	clientConnection.Request = request
 
+	// 8.1 Remember dataplane used before, since dataplane could be changed during Heal/Update.
+	var previousDataplane *model.Dataplane
+	if existingConnection != nil {
+		previousDataplane = existingConnection.Dataplane
+	}
+	clientConnection.Dataplane = dp
+
+	// 8.2 Source should refresh connection before its lease is expired.
+	srv.updateLease(clientConnection)
+
 	// 9. We need Add connection to model, or update it in case of Healing.
 	if existingConnection == nil {
 		srv.model.AddClientConnection(clientConnection)
 	}
 
 	// 10. We need to programm dataplane with our values.
-	// 10.1 TODO: Close current dataplane local configuration, since currently Dataplane doesn't support upgrade.
-	if existingConnection != nil {
-		if _, err := dataplaneClient.Close(ctx, existingConnection.Xcon); err != nil {
-			logrus.Errorf("NSM:(10.1-%v) Closing Dataplane error for local connection: %v", requestId, err)
+	if existingXcon != nil && previousDataplane != nil && previousDataplane.RegisteredName != dp.RegisteredName {
+		// 10.1 Dataplane is changed, so cross connect should be removed from previous one, if it is still alive.
+		if srv.model.GetDataplane(previousDataplane.RegisteredName) != nil {
+			if err := srv.closeDataplaneXcon(ctx, previousDataplane, existingXcon); err != nil {
+				log.Errorf("NSM:(10.1-%v) Closing previous Dataplane %v error for local connection: %v", requestId, previousDataplane.RegisteredName, err)
+			}
+		}
+		existingXcon = nil
+	}
+	retryPolicy := retry.NewPolicy(clientConnection.Endpoint.GetNetworkService())
+	updated := false
+	if existingXcon != nil {
+		// 10.1.1 Update cross connect in place, so dataplane will re-programm only changed configuration.
+		log.Infof("NSM:(10.1.1-%v) Sending update to dataplane: %v", requestId, clientConnection.Xcon)
+		err = retryPolicy.Do(ctx, fmt.Sprintf("NSM:(10.1.1-%v) Dataplane update", requestId), func(attempt int) error {
+			xcon, err := dataplaneClient.Update(ctx, &dataplane.CrossConnectUpdate{
+				Previous: existingXcon,
+				Current:  clientConnection.Xcon,
+			})
+			if err == nil {
+				clientConnection.Xcon = xcon
+			}
+			return err
+		})
+		if err != nil {
+			log.Errorf("NSM:(10.1.2-%v) Dataplane update failed, will do a full request: %s", requestId, err)
+			metrics.DataplaneErrorsTotal.WithLabelValues(dp.RegisteredName, metrics.OperationUpdate).Inc()
+			// 10.1.3 State of previous cross connect is unknown after failed update, so it should be removed before
+			// full request, otherwise its interfaces could be left in dataplane.
+			if err := srv.closeDataplaneXcon(ctx, dp, existingXcon); err != nil {
+				log.Errorf("NSM:(10.1.3-%v) Closing previous cross connect on Dataplane %v error: %v", requestId, dp.RegisteredName, err)
+			}
+		} else {
+			updated = true
 		}
 	}
-	// 10.2 Sending updated request to dataplane.
-	logrus.Infof("NSM:(10.2-%v) Sending request to dataplane: %v", requestId, clientConnection.Xcon)
-	clientConnection.Xcon, err = dataplaneClient.Request(ctx, clientConnection.Xcon)
-	if err != nil {
-		logrus.Errorf("NSM:(10.2.1-%v) Dataplane request failed: %s", requestId, err)
-		// Let's try again with a short delay
-		<-time.Tick(500)
-		logrus.Errorf("NSM:(10.2.2-%v) Dataplane request retry: %v", requestId, clientConnection.Xcon)
-		clientConnection.Xcon, err = dataplaneClient.Request(ctx, clientConnection.Xcon)
-
+	if !updated {
+		// 10.2 Sending request to dataplane, transient failures are retried according to Network Service retry policy.
+		log.Infof("NSM:(10.2-%v) Sending request to dataplane: %v", requestId, clientConnection.Xcon)
+		err = retryPolicy.Do(ctx, fmt.Sprintf("NSM:(10.2.1-%v) Dataplane request", requestId), func(attempt int) error {
+			xcon, err := dataplaneClient.Request(ctx, clientConnection.Xcon)
+			if err == nil {
+				clientConnection.Xcon = xcon
+			}
+			return err
+		})
 		if err != nil {
-			logrus.Errorf("NSM:(10.2.3-%v) Dataplane request retry failed: %s", requestId, err)
+			log.Errorf("NSM:(10.2.2-%v) Dataplane request failed: %s", requestId, err)
+			metrics.DataplaneErrorsTotal.WithLabelValues(dp.RegisteredName, metrics.OperationRequest).Inc()
 			// 10.3 If datplane configuration are failed, we need to close remore NSE actually.
-			if dp_err := srv.close(context.Background(), clientConnection, true); dp_err != nil {
-				logrus.Errorf("NSM:(10.2.4-%v) Failed to NSE.Close() caused by local dataplane configuration failure: %v", requestId, dp_err)
+			if dp_err := srv.close(context.Background(), clientConnection, true, "dataplane request failed"); dp_err != nil {
+				log.Errorf("NSM:(10.2.3-%v) Failed to NSE.Close() caused by local dataplane configuration failure: %v", requestId, dp_err)
 			}
 			// 10.4 We need to remove local connection we just added already.
 			srv.model.DeleteClientConnection(clientConnection.ConnectionId)
+			if !previousDestinationClosed {
+				srv.closePreviousDestination(ctx, requestId, existingConnection, previousXcon, clientConnection)
+			}
 			return nil, err
 		}
 	}
-	logrus.Infof("NSM:(10.3-%v) Dataplane configuration sucessfull %v", requestId, clientConnection.Xcon)
+	log.Infof("NSM:(10.3-%v) Dataplane configuration sucessfull %v", requestId, clientConnection.Xcon)
+	if updated {
+		srv.journal.Record(clientConnection.GetId(), journal.EventDataplaneProgrammed, fmt.Sprintf("cross connect is updated in dataplane %s", dp.RegisteredName))
+	} else {
+		srv.journal.Record(clientConnection.GetId(), journal.EventDataplaneProgrammed, fmt.Sprintf("cross connect is requested from dataplane %s", dp.RegisteredName))
+	}
+
+	// 10.5 Replacement is programmed, so previous destination could be closed.
+	if existingConnection != nil && requestNSEOnUpdate && !previousDestinationClosed {
+		srv.closePreviousDestination(ctx, requestId, existingConnection, previousXcon, clientConnection)
+	}
 
 	// 11. Send update for client connection
 	clientConnection.ConnectionState = model.ClientConnection_Ready
@@ -220,17 +361,17 @@ func (srv *networkServiceManager) request(ctx context.Context, request nsm.NSMRe
 	} else {
 		nsmConnection = clientConnection.Xcon.GetSource().(*crossconnect.CrossConnect_LocalSource).LocalSource
 	}
-	logrus.Infof("NSM:(11-%v) Request done...", requestId)
+	log.Infof("NSM:(11-%v) Request done...", requestId)
 	return nsmConnection, nil
 }
 
-func (srv *networkServiceManager) closeDataplaneLog(prefix string, existingConnection *model.ClientConnection) {
-	if dp_err := srv.closeDataplane(existingConnection); dp_err != nil {
-		logrus.Errorf("%v Failed to close local Dataplane for connection %v", prefix, existingConnection)
+func (srv *networkServiceManager) closeDataplaneLog(ctx context.Context, prefix string, existingConnection *model.ClientConnection) {
+	if dp_err := srv.closeDataplane(ctx, existingConnection); dp_err != nil {
+		tools.Log(ctx).Errorf("%v Failed to close local Dataplane for connection %v", prefix, existingConnection)
 	}
 }
 
-func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.Context, ignore_endpoints map[string]*registry.NSERegistration, request nsm.NSMRequest, nsmConnection nsm.NSMConnection, existingConnection *model.ClientConnection, dp *model.Dataplane) (*model.ClientConnection, error) {
+func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.Context, ignore_endpoints map[string]*registry.NSERegistration, request nsm.NSMRequest, nsmConnection nsm.NSMConnection, existingConnection *model.ClientConnection, dp *model.Dataplane, discovery *registry.FindNetworkServiceResponse) (*model.ClientConnection, error) {
 	// 7.x
 	var endpoint *registry.NSERegistration
 	var err error
@@ -243,7 +384,11 @@ func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.C
 
 		if existingConnection != nil {
 			// 7.2.1 Check previous endpoint, and it we will be able to contact it, it should be fine.
-			if ignore_endpoints[existingConnection.Endpoint.NetworkserviceEndpoint.EndpointName] == nil {
+			// Endpoint is selected again if previous destination is down.
+			existingEndpoint := existingConnection.Endpoint.GetNetworkserviceEndpoint()
+			targetEndpoint := nseConnection.GetNetworkServiceEndpointName()
+			if ignore_endpoints[existingEndpoint.GetEndpointName()] == nil && srv.isDestinationAvailable(existingConnection) &&
+				(targetEndpoint == "" || targetEndpoint == existingEndpoint.GetEndpointName()) {
 				endpoint = existingConnection.Endpoint
 			}
 		}
@@ -251,7 +396,7 @@ func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.C
 
 		if endpoint == nil {
 			// 7.2.3 Choose a new endpoint
-			endpoint, err = srv.getEndpoint(ctx, nseConnection, ignore_endpoints)
+			endpoint, err = srv.getEndpoint(ctx, nseConnection, ignore_endpoints, dp, discovery)
 		}
 		if err != nil {
 			// 7.2.4 No endpoints found, we need to return error, including last error for previous NSE
@@ -264,13 +409,23 @@ func (srv *networkServiceManager) findConnectNSE(requestId string, ctx context.C
 		// 7.2.5 Update Request with exclude_prefixes, etc
 		srv.updateExcludePrefixes(nseConnection)
 
-		// 7.2.6 perform request to NSE/remote NSMD/NSE
-		clientConnection, err = srv.performNSERequest(requestId, ctx, endpoint, nseConnection, request, dp, existingConnection)
+		srv.journal.Record(nseConnection.GetId(), journal.EventEndpointSelected, fmt.Sprintf("endpoint %s of NSM %s",
+			endpoint.GetNetworkserviceEndpoint().GetEndpointName(), endpoint.GetNetworkServiceManager().GetName()))
+
+		// 7.2.6 perform request to NSE/remote NSMD/NSE, transient failures are retried according to Network Service retry policy.
+		retryPolicy := retry.NewPolicy(endpoint.GetNetworkService())
+		err = retryPolicy.Do(ctx, fmt.Sprintf("NSM:(7.2.6-%v) NSE request", requestId), func(attempt int) error {
+			var requestErr error
+			clientConnection, requestErr = srv.performNSERequest(requestId, ctx, endpoint, srv.cloneConnection(request, nseConnection), request, dp, existingConnection)
+			return requestErr
+		})
 
 		// 7.2.7 in case of error we put NSE into ignored list to check another one.
 		if err != nil {
-			logrus.Errorf("NSM:(7.2.7-%v) NSE respond with error: %v ", requestId, err)
+			tools.Log(ctx).Errorf("NSM:(7.2.7-%v) NSE respond with error: %v ", requestId, err)
 			last_error = err
+			srv.journal.Record(nseConnection.GetId(), journal.EventEndpointFailed, fmt.Sprintf("endpoint %s respond with error: %v",
+				endpoint.GetNetworkserviceEndpoint().GetEndpointName(), err))
 			ignore_endpoints[endpoint.NetworkserviceEndpoint.EndpointName] = endpoint
 			continue
 		}
@@ -299,52 +454,86 @@ func (srv *networkServiceManager) newConnection(request nsm.NSMRequest) nsm.NSMC
 }
 
 func (srv *networkServiceManager) Close(ctx context.Context, connection nsm.NSMClientConnection) error {
-	return srv.close(ctx, connection.(*model.ClientConnection), true)
+	start := time.Now()
+	err := srv.close(ctx, connection.(*model.ClientConnection), true, "closed by source")
+	metrics.ObserveClose(connection.GetNetworkService(), start, err)
+	return err
 }
 
-func (srv *networkServiceManager) close(ctx context.Context, clientConnection *model.ClientConnection, closeDataplane bool) error {
-	logrus.Infof("Closing connection %v", clientConnection)
+func (srv *networkServiceManager) close(ctx context.Context, clientConnection *model.ClientConnection, closeDataplane bool, reason string) error {
+	ctx, closeId := tools.EnsureCorrelationId(ctx)
+	tools.Log(ctx).Infof("NSM:(%v) Closing connection %v", closeId, clientConnection)
 	if clientConnection.ConnectionState == model.ClientConnection_Closing {
 		return nil
 	}
+	srv.journal.Record(clientConnection.GetId(), journal.EventClosed, reason)
 	clientConnection.ConnectionState = model.ClientConnection_Closing
-	var nseClientError error
-	var nseCloseError error
-
-	client, nseClientError := srv.createNSEClient(clientConnection.Endpoint)
-
-	if client != nil {
-		defer func() {
-			err := client.Cleanup()
-			if err != nil {
-				logrus.Errorf("Error during Cleanup: %v", err)
-			}
-		}()
-		ld := clientConnection.Xcon.GetLocalDestination()
-		if ld != nil {
-			nseCloseError = client.Close(ctx, ld)
-		}
-		rd := clientConnection.Xcon.GetRemoteDestination()
-		if rd != nil {
-			nseCloseError = client.Close(ctx, rd)
-		}
-	} else {
-		logrus.Errorf("Failed to create NSE Client %v", nseClientError)
-	}
+	nseCloseError := srv.closeDestination(ctx, clientConnection.Endpoint, clientConnection.Xcon)
 	var dpCloseError error = nil
 	if closeDataplane {
-		dpCloseError = srv.closeDataplane(clientConnection)
+		dpCloseError = srv.closeDataplane(ctx, clientConnection)
 		// TODO: We need to be sure Dataplane is respond well so we could delete connection.
 		srv.model.DeleteClientConnection(clientConnection.ConnectionId)
 	}
+	// VNI is not used by connection anymore, so it could be allocated to other connections.
+	srv.serviceRegistry.VniAllocator().Release(clientConnection.ConnectionId)
 	clientConnection.ConnectionState = model.ClientConnection_Closed
 
-	if nseClientError != nil || nseCloseError != nil || dpCloseError != nil {
-		return fmt.Errorf("Close error: %v", []error{nseClientError, nseCloseError, dpCloseError})
+	if nseCloseError != nil || dpCloseError != nil {
+		return fmt.Errorf("Close error: %v", []error{nseCloseError, dpCloseError})
 	}
 	return nil
 }
 
+// closeDestination - closes destination side of cross connect on NSE or remote NSM.
+func (srv *networkServiceManager) closeDestination(ctx context.Context, endpoint *registry.NSERegistration, xcon *crossconnect.CrossConnect) error {
+	log := tools.Log(ctx)
+	client, err := srv.createNSEClient(endpoint)
+	if err != nil {
+		log.Errorf("Failed to create NSE Client %v", err)
+		return err
+	}
+	defer func() {
+		err := client.Cleanup()
+		if err != nil {
+			log.Errorf("Error during Cleanup: %v", err)
+		}
+	}()
+	if ld := xcon.GetLocalDestination(); ld != nil {
+		return client.Close(ctx, ld)
+	}
+	if rd := xcon.GetRemoteDestination(); rd != nil {
+		return client.Close(ctx, rd)
+	}
+	return nil
+}
+
+// closePreviousDestination - closes destination replaced by update or heal, it is kept until replacement is ready.
+func (srv *networkServiceManager) closePreviousDestination(ctx context.Context, requestId string, existingConnection *model.ClientConnection, previousXcon *crossconnect.CrossConnect, clientConnection *model.ClientConnection) {
+	log := tools.Log(ctx)
+	if previousXcon == nil || existingConnection.Endpoint == nil {
+		return
+	}
+	// Destination ids are unique within NSM managing destination connection.
+	previousNsm := existingConnection.Endpoint.GetNetworkServiceManager().GetName()
+	if previousNsm == clientConnection.Endpoint.GetNetworkServiceManager().GetName() &&
+		destinationId(previousXcon) == destinationId(clientConnection.Xcon) {
+		// Same destination is updated in place.
+		return
+	}
+	log.Infof("NSM:(10.5-%v) Closing previous destination %v of NSM %v", requestId, destinationId(previousXcon), previousNsm)
+	if err := srv.closeDestination(ctx, existingConnection.Endpoint, previousXcon); err != nil {
+		log.Errorf("NSM:(10.5.1-%v) Failed to close previous destination: %v", requestId, err)
+	}
+}
+
+func destinationId(xcon *crossconnect.CrossConnect) string {
+	if ld := xcon.GetLocalDestination(); ld != nil {
+		return ld.GetId()
+	}
+	return xcon.GetRemoteDestination().GetId()
+}
+
 func (srv *networkServiceManager) isLocalEndpoint(endpoint *registry.NSERegistration) bool {
 	return srv.getNetworkServiceManagerName() == endpoint.GetNetworkServiceManager().GetName()
 }
@@ -366,16 +555,17 @@ func (srv *networkServiceManager) createNSEClient(endpoint *registry.NSERegistra
 }
 
 func (srv *networkServiceManager) performNSERequest(requestId string, ctx context.Context, endpoint *registry.NSERegistration, requestConnection nsm.NSMConnection, request nsm.NSMRequest, dp *model.Dataplane, existingConnection *model.ClientConnection) (*model.ClientConnection, error) {
+	log := tools.Log(ctx)
 	// 7.2.6.x
 	client, err := srv.createNSEClient(endpoint)
 	if err != nil {
 		// 7.2.6.1
-		return nil, fmt.Errorf("NSM:(7.2.6.1-%v) Failed to create NSE Client", err)
+		return nil, status.Errorf(codes.Unavailable, "NSM:(7.2.6.1-%v) Failed to create NSE Client: %v", requestId, err)
 	}
 	defer func() {
 		err := client.Cleanup()
 		if err != nil {
-			logrus.Errorf("NSM:(7.2.6.2-%v) Error during Cleanup: %v", requestId, err)
+			log.Errorf("NSM:(7.2.6.2-%v) Error during Cleanup: %v", requestId, err)
 		}
 	}()
 
@@ -385,11 +575,13 @@ func (srv *networkServiceManager) performNSERequest(requestId string, ctx contex
 	} else {
 		message = srv.createRemoteNSMRequest(endpoint, requestConnection, dp, existingConnection)
 	}
-	logrus.Infof("NSM:(7.2.6.2-%v) Requesting NSE with request %v", requestId, message)
-	nseConnection, e := client.Request(ctx, message)
+	log.Infof("NSM:(7.2.6.2-%v) Requesting NSE with request %v", requestId, message)
+	nseCtx, cancel := context.WithTimeout(ctx, srv.getSettings().NseConnectionTimeout)
+	defer cancel()
+	nseConnection, e := client.Request(nseCtx, message)
 
 	if e != nil {
-		logrus.Errorf("NSM:(7.2.6.2.1-%v) error requesting networkservice from %+v with message %#v error: %s", requestId, endpoint, message, e)
+		log.Errorf("NSM:(7.2.6.2.1-%v) error requesting networkservice from %+v with message %#v error: %s", requestId, endpoint, message, e)
 		return nil, e
 	}
 
@@ -420,6 +612,7 @@ func (srv *networkServiceManager) performNSERequest(requestId string, ctx contex
 	// 7.2.6.2.6 - It not a local NSE put remote NSM name in request
 	if !srv.isLocalEndpoint(endpoint) {
 		clientConnection.RemoteNsm = endpoint.GetNetworkServiceManager()
+		clientConnection.RemoteLease = remoteLease(clientConnection)
 	}
 	return clientConnection, nil
 }
@@ -442,7 +635,7 @@ func (srv *networkServiceManager) createCrossConnect(requestConnection nsm.NSMCo
 	}
 
 	// We handling request from local or remote endpoint.
-	//TODO: in case of remote NSE( different cluster case, this method should be changed)
+	// Endpoints of another domain are remote as well, their remote NSM is reached through domain proxy NSMD.
 	if !srv.isLocalEndpoint(endpoint) {
 		dpApiConnection.Destination = &crossconnect.CrossConnect_RemoteDestination{
 			RemoteDestination: nseConnection.(*remote_connection.Connection),
@@ -467,21 +660,27 @@ func (srv *networkServiceManager) createConnectionId() string {
 	return srv.model.ConnectionId()
 }
 
-func (srv *networkServiceManager) closeDataplane(clientConnection *model.ClientConnection) error {
-	logrus.Info("Closing cross connection on dataplane...")
-	dataplaneClient, conn, err := srv.serviceRegistry.DataplaneConnection(clientConnection.Dataplane)
+func (srv *networkServiceManager) closeDataplane(ctx context.Context, clientConnection *model.ClientConnection) error {
+	return srv.closeDataplaneXcon(ctx, clientConnection.Dataplane, clientConnection.Xcon)
+}
+
+func (srv *networkServiceManager) closeDataplaneXcon(ctx context.Context, dataplane *model.Dataplane, xcon *crossconnect.CrossConnect) error {
+	log := tools.Log(ctx)
+	log.Infof("NSM:(%v) Closing cross connection on dataplane...", tools.CorrelationId(ctx))
+	dataplaneClient, conn, err := srv.serviceRegistry.DataplaneConnection(dataplane)
 	if err != nil {
-		logrus.Error(err)
+		log.Error(err)
 		return err
 	}
 	if conn != nil {
 		defer conn.Close()
 	}
-	if _, err := dataplaneClient.Close(context.Background(), clientConnection.Xcon); err != nil {
-		logrus.Error(err)
+	if _, err := dataplaneClient.Close(ctx, xcon); err != nil {
+		log.Error(err)
+		metrics.DataplaneErrorsTotal.WithLabelValues(dataplane.RegisteredName, metrics.OperationClose).Inc()
 		return err
 	}
-	logrus.Info("Cross connection successfully closed on dataplane")
+	log.Infof("NSM:(%v) Cross connection successfully closed on dataplane", tools.CorrelationId(ctx))
 	return nil
 }
 
@@ -504,41 +703,69 @@ func (srv *networkServiceManager) updateExcludePrefixes(requestConnection nsm.NS
 	if c == nil {
 		c = &connectioncontext.ConnectionContext{}
 	}
-	for _, ep := range srv.excluded_prefixes {
+	for _, ep := range srv.getExcludedPrefixes() {
 		c.ExcludedPrefixes = append(c.ExcludedPrefixes, ep)
 	}
 	// Since we do not worry about validation, just
 	requestConnection.SetContext(c)
 }
 
-func (srv *networkServiceManager) getEndpoint(ctx context.Context, requestConnection nsm.NSMConnection, ignore_endpoints map[string]*registry.NSERegistration) (*registry.NSERegistration, error) {
+// discoverNetworkService - finds endpoints of network service requested. Nil is returned if endpoints are not
+// discovered via registry, since particular endpoint or network service of another domain is requested.
+func (srv *networkServiceManager) discoverNetworkService(ctx context.Context, requestConnection nsm.NSMConnection) (*registry.FindNetworkServiceResponse, error) {
+	if requestConnection.GetNetworkServiceEndpointName() != "" {
+		return nil, nil
+	}
+	if _, domain := interdomain.SplitDomain(requestConnection.GetNetworkService()); domain != "" {
+		return nil, nil
+	}
+	discoveryClient, err := srv.serviceRegistry.NetworkServiceDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	return discoveryClient.FindNetworkService(ctx, &registry.FindNetworkServiceRequest{
+		NetworkServiceName: requestConnection.GetNetworkService(),
+	})
+}
+
+// getEndpoint - selects endpoint for connection, endpoints are discovered unless discovery response is passed.
+func (srv *networkServiceManager) getEndpoint(ctx context.Context, requestConnection nsm.NSMConnection, ignore_endpoints map[string]*registry.NSERegistration, dp *model.Dataplane, discovery *registry.FindNetworkServiceResponse) (*registry.NSERegistration, error) {
 
 	// Handle case we are remote NSM and asked for particular endpoint to connect to.
 	targetEndpoint := requestConnection.GetNetworkServiceEndpointName()
 	if len(targetEndpoint) > 0 {
 		endpoint := srv.model.GetEndpoint(targetEndpoint)
-		if endpoint != nil && ignore_endpoints[endpoint.NetworkserviceEndpoint.EndpointName] == nil {
+		if endpoint != nil && ignore_endpoints[endpoint.NetworkserviceEndpoint.EndpointName] == nil && srv.isEndpointAvailable(endpoint.GetNetworkserviceEndpoint()) {
 			return endpoint, nil
 		} else {
 			return nil, fmt.Errorf("Could not find endpoint with name: %s at local registry", targetEndpoint)
 		}
 	}
 
-	// Get endpoints, do it every time since we do not know if list are changed or not.
-	discoveryClient, err := srv.serviceRegistry.NetworkServiceDiscovery()
-	if err != nil {
-		logrus.Error(err)
-		return nil, err
-	}
-	nseRequest := &registry.FindNetworkServiceRequest{
-		NetworkServiceName: requestConnection.GetNetworkService(),
+	// Handle case Network Service is hosted in another domain, it is requested as service@domain.
+	if networkService, domain := interdomain.SplitDomain(requestConnection.GetNetworkService()); domain != "" {
+		return srv.getDomainEndpoint(ctx, requestConnection, ignore_endpoints, networkService, domain)
 	}
-	endpointResponse, err := discoveryClient.FindNetworkService(ctx, nseRequest)
-	if err != nil {
-		logrus.Error(err)
-		return nil, err
+
+	// Get endpoints unless they are already discovered for this request.
+	endpointResponse := discovery
+	if endpointResponse == nil {
+		var err error
+		if endpointResponse, err = srv.discoverNetworkService(ctx, requestConnection); err != nil {
+			tools.Log(ctx).Error(err)
+			return nil, err
+		}
 	}
 	endpoints := srv.filterEndpoints(endpointResponse.GetNetworkServiceEndpoints(), ignore_endpoints)
+	// Endpoints of NSMs having no remote mechanism in common with dataplane could not be connected.
+	compatible := []*registry.NetworkServiceEndpoint{}
+	for _, endpoint := range endpoints {
+		manager := endpointResponse.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()]
+		if endpoint.GetNetworkServiceManagerName() == srv.getNetworkServiceManagerName() || isRemoteMechanismCompatible(manager.GetRemoteMechanisms(), dp) {
+			compatible = append(compatible, endpoint)
+		}
+	}
+	endpoints = compatible
 
 	if len(endpoints) == 0 {
 		return nil, fmt.Errorf("Failed to find NSE for NetworkService %s. Checked: %d of total NSEs: %d",
@@ -547,7 +774,7 @@ func (srv *networkServiceManager) getEndpoint(ctx context.Context, requestConnec
 
 	endpoint := srv.model.GetSelector().SelectEndpoint(requestConnection.(*connection.Connection), endpointResponse.GetNetworkService(), endpoints)
 	if endpoint == nil {
-		return nil, err
+		return nil, fmt.Errorf("Failed to select NSE for NetworkService %s", requestConnection.GetNetworkService())
 	}
 	return &registry.NSERegistration{
 		NetworkServiceManager:  endpointResponse.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()],
@@ -558,9 +785,9 @@ func (srv *networkServiceManager) getEndpoint(ctx context.Context, requestConnec
 
 func (srv *networkServiceManager) filterEndpoints(endpoints []*registry.NetworkServiceEndpoint, ignore_endpoints map[string]*registry.NSERegistration) []*registry.NetworkServiceEndpoint {
 	result := []*registry.NetworkServiceEndpoint{}
-	// Do filter of endpoints
+	// Do filter of endpoints, unhealthy and draining endpoints are skipped as well.
 	for _, candidate := range endpoints {
-		if ignore_endpoints[candidate.GetEndpointName()] == nil {
+		if ignore_endpoints[candidate.GetEndpointName()] == nil && srv.isEndpointAvailable(candidate) {
 			result = append(result, candidate)
 		}
 	}
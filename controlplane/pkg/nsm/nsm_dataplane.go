@@ -0,0 +1,134 @@
+package nsm
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+// selectDataplane - selects a dataplane supporting mechanisms requested, in case of heal/update existing dataplane
+// is preferred if it is still registered and could serve request. Discovery is a response of registry for network
+// service requested, it is nil if endpoints are not discovered.
+func (srv *networkServiceManager) selectDataplane(requestId string, request nsm.NSMRequest, existingConnection *model.ClientConnection, discovery *registry.FindNetworkServiceResponse) (*model.Dataplane, error) {
+	// 3.1 Keep existing dataplane if possible, so cross connect will not be moved between dataplanes.
+	if existingConnection != nil && existingConnection.Dataplane != nil {
+		if dp := srv.model.GetDataplane(existingConnection.Dataplane.RegisteredName); dp != nil && srv.isDataplaneMatches(request, existingConnection, dp) {
+			return dp, nil
+		}
+	}
+
+	// 3.2 Select dataplane supports both local and remote mechanisms required.
+	var peerMechanisms [][]string
+	if !request.IsRemote() && existingConnection == nil {
+		peerMechanisms = srv.getPeerRemoteMechanisms(discovery)
+	}
+	dp, err := srv.model.SelectDataplane(func(dp *model.Dataplane) bool {
+		return srv.isDataplaneMatches(request, existingConnection, dp) && isPeerCompatible(peerMechanisms, dp)
+	})
+	if err != nil && existingConnection != nil {
+		// 3.3 No dataplane supports remote mechanism used before, so we will need to re-negotiate it with remote side.
+		logrus.Infof("NSM:(3.3-%v) No dataplane supports previously selected remote mechanism, re-negotiating", requestId)
+		dp, err = srv.model.SelectDataplane(func(dp *model.Dataplane) bool {
+			return srv.isDataplaneMatches(request, nil, dp)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("NSM:(3.4-%v) Dataplane selected: %v", requestId, dp.RegisteredName)
+	return dp, nil
+}
+
+// isDataplaneMatches - checks if dataplane supports at least one of mechanisms preferred by request, and
+// remote mechanism used by existing connection to communicate with remote NSM.
+func (srv *networkServiceManager) isDataplaneMatches(request nsm.NSMRequest, existingConnection *model.ClientConnection, dp *model.Dataplane) bool {
+	if request.IsRemote() {
+		if !isRemoteMechanismSupported(request.(*remote_networkservice.NetworkServiceRequest), dp) {
+			return false
+		}
+	} else {
+		if !isLocalMechanismSupported(request.(*networkservice.NetworkServiceRequest), dp) {
+			return false
+		}
+	}
+	if existingConnection != nil {
+		if remoteDestination := existingConnection.Xcon.GetRemoteDestination(); remoteDestination != nil {
+			return findRemoteMechanism(dp.RemoteMechanisms, remoteDestination.GetMechanism().GetType()) != nil
+		}
+	}
+	return true
+}
+
+func isLocalMechanismSupported(request *networkservice.NetworkServiceRequest, dp *model.Dataplane) bool {
+	for _, m := range request.GetMechanismPreferences() {
+		if findLocalMechanism(dp.LocalMechanisms, m.GetType()) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func isRemoteMechanismSupported(request *remote_networkservice.NetworkServiceRequest, dp *model.Dataplane) bool {
+	for _, m := range request.GetMechanismPreferences() {
+		if findRemoteMechanism(dp.RemoteMechanisms, m.GetType()) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// getPeerRemoteMechanisms - returns remote mechanism types supported by every remote NSM providing network service,
+// so dataplane having remote mechanism in common with at least one of them is selected. Nil is returned if any
+// dataplane could be used: there is local endpoint, NSM does not advertise its remote mechanisms or endpoints are
+// not known.
+func (srv *networkServiceManager) getPeerRemoteMechanisms(response *registry.FindNetworkServiceResponse) [][]string {
+	if response == nil {
+		return nil
+	}
+	result := [][]string{}
+	for _, endpoint := range srv.filterEndpoints(response.GetNetworkServiceEndpoints(), nil) {
+		if endpoint.GetNetworkServiceManagerName() == srv.getNetworkServiceManagerName() {
+			return nil
+		}
+		mechanisms := response.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()].GetRemoteMechanisms()
+		if len(mechanisms) == 0 {
+			return nil
+		}
+		result = append(result, mechanisms)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// isPeerCompatible - checks if dataplane supports at least one remote mechanism of any of peers.
+func isPeerCompatible(peerMechanisms [][]string, dp *model.Dataplane) bool {
+	if peerMechanisms == nil {
+		return true
+	}
+	for _, mechanisms := range peerMechanisms {
+		if isRemoteMechanismCompatible(mechanisms, dp) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRemoteMechanismCompatible - checks if dataplane supports one of remote mechanisms, empty list means
+// mechanisms are unknown and any dataplane is compatible.
+func isRemoteMechanismCompatible(mechanisms []string, dp *model.Dataplane) bool {
+	if len(mechanisms) == 0 {
+		return true
+	}
+	for _, name := range mechanisms {
+		if mechanismType, ok := remote_connection.MechanismType_value[name]; ok && findRemoteMechanism(dp.RemoteMechanisms, remote_connection.MechanismType(mechanismType)) != nil {
+			return true
+		}
+	}
+	return false
+}
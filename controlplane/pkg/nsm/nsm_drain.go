@@ -0,0 +1,113 @@
+package nsm
+
+import (
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// drainPollInterval - how often connections of remote sources are checked to be moved from drained endpoint.
+const drainPollInterval = 100 * time.Millisecond
+
+// DrainEndpoint - marks endpoint as draining, so it is not selected for new connections, and moves its connections
+// to other endpoints of the same Network Service using heal procedure. Connections of local sources are re-requested
+// by NSM itself, sources of remote NSMs are notified to re-request them and connections not moved in time are closed.
+func (srv *networkServiceManager) DrainEndpoint(ctx context.Context, endpointName string) error {
+	logrus.Infof("NSM_Drain(1-%v) Draining endpoint", endpointName)
+	if err := srv.model.SetEndpointState(endpointName, registry.EndpointStateDraining); err != nil {
+		logrus.Errorf("NSM_Drain(1.1-%v) Failed to mark endpoint as draining: %v", endpointName, err)
+		return err
+	}
+	remoteSourced := map[string]bool{}
+	for _, clientConnection := range srv.endpointConnections(endpointName) {
+		if ctx.Err() != nil {
+			logrus.Errorf("NSM_Drain(2.1-%v) Draining is interrupted: %v", endpointName, ctx.Err())
+			return ctx.Err()
+		}
+		if clientConnection.ConnectionState != model.ClientConnection_Ready {
+			// Connection is already healing or closing.
+			continue
+		}
+		if clientConnection.Xcon.GetRemoteSource() != nil {
+			remoteSourced[clientConnection.GetId()] = true
+		}
+		logrus.Infof("NSM_Drain(2-%v) Migrating connection %v", endpointName, clientConnection.GetId())
+		srv.Heal(clientConnection, nsm.HealState_DstDown)
+	}
+	if len(remoteSourced) > 0 {
+		srv.waitRemoteSourcesMigrated(ctx, endpointName, remoteSourced)
+	}
+	logrus.Infof("NSM_Drain(4-%v) Endpoint is drained", endpointName)
+	return nil
+}
+
+func (srv *networkServiceManager) endpointConnections(endpointName string) []*model.ClientConnection {
+	var result []*model.ClientConnection
+	for _, clientConnection := range srv.model.GetAllClientConnections() {
+		if clientConnection.Endpoint.GetNetworkserviceEndpoint().GetEndpointName() == endpointName {
+			result = append(result, clientConnection)
+		}
+	}
+	return result
+}
+
+// waitRemoteSourcesMigrated - waits for remote NSMs to move connections to another endpoint, connections still using
+// drained endpoint after heal timeout are closed.
+func (srv *networkServiceManager) waitRemoteSourcesMigrated(ctx context.Context, endpointName string, remoteSourced map[string]bool) {
+	logrus.Infof("NSM_Drain(3-%v) Waiting for remote NSMs to move %d connections", endpointName, len(remoteSourced))
+	ctx, cancel := context.WithTimeout(ctx, srv.getSettings().HealTimeout)
+	defer cancel()
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		remaining := []*model.ClientConnection{}
+		for _, clientConnection := range srv.endpointConnections(endpointName) {
+			if remoteSourced[clientConnection.GetId()] {
+				remaining = append(remaining, clientConnection)
+			}
+		}
+		if len(remaining) == 0 {
+			return
+		}
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+		}
+		for _, clientConnection := range remaining {
+			logrus.Warnf("NSM_Drain(3.1-%v) Connection %v is not moved by remote NSM in time, closing", endpointName, clientConnection.GetId())
+			if err := srv.close(context.Background(), clientConnection, true, "endpoint is drained"); err != nil {
+				logrus.Errorf("NSM_Drain(3.2-%v) Error closing connection %v: %v", endpointName, clientConnection.GetId(), err)
+			}
+		}
+		return
+	}
+}
+
+// isEndpointAvailable - checks if endpoint could be used for new connections, it should be healthy and not draining.
+func (srv *networkServiceManager) isEndpointAvailable(endpoint *registry.NetworkServiceEndpoint) bool {
+	if endpoint.GetState() == registry.EndpointStateDraining {
+		return false
+	}
+	if local := srv.model.GetEndpoint(endpoint.GetEndpointName()); local.GetNetworkserviceEndpoint().GetState() == registry.EndpointStateDraining {
+		return false
+	}
+	return srv.model.IsEndpointHealthy(endpoint.GetEndpointName())
+}
+
+// isDestinationAvailable - checks if connection destination is up and its endpoint is not drained.
+func (srv *networkServiceManager) isDestinationAvailable(clientConnection *model.ClientConnection) bool {
+	if ld := clientConnection.Xcon.GetLocalDestination(); ld != nil && ld.GetState() == connection.State_DOWN {
+		return false
+	}
+	if rd := clientConnection.Xcon.GetRemoteDestination(); rd != nil && rd.GetState() == remote_connection.State_DOWN {
+		return false
+	}
+	return srv.isEndpointAvailable(clientConnection.Endpoint.GetNetworkserviceEndpoint())
+}
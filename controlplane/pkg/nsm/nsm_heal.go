@@ -2,15 +2,20 @@ package nsm
 
 import (
 	"fmt"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
-	"github.com/sirupsen/logrus"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"golang.org/x/net/context"
 )
 
 func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healState nsm.HealState) {
-	healId := create_logid()
-	logrus.Infof("NSM_Heal(1-%v) %v", healId, connection)
+	healId := tools.NewCorrelationId()
+	log := tools.Log(tools.WithCorrelationId(context.Background(), healId))
+	log.Infof("NSM_Heal(1-%v) %v", healId, connection)
 
 	clientConnection := connection.(*model.ClientConnection)
 	if clientConnection.ConnectionState != model.ClientConnection_Ready {
@@ -18,59 +23,60 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 		return
 	}
 
+	srv.journal.Record(clientConnection.GetId(), journal.EventHealStarted, healReasons[healState])
 	defer func() {
-		logrus.Infof("NSM_Heal(1.1-%v) Connection %v healing state is finished...", healId, clientConnection.GetId())
-		clientConnection.ConnectionState = model.ClientConnection_Ready
+		log.Infof("NSM_Heal(1.1-%v) Connection %v healing state is finished...", healId, clientConnection.GetId())
+		if clientConnection.ConnectionState == model.ClientConnection_Healing {
+			clientConnection.ConnectionState = model.ClientConnection_Ready
+		}
+		// Connection could be replaced in model by recovered one.
+		state := model.ClientConnectionState(model.ClientConnection_Closed)
+		if current := srv.model.GetClientConnection(clientConnection.GetId()); current != nil {
+			state = current.ConnectionState
+		}
+		srv.journal.Record(clientConnection.GetId(), journal.EventHealFinished, fmt.Sprintf("connection is %s", state))
+		if state == model.ClientConnection_Ready {
+			metrics.HealsTotal.WithLabelValues(healState.String(), metrics.HealResultRecovered).Inc()
+		} else {
+			metrics.HealsTotal.WithLabelValues(healState.String(), metrics.HealResultClosed).Inc()
+		}
 	}()
 
 	clientConnection.ConnectionState = model.ClientConnection_Healing
 
-	ctx, cancel := context.WithTimeout(context.Background(), HealTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), srv.getSettings().HealTimeout)
 	defer cancel()
+	// Requests done during heal are correlated with heal itself.
+	ctx = tools.WithCorrelationId(ctx, healId)
 
 	// 2 Choose heal style
 	switch healState {
 	case nsm.HealState_DstDown:
 		// Destination is down, we need to find it again.
 		if clientConnection.Xcon.GetRemoteSource() != nil {
-			// NSMd id remote one, we just need to close and return.
-			logrus.Infof("NSM_Heal(2.1-%v) Remote NSE heal is done on source side", healId)
-			break
-		} else {
-			// We are client NSMd, we need to try recover our connection.
-			//srv.
-			logrus.Infof("NSM_Heal(2.2-%v) Closing local connection: %v", healId, connection.GetConnectionSource())
-			// Since Dataplane could work unproperly if we do not close existing one, we do so.
-			err := srv.close(ctx, clientConnection, true)
-			if err != nil {
-				logrus.Warnf("NSM_Heal(2.2.1-%v) Ignored error during connection healing: %v", healId, err)
-			}
-			recoveredConnection, err := srv.request(ctx, clientConnection.Request, clientConnection)
-			logrus.Infof("NSM_Heal(2.3-%v) Recovered: %v", healId, recoveredConnection)
-			if err != nil {
-				logrus.Errorf("NSM_Heal(2.3.1-%v) Failed to heal connection: %v", healId, err)
-				// We need to delete connection, since we are not able to Heal it
-				srv.model.DeleteClientConnection(clientConnection.ConnectionId)
-				if err != nil {
-					logrus.Errorf("NSM_Heal(2.3.2-%v) Error in Recovery Close: %v", healId, err)
-				}
-				clientConnection.ConnectionState = model.ClientConnection_Closed
-			} else {
-				logrus.Infof("NSM_Heal(2.4-%v) Heal: Connection recovered: %v", healId, connection)
-			}
+			// NSMd id remote one, source NSM is notified to re-request connection and will close this one then.
+			log.Infof("NSM_Heal(2.1-%v) Remote NSE heal is done on source side", healId)
+			clientConnection.Xcon.GetRemoteSource().State = remote_connection.State_DOWN
+			srv.model.UpdateClientConnection(clientConnection)
 			return
 		}
-
-		// Let's Close remote connection and re-create new one.
+		// We are client NSMd, we need to try recover our connection.
+		// Replacement is requested first, previous destination is closed when dataplane is re-programmed.
+		log.Infof("NSM_Heal(2.2-%v) Re-requesting local connection: %v", healId, connection.GetConnectionSource())
+		markDestinationDown(clientConnection)
+		request := clientConnection.Request.Clone()
+		request.SetConnection(clientConnection.GetConnectionSource())
+		srv.requestOrClose(fmt.Sprintf("NSM_Heal(2.3-%v) ", healId), ctx, request, clientConnection)
+		return
 	case nsm.HealState_DataplaneDown:
 		// Dataplane is down, we only need to re-programm dataplane.
 		// 1. Wait for dataplane to appear.
-		logrus.Infof("NSM_Heal(3.1-%v) Waiting for Dataplane to recovery...", healId)
+		log.Infof("NSM_Heal(3.1-%v) Waiting for Dataplane to recovery...", healId)
 		if err := srv.serviceRegistry.WaitForDataplaneAvailable(srv.model, HealDataplaneTimeout); err != nil {
-			logrus.Errorf("NSM_Heal(3.1-%v) Dataplane is not available on recovery for timeout %v: %v", HealDataplaneTimeout, healId, err)
+			log.Errorf("NSM_Heal(3.1-%v) Dataplane is not available on recovery for timeout %v: %v", HealDataplaneTimeout, healId, err)
 			break
 		}
-		logrus.Infof("NSM_Heal(3.2-%v) Dataplane is now available...", healId)
+		log.Infof("NSM_Heal(3.2-%v) Dataplane is now available...", healId)
 
 		// We could send connection is down now.
 		srv.model.UpdateClientConnection(clientConnection)
@@ -78,7 +84,7 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 		if clientConnection.Xcon.GetRemoteSource() != nil {
 			// NSMd id remote one, we just need to close and return.
 			// Recovery will be performed by NSM client side.
-			logrus.Infof("NSM_Heal(3.3-%v)  Healing will be continued on source side...", healId)
+			log.Infof("NSM_Heal(3.3-%v)  Healing will be continued on source side...", healId)
 			return
 		}
 
@@ -88,10 +94,18 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 		request.SetConnection(clientConnection.GetConnectionSource())
 		srv.requestOrClose(fmt.Sprintf("NSM_Heal(3.4-%v) ", healId), ctx, request, clientConnection)
 		return
+	case nsm.HealState_SrcDown:
+		// Source is gone, so there is nobody to recover connection for. We need to close NSE/Remote NSM side,
+		// dataplane and release all resources allocated for connection.
+		log.Infof("NSM_Heal(5.1-%v) Source is down, closing connection: %v", healId, clientConnection)
+		if err := srv.close(ctx, clientConnection, true, "source is down"); err != nil {
+			log.Errorf("NSM_Heal(5.2-%v) Error in Source Down Close: %v", healId, err)
+		}
+		return
 	case nsm.HealState_DstUpdate:
 		// Remote DST is updated.
 		// Update request to contain a proper connection object from previous attempt.
-		logrus.Infof("NSM_Heal(4.1-%v) Healing DST Update/Remote Dataplane... %v", healId, clientConnection)
+		log.Infof("NSM_Heal(4.1-%v) Healing DST Update/Remote Dataplane... %v", healId, clientConnection)
 		if clientConnection.Request != nil {
 			request := clientConnection.Request.Clone()
 			request.SetConnection(clientConnection.GetConnectionSource())
@@ -102,22 +116,32 @@ func (srv *networkServiceManager) Heal(connection nsm.NSMClientConnection, healS
 	}
 
 	// Close both connection and dataplane
-	err := srv.Close(context.Background(), clientConnection)
+	err := srv.close(tools.WithCorrelationId(context.Background(), healId), clientConnection, true, "connection could not be healed")
 	if err != nil {
-		logrus.Errorf("NSM_Heal(4-%v) Error in Recovery: %v", healId, err)
+		log.Errorf("NSM_Heal(4-%v) Error in Recovery: %v", healId, err)
 	}
 
 }
 
 func (srv *networkServiceManager) requestOrClose(logPrefix string, ctx context.Context, request nsm.NSMRequest, clientConnection *model.ClientConnection) {
-	logrus.Infof("%v delegate to Request %v", logPrefix, request)
+	log := tools.Log(ctx)
+	log.Infof("%v delegate to Request %v", logPrefix, request)
 	connection, err := srv.request(ctx, request, clientConnection)
 	if err != nil {
-		logrus.Errorf("%v Failed to heal connection: %v", logPrefix, err)
+		log.Errorf("%v Failed to heal connection: %v", logPrefix, err)
 		// Close in case of any errors in recovery.
-		err = srv.Close(context.Background(), clientConnection)
-		logrus.Errorf("%v Error in Recovery Close: %v", logPrefix, err)
+		err = srv.close(tools.WithCorrelationId(context.Background(), tools.CorrelationId(ctx)), clientConnection, true, fmt.Sprintf("heal request failed: %v", err))
+		log.Errorf("%v Error in Recovery Close: %v", logPrefix, err)
 	} else {
-		logrus.Infof("%v Heal: Connection recovered: %v", logPrefix, connection)
+		log.Infof("%v Heal: Connection recovered: %v", logPrefix, connection)
+	}
+}
+
+// markDestinationDown - marks destination as down, so it is requested again.
+func markDestinationDown(clientConnection *model.ClientConnection) {
+	if ld := clientConnection.Xcon.GetLocalDestination(); ld != nil {
+		ld.State = connection.State_DOWN
+	} else if rd := clientConnection.Xcon.GetRemoteDestination(); rd != nil {
+		rd.State = remote_connection.State_DOWN
 	}
 }
@@ -0,0 +1,76 @@
+package nsm
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"golang.org/x/net/context"
+)
+
+// getDomainEndpoint - finds endpoint of Network Service hosted in another domain. NSM of such endpoint is reached
+// through domain proxy NSMD, it is named with domain suffix to never match NSMs of our domain.
+func (srv *networkServiceManager) getDomainEndpoint(ctx context.Context, requestConnection nsm.NSMConnection, ignore_endpoints map[string]*registry.NSERegistration, networkServiceName string, domainName string) (*registry.NSERegistration, error) {
+	log := tools.Log(ctx)
+	domain, err := srv.serviceRegistry.DomainResolver().Resolve(domainName)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	discoveryClient, conn, err := srv.serviceRegistry.DomainDiscovery(domain)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	if conn != nil {
+		defer conn.Close()
+	}
+	endpointResponse, err := discoveryClient.FindNetworkService(ctx, &registry.FindNetworkServiceRequest{
+		NetworkServiceName: networkServiceName,
+	})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	endpoints := []*registry.NetworkServiceEndpoint{}
+	for _, candidate := range endpointResponse.GetNetworkServiceEndpoints() {
+		if ignore_endpoints[candidate.GetEndpointName()] == nil && candidate.GetState() != registry.EndpointStateDraining {
+			endpoints = append(endpoints, candidate)
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("Failed to find NSE for NetworkService %s in domain %s. Checked: %d of total NSEs: %d",
+			networkServiceName, domainName, len(ignore_endpoints), len(endpointResponse.GetNetworkServiceEndpoints()))
+	}
+
+	endpoint := srv.model.GetSelector().SelectEndpoint(requestConnection.(*connection.Connection), endpointResponse.GetNetworkService(), endpoints)
+	if endpoint == nil {
+		return nil, fmt.Errorf("Failed to select NSE for NetworkService %s in domain %s", networkServiceName, domainName)
+	}
+	remoteNsm := endpointResponse.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()]
+	if remoteNsm == nil {
+		return nil, fmt.Errorf("NSM %s of NSE %s is not found in domain %s", endpoint.GetNetworkServiceManagerName(), endpoint.GetEndpointName(), domainName)
+	}
+
+	networkService := proto.Clone(endpointResponse.GetNetworkService()).(*registry.NetworkService)
+	networkService.Name = interdomain.JoinDomain(networkServiceName, domain.Name)
+	endpoint = proto.Clone(endpoint).(*registry.NetworkServiceEndpoint)
+	endpoint.NetworkServiceName = networkService.GetName()
+	endpoint.NetworkServiceManagerName = interdomain.JoinDomain(remoteNsm.GetName(), domain.Name)
+
+	return &registry.NSERegistration{
+		NetworkServiceManager: &registry.NetworkServiceManager{
+			Name:     endpoint.GetNetworkServiceManagerName(),
+			Url:      domain.ProxyNsmdUrl,
+			LastSeen: remoteNsm.GetLastSeen(),
+			State:    remoteNsm.GetState(),
+		},
+		NetworkserviceEndpoint: endpoint,
+		NetworkService:         networkService,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package nsm
+
+import (
+	"fmt"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+var healReasons = map[nsm.HealState]string{
+	nsm.HealState_DstDown:       "destination is down",
+	nsm.HealState_SrcDown:       "source is down",
+	nsm.HealState_DataplaneDown: "dataplane is down",
+	nsm.HealState_DstUpdate:     "destination is updated",
+}
+
+func (srv *networkServiceManager) requestReason(request nsm.NSMRequest, nsmConnection nsm.NSMConnection, existingConnection *model.ClientConnection) string {
+	source := "local client"
+	if request.IsRemote() {
+		source = "remote NSM"
+	}
+	if existingConnection != nil {
+		return fmt.Sprintf("update of network service %s connection by %s", nsmConnection.GetNetworkService(), source)
+	}
+	return fmt.Sprintf("network service %s is requested by %s", nsmConnection.GetNetworkService(), source)
+}
+
+func (srv *networkServiceManager) mechanismReason(nsmConnection nsm.NSMConnection, dp *model.Dataplane) string {
+	var mechanism fmt.Stringer
+	switch c := nsmConnection.(type) {
+	case *connection.Connection:
+		mechanism = c.GetMechanism().GetType()
+	case *remote_connection.Connection:
+		mechanism = c.GetMechanism().GetType()
+	}
+	return fmt.Sprintf("mechanism %v of dataplane %s", mechanism, dp.RegisteredName)
+}
@@ -0,0 +1,121 @@
+package nsm
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// updateLease - extends lease of connection source, source should send Request again before lease is expired.
+// Lease is removed if leases are disabled.
+func (srv *networkServiceManager) updateLease(clientConnection *model.ClientConnection) {
+	leaseDuration := srv.getSettings().LeaseDuration
+	if leaseDuration <= 0 {
+		setSourceExpires(clientConnection, nil)
+		return
+	}
+	expires, err := ptypes.TimestampProto(time.Now().Add(leaseDuration))
+	if err != nil {
+		logrus.Errorf("NSM: Failed to update lease of connection %v: %v", clientConnection.GetId(), err)
+		return
+	}
+	setSourceExpires(clientConnection, expires)
+}
+
+func setSourceExpires(clientConnection *model.ClientConnection, expires *timestamp.Timestamp) {
+	if localSource := clientConnection.Xcon.GetLocalSource(); localSource != nil {
+		localSource.Expires = expires
+	} else if remoteSource := clientConnection.Xcon.GetRemoteSource(); remoteSource != nil {
+		remoteSource.Expires = expires
+	}
+}
+
+// remoteLease - returns a lease granted by remote NSM, starting now.
+func remoteLease(clientConnection *model.ClientConnection) time.Duration {
+	expires, err := ptypes.Timestamp(clientConnection.Xcon.GetRemoteDestination().GetExpires())
+	if err != nil {
+		return 0
+	}
+	return time.Until(expires)
+}
+
+// isRemoteLeaseRefreshRequired - checks if lease of remote NSM connection is expired before deadline or in less than
+// half of lease granted by remote NSM. Lease is refreshed if it is not known how long it was granted for.
+func (srv *networkServiceManager) isRemoteLeaseRefreshRequired(clientConnection *model.ClientConnection, deadline time.Time) bool {
+	remoteDestination := clientConnection.Xcon.GetRemoteDestination()
+	if remoteDestination == nil || remoteDestination.GetExpires() == nil {
+		return false
+	}
+	expires, err := ptypes.Timestamp(remoteDestination.GetExpires())
+	if err != nil || clientConnection.RemoteLease <= 0 {
+		return true
+	}
+	return expires.Before(deadline) || time.Until(expires) < clientConnection.RemoteLease/2
+}
+
+// RefreshRemoteLeases - requests remote NSMs again for connections which leases are expired before deadline or in
+// less than half of lease granted by remote NSM. Lease of connection source is not extended by refresh.
+func (srv *networkServiceManager) RefreshRemoteLeases(ctx context.Context, deadline time.Time) {
+	for _, clientConnection := range srv.model.GetAllClientConnections() {
+		if clientConnection.ConnectionState != model.ClientConnection_Ready || clientConnection.Request == nil {
+			continue
+		}
+		if !srv.isRemoteLeaseRefreshRequired(clientConnection, deadline) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		refreshId := tools.NewCorrelationId()
+		refreshCtx := tools.WithCorrelationId(ctx, refreshId)
+		tools.Log(refreshCtx).Infof("NSM_Lease(%v) Refreshing remote NSM lease of connection %v", refreshId, clientConnection.GetId())
+		if err := srv.refreshRemoteLease(refreshCtx, clientConnection); err != nil {
+			tools.Log(refreshCtx).Errorf("NSM_Lease(%v) Failed to refresh remote NSM lease of connection %v: %v", refreshId, clientConnection.GetId(), err)
+		}
+	}
+}
+
+func (srv *networkServiceManager) refreshRemoteLease(ctx context.Context, clientConnection *model.ClientConnection) error {
+	sourceExpires := clientConnection.GetConnectionSource().GetExpires()
+	request := clientConnection.Request.Clone()
+	request.SetConnection(clientConnection.GetConnectionSource())
+	ctx, cancel := context.WithTimeout(ctx, srv.getSettings().NseConnectionTimeout)
+	defer cancel()
+	if _, err := srv.request(ctx, request, clientConnection); err != nil {
+		return err
+	}
+	// Source has to refresh connection by itself, otherwise it is reaped.
+	if refreshed := srv.model.GetClientConnection(clientConnection.GetId()); refreshed != nil {
+		setSourceExpires(refreshed, sourceExpires)
+		srv.model.UpdateClientConnection(refreshed)
+	}
+	return nil
+}
+
+// ReapExpiredConnections - closes connections which sources did not refresh them in time.
+func (srv *networkServiceManager) ReapExpiredConnections(now time.Time) {
+	for _, clientConnection := range srv.model.GetAllClientConnections() {
+		if clientConnection.ConnectionState != model.ClientConnection_Ready {
+			// Connection is being requested, healed or closed, lease will be updated or it is not required anymore.
+			continue
+		}
+		source := clientConnection.GetConnectionSource()
+		if source.GetExpires() == nil {
+			// Connection has no lease.
+			continue
+		}
+		expires, err := ptypes.Timestamp(source.GetExpires())
+		if err != nil || expires.After(now) {
+			continue
+		}
+		logrus.Infof("NSM_Reaper: Connection %v lease is expired at %v, closing", clientConnection.GetId(), expires)
+		if err := srv.close(context.Background(), clientConnection, true, "lease is expired"); err != nil {
+			logrus.Errorf("NSM_Reaper: Error closing expired connection %v: %v", clientConnection.GetId(), err)
+		}
+	}
+}
@@ -17,7 +17,7 @@ func (srv *networkServiceManager) updateMechanism(requestId string, nsmConnectio
 	// 5.x
 	if request.IsRemote() {
 		//5.1 Select appropriate remote mechanism
-		mechanism, err := srv.selectRemoteMechanism(requestId, request.(*remote_networkservice.NetworkServiceRequest), dataplane)
+		mechanism, err := srv.selectRemoteMechanism(requestId, nsmConnection.GetId(), request.(*remote_networkservice.NetworkServiceRequest), dataplane)
 		if err != nil {
 			return err
 		}
@@ -52,26 +52,46 @@ func (srv *networkServiceManager) updateMechanism(requestId string, nsmConnectio
 	return nil
 }
 
-func (srv *networkServiceManager) selectRemoteMechanism(requestId string, request *remote_networkservice.NetworkServiceRequest, dp *model.Dataplane) (*remote_connection.Mechanism, error) {
+func (srv *networkServiceManager) selectRemoteMechanism(requestId string, connectionId string, request *remote_networkservice.NetworkServiceRequest, dp *model.Dataplane) (*remote_connection.Mechanism, error) {
 	for _, mechanism := range request.MechanismPreferences {
-		dp_mechanism := findRemoteMechanism(dp.RemoteMechanisms, remote_connection.MechanismType_VXLAN)
+		dp_mechanism := findRemoteMechanism(dp.RemoteMechanisms, mechanism.GetType())
 		if dp_mechanism == nil {
 			continue
 		}
-		// TODO: Add other mechanisms support
-		if mechanism.Type == remote_connection.MechanismType_VXLAN {
-			// Update DST IP to be ours
-			remoteSrc := mechanism.Parameters[remote_connection.VXLANSrcIP]
-			mechanism.Parameters[remote_connection.VXLANSrcIP] = remoteSrc
-			mechanism.Parameters[remote_connection.VXLANDstIP] = dp_mechanism.Parameters[remote_connection.VXLANSrcIP]
-			mechanism.Parameters[remote_connection.VXLANVNI] = strconv.FormatUint(srv.serviceRegistry.VniAllocator().Vni(dp_mechanism.Parameters[remote_connection.VXLANSrcIP], remoteSrc), 10)
+		if mechanism.Parameters == nil {
+			mechanism.Parameters = map[string]string{}
 		}
-		logrus.Infof("NSM:(4.1-%v) Remote mechanism selected %v", requestId, mechanism)
+		if err := srv.updateRemoteMechanism(connectionId, request.GetConnection().GetNetworkService(), mechanism, dp_mechanism); err != nil {
+			srv.serviceRegistry.VniAllocator().Release(connectionId)
+			logrus.Errorf("NSM:(5.1-%v) Failed to use remote mechanism %v: %v", requestId, mechanism.GetType(), err)
+			continue
+		}
+		logrus.Infof("NSM:(5.1-%v) Remote mechanism selected %v", requestId, mechanism)
 		return mechanism, nil
 	}
 	return nil, fmt.Errorf("NSM:(5.1-%v) Failed to select mechanism. No matched mechanisms found...", requestId)
 }
 
+// updateRemoteMechanism - fills parameters of remote mechanism requested by remote NSM with parameters of our dataplane.
+func (srv *networkServiceManager) updateRemoteMechanism(connectionId string, networkService string, mechanism *remote_connection.Mechanism, dp_mechanism *remote_connection.Mechanism) error {
+	// Dataplanes are able to program VXLAN tunnels only, other remote mechanisms are never advertised by them.
+	if mechanism.GetType() != remote_connection.MechanismType_VXLAN {
+		return fmt.Errorf("remote mechanism %v is not supported", mechanism.GetType())
+	}
+	remoteSrc := mechanism.Parameters[remote_connection.VXLANSrcIP]
+	localSrc := dp_mechanism.Parameters[remote_connection.VXLANSrcIP]
+
+	// Update DST IP to be ours
+	mechanism.Parameters[remote_connection.VXLANDstIP] = localSrc
+	// Network service is used as a tenant to select a range of VNIs.
+	vni, err := srv.serviceRegistry.VniAllocator().Allocate(connectionId, networkService, localSrc, remoteSrc)
+	if err != nil {
+		return err
+	}
+	mechanism.Parameters[remote_connection.VXLANVNI] = strconv.FormatUint(uint64(vni), 10)
+	return nil
+}
+
 func findRemoteMechanism(MechanismPreferences []*remote_connection.Mechanism, mechanismType remote_connection.MechanismType) *remote_connection.Mechanism {
 	for _, m := range MechanismPreferences {
 		if m.Type == mechanismType {
@@ -0,0 +1,18 @@
+package nsm
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+)
+
+// requestNetworkService - returns a name of requested Network Service to label request metrics.
+func requestNetworkService(request nsm.NSMRequest) string {
+	switch r := request.(type) {
+	case *networkservice.NetworkServiceRequest:
+		return r.GetConnection().GetNetworkService()
+	case *remote_networkservice.NetworkServiceRequest:
+		return r.GetConnection().GetNetworkService()
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package nsm
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/sirupsen/logrus"
+)
+
+// RestoreConnections - put connections restored from persistence back into model and heal them,
+// so cross connects programmed before NSMD restart will be reconciled instead of orphaned.
+// Dataplanes register again after NSMD restart, so connections are kept in model as healing ones
+// until dataplane is available and healed only then.
+func (srv *networkServiceManager) RestoreConnections(connections []nsm.NSMClientConnection) {
+	restored := []*model.ClientConnection{}
+	for _, c := range connections {
+		clientConnection := c.(*model.ClientConnection)
+		logrus.Infof("NSM_Restore(1) Restoring connection: %v", clientConnection)
+
+		// 1.1 Connection is known again, so monitors and listeners will be informed.
+		clientConnection.ConnectionState = model.ClientConnection_Healing
+		srv.model.AddClientConnection(clientConnection)
+		restored = append(restored, clientConnection)
+	}
+	if len(restored) > 0 {
+		go srv.healRestoredConnections(restored)
+	}
+}
+
+func (srv *networkServiceManager) healRestoredConnections(connections []*model.ClientConnection) {
+	// 2. Wait for dataplane to register.
+	if err := srv.serviceRegistry.WaitForDataplaneAvailable(srv.model, RestoreDataplaneTimeout); err != nil {
+		logrus.Errorf("NSM_Restore(2) Dataplane is not available for %v, restored connections are dropped: %v", RestoreDataplaneTimeout, err)
+		for _, clientConnection := range connections {
+			srv.model.DeleteClientConnection(clientConnection.GetId())
+		}
+		return
+	}
+
+	for _, clientConnection := range connections {
+		// 2.1 Dataplane is registered again after restart, so we need to use actual one.
+		var dp *model.Dataplane
+		if clientConnection.Dataplane != nil {
+			dp = srv.model.GetDataplane(clientConnection.Dataplane.RegisteredName)
+		}
+		if dp == nil {
+			var err error
+			if clientConnection.Request != nil {
+				dp, err = srv.selectDataplane("restore", clientConnection.Request, nil, nil)
+			} else {
+				dp, err = srv.model.SelectDataplane(nil)
+			}
+			if err != nil {
+				logrus.Errorf("NSM_Restore(2.1) Failed to restore connection %v: %v", clientConnection.GetId(), err)
+				srv.model.DeleteClientConnection(clientConnection.GetId())
+				continue
+			}
+		}
+		clientConnection.Dataplane = dp
+		clientConnection.ConnectionState = model.ClientConnection_Ready
+		srv.model.UpdateClientConnection(clientConnection)
+
+		// 2.2 Re-request NSE and re-program dataplane if required.
+		if clientConnection.Request == nil {
+			logrus.Warnf("NSM_Restore(2.2) No original request for connection %v, healing skipped", clientConnection.GetId())
+			continue
+		}
+		go srv.Heal(clientConnection, nsm.HealState_DataplaneDown)
+	}
+}
@@ -0,0 +1,83 @@
+// Package nsmctl implements commands of nsmctl, a command-line tool for operators to inspect NSMD and
+// Network Service Registry and to trigger manual operations on connections.
+package nsmctl
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultNsmServerSocket - socket NSMD serves admin API on.
+	DefaultNsmServerSocket = "/var/lib/networkservicemesh/nsm.io.sock"
+	// DefaultPublicAPI - address of NSMD public API.
+	DefaultPublicAPI = "127.0.0.1:5001"
+	// DefaultRegistry - address of nsmd-k8s Network Service Registry.
+	DefaultRegistry = "127.0.0.1:5000"
+	// DefaultTimeout - time to wait for NSMD and registry to respond.
+	DefaultTimeout = 15 * time.Second
+)
+
+// Config - addresses of NSMD and nsmd-k8s nsmctl talks to.
+type Config struct {
+	// NsmServerSocket - path of NSM server socket NSMD admin API is served on, test NSMDs serve it on host:port.
+	NsmServerSocket string
+	// PublicAPI - address of NSMD public API, used to query monitor streams.
+	PublicAPI string
+	// Registry - address of nsmd-k8s Network Service Registry.
+	Registry string
+	// Output - one of table, json or yaml.
+	Output  string
+	Timeout time.Duration
+}
+
+// Client - connects to NSMDs and registry, public APIs are dialed with mutual TLS if security provider is set.
+type Client struct {
+	config           *Config
+	securityProvider *security.Provider
+}
+
+// NewClient - creates a client using config, securityProvider could be nil if TLS is disabled.
+func NewClient(config *Config, securityProvider *security.Provider) *Client {
+	return &Client{
+		config:           config,
+		securityProvider: securityProvider,
+	}
+}
+
+// adminClient - connects to admin API of local NSMD.
+func (c *Client) adminClient(ctx context.Context) (admin.NSMAdminClient, *grpc.ClientConn, error) {
+	address := c.config.NsmServerSocket
+	options := []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock()}
+	if strings.HasPrefix(address, "/") {
+		options = append(options, grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout("unix", addr, timeout)
+		}))
+	}
+	conn, err := grpc.DialContext(ctx, address, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return admin.NewNSMAdminClient(conn), conn, nil
+}
+
+// dialNsm - connects to public API of NSMD, nsmName is verified if TLS is enabled, empty name accepts any NSMD.
+func (c *Client) dialNsm(ctx context.Context, address string, nsmName string) (*grpc.ClientConn, error) {
+	return grpc.DialContext(ctx, address, c.securityProvider.DialOption(nsmName), grpc.WithBlock())
+}
+
+// discoveryClient - connects to Network Service Registry.
+func (c *Client) discoveryClient(ctx context.Context) (registry.NetworkServiceDiscoveryClient, *grpc.ClientConn, error) {
+	conn, err := grpc.DialContext(ctx, c.config.Registry, c.securityProvider.DialOption(""), grpc.WithBlock())
+	if err != nil {
+		return nil, nil, err
+	}
+	return registry.NewNetworkServiceDiscoveryClient(conn), conn, nil
+}
@@ -0,0 +1,342 @@
+package nsmctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+)
+
+// Usage - describes commands of nsmctl.
+const Usage = `Commands:
+  connections                    list client connections of NSMD
+  endpoints                      list endpoints known by NSMD
+  dataplanes                     list dataplanes of NSMD
+  workspaces                     list workspaces of NSMD
+  networkservices [name...]      list NetworkServices of registry, or describe named ones with their endpoints
+  describe <connection-id>       describe connection end to end across NSMs
+  monitor crossconnects          tail cross connects of NSMD
+  monitor connections <nsm-name> tail connections NSMD provides to another NSM
+  close <connection-id>          close connection
+  heal <connection-id>           heal connection as if its destination is down, on NSMD of its source
+  dump                           dump model of NSMD as JSON`
+
+// command - runs nsmctl command with its arguments.
+type command func(ctx context.Context, c *Client, p *printer, args []string) error
+
+var commands = map[string]command{
+	"connections":     listConnections,
+	"endpoints":       listEndpoints,
+	"dataplanes":      listDataplanes,
+	"workspaces":      listWorkspaces,
+	"networkservices": listNetworkServices,
+	"describe":        describeConnection,
+	"monitor":         monitor,
+	"close":           closeConnection,
+	"heal":            healConnection,
+	"dump":            dumpModel,
+}
+
+// Run - runs command passed in args, output is written to out. Monitor commands are run until ctx is done.
+func Run(ctx context.Context, c *Client, out io.Writer, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("command is not specified\n%s", Usage)
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %s\n%s", args[0], Usage)
+	}
+	p, err := newPrinter(out, c.config.Output)
+	if err != nil {
+		return err
+	}
+	if args[0] != "monitor" && c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+	return cmd(ctx, c, p, args[1:])
+}
+
+func listConnections(ctx context.Context, c *Client, p *printer, args []string) error {
+	adminClient, conn, err := c.adminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	connections, err := adminClient.GetConnections(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	rows := table{{"ID", "NETWORK SERVICE", "STATE", "WORKSPACE", "ENDPOINT", "DATAPLANE", "REMOTE NSM"}}
+	for _, info := range connections.GetConnections() {
+		rows = append(rows, []string{info.ConnectionId, info.NetworkService, info.State, info.Workspace,
+			info.Endpoint, info.Dataplane, info.RemoteNsm})
+	}
+	return p.print(connections.GetConnections(), rows)
+}
+
+func listEndpoints(ctx context.Context, c *Client, p *printer, args []string) error {
+	adminClient, conn, err := c.adminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	endpoints, err := adminClient.GetEndpoints(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	rows := table{{"NAME", "NETWORK SERVICE", "NSM", "LABELS"}}
+	for _, info := range endpoints.GetEndpoints() {
+		rows = append(rows, []string{info.Name, info.NetworkService, info.NetworkServiceManager, labels(info.Labels)})
+	}
+	return p.print(endpoints.GetEndpoints(), rows)
+}
+
+func listDataplanes(ctx context.Context, c *Client, p *printer, args []string) error {
+	adminClient, conn, err := c.adminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	dataplanes, err := adminClient.GetDataplanes(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	rows := table{{"NAME", "SOCKET", "LOCAL MECHANISMS", "REMOTE MECHANISMS"}}
+	for _, info := range dataplanes.GetDataplanes() {
+		rows = append(rows, []string{info.Name, info.SocketLocation, strings.Join(info.LocalMechanisms, ","),
+			strings.Join(info.RemoteMechanisms, ",")})
+	}
+	return p.print(dataplanes.GetDataplanes(), rows)
+}
+
+func listWorkspaces(ctx context.Context, c *Client, p *printer, args []string) error {
+	adminClient, conn, err := c.adminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	workspaces, err := adminClient.GetWorkspaces(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	rows := table{{"WORKSPACE"}}
+	for _, workspace := range workspaces.GetWorkspaces() {
+		rows = append(rows, []string{workspace})
+	}
+	return p.print(workspaces.GetWorkspaces(), rows)
+}
+
+func listNetworkServices(ctx context.Context, c *Client, p *printer, args []string) error {
+	discoveryClient, conn, err := c.discoveryClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if len(args) > 0 {
+		return describeNetworkServices(ctx, discoveryClient, p, args)
+	}
+	services, err := discoveryClient.ListNetworkServices(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	sort.Slice(services.NetworkServices, func(i, j int) bool {
+		return services.NetworkServices[i].GetName() < services.NetworkServices[j].GetName()
+	})
+	rows := table{{"NAME", "PAYLOAD", "SELECTION", "MATCHES"}}
+	for _, service := range services.GetNetworkServices() {
+		rows = append(rows, []string{service.Name, service.Payload, service.SelectionStrategy, fmt.Sprint(len(service.Matches))})
+	}
+	return p.print(services.GetNetworkServices(), rows)
+}
+
+func describeNetworkServices(ctx context.Context, discoveryClient registry.NetworkServiceDiscoveryClient, p *printer, names []string) error {
+	responses := []*registry.FindNetworkServiceResponse{}
+	rows := table{{"NETWORK SERVICE", "ENDPOINT", "NSM", "URL", "STATE", "LABELS"}}
+	for _, name := range names {
+		response, err := discoveryClient.FindNetworkService(ctx, &registry.FindNetworkServiceRequest{
+			NetworkServiceName: name,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find NetworkService %s: %v", name, err)
+		}
+		responses = append(responses, response)
+		for _, endpoint := range response.GetNetworkServiceEndpoints() {
+			manager := response.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()]
+			rows = append(rows, []string{name, endpoint.EndpointName, endpoint.NetworkServiceManagerName,
+				manager.GetUrl(), endpoint.State, labels(endpoint.Labels)})
+		}
+	}
+	return p.print(responses, rows)
+}
+
+func closeConnection(ctx context.Context, c *Client, p *printer, args []string) error {
+	connectionId, err := connectionIdArg(args)
+	if err != nil {
+		return err
+	}
+	adminClient, conn, err := c.adminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := adminClient.CloseConnection(ctx, &admin.ConnectionId{ConnectionId: connectionId}); err != nil {
+		return err
+	}
+	return p.print(map[string]string{"closed": connectionId}, table{{"CLOSED"}, {connectionId}})
+}
+
+func healConnection(ctx context.Context, c *Client, p *printer, args []string) error {
+	connectionId, err := connectionIdArg(args)
+	if err != nil {
+		return err
+	}
+	adminClient, conn, err := c.adminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := adminClient.HealConnection(ctx, &admin.ConnectionId{ConnectionId: connectionId}); err != nil {
+		return err
+	}
+	return p.print(map[string]string{"healed": connectionId}, table{{"HEALED"}, {connectionId}})
+}
+
+func dumpModel(ctx context.Context, c *Client, p *printer, args []string) error {
+	adminClient, conn, err := c.adminClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	dump, err := adminClient.DumpModel(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	// Model is dumped as JSON by NSMD in any output format.
+	_, err = fmt.Fprintln(p.out, dump.GetJson())
+	return err
+}
+
+func monitor(ctx context.Context, c *Client, p *printer, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("stream to monitor is not specified, should be crossconnects or connections")
+	}
+	switch args[0] {
+	case "crossconnects":
+		return monitorCrossConnects(ctx, c, p)
+	case "connections":
+		if len(args) < 2 {
+			return fmt.Errorf("name of NSM connections are provided to is not specified")
+		}
+		return monitorConnections(ctx, c, p, args[1])
+	}
+	return fmt.Errorf("unknown stream %s, should be crossconnects or connections", args[0])
+}
+
+func monitorCrossConnects(ctx context.Context, c *Client, p *printer) error {
+	conn, err := c.dialNsm(ctx, c.config.PublicAPI, "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stream, err := crossconnect.NewMonitorCrossConnectClient(conn).MonitorCrossConnects(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	for first := true; ; first = false {
+		event, err := stream.Recv()
+		if err != nil {
+			return streamError(ctx, err)
+		}
+		rows := table{{"EVENT", "ID", "PAYLOAD", "SOURCE", "DESTINATION"}}
+		xcons := []*crossconnect.CrossConnect{}
+		for _, xcon := range event.GetCrossConnects() {
+			xcons = append(xcons, xcon)
+		}
+		sort.Slice(xcons, func(i, j int) bool { return xcons[i].GetId() < xcons[j].GetId() })
+		for _, xcon := range xcons {
+			rows = append(rows, []string{event.GetType().String(), xcon.GetId(), xcon.GetPayload(),
+				sourceString(xcon), destinationString(xcon)})
+		}
+		if err := p.printEvent(event, rows, first); err != nil {
+			return err
+		}
+	}
+}
+
+func monitorConnections(ctx context.Context, c *Client, p *printer, nsmName string) error {
+	conn, err := c.dialNsm(ctx, c.config.PublicAPI, "")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stream, err := remote_connection.NewMonitorConnectionClient(conn).MonitorConnections(ctx, &remote_connection.MonitorScopeSelector{
+		NetworkServiceManagerName: nsmName,
+	})
+	if err != nil {
+		return err
+	}
+	for first := true; ; first = false {
+		event, err := stream.Recv()
+		if err != nil {
+			return streamError(ctx, err)
+		}
+		rows := table{{"EVENT", "ID", "NETWORK SERVICE", "SOURCE NSM", "DESTINATION NSM", "ENDPOINT", "STATE"}}
+		connections := []*remote_connection.Connection{}
+		for _, connection := range event.GetConnections() {
+			connections = append(connections, connection)
+		}
+		sort.Slice(connections, func(i, j int) bool { return connections[i].GetId() < connections[j].GetId() })
+		for _, connection := range connections {
+			rows = append(rows, []string{event.GetType().String(), connection.GetId(), connection.GetNetworkService(),
+				connection.GetSourceNetworkServiceManagerName(), connection.GetDestinationNetworkServiceManagerName(),
+				connection.GetNetworkServiceEndpointName(), connection.GetState().String()})
+		}
+		if err := p.printEvent(event, rows, first); err != nil {
+			return err
+		}
+	}
+}
+
+// streamError - monitoring is stopped without error when it is interrupted by user.
+func streamError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func connectionIdArg(args []string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", fmt.Errorf("connection id is not specified")
+	}
+	return args[0], nil
+}
+
+func sourceString(xcon *crossconnect.CrossConnect) string {
+	if source := xcon.GetLocalSource(); source != nil {
+		return fmt.Sprintf("local %s %s", source.GetId(), source.GetState())
+	}
+	if source := xcon.GetRemoteSource(); source != nil {
+		return fmt.Sprintf("remote %s@%s %s", source.GetId(), source.GetSourceNetworkServiceManagerName(), source.GetState())
+	}
+	return ""
+}
+
+func destinationString(xcon *crossconnect.CrossConnect) string {
+	if destination := xcon.GetLocalDestination(); destination != nil {
+		return fmt.Sprintf("local %s %s", destination.GetId(), destination.GetState())
+	}
+	if destination := xcon.GetRemoteDestination(); destination != nil {
+		return fmt.Sprintf("remote %s@%s %s", destination.GetId(), destination.GetDestinationNetworkServiceManagerName(), destination.GetState())
+	}
+	return ""
+}
@@ -0,0 +1,319 @@
+package nsmctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConnectionDescription - connection as seen by each NSM it passes through, starting from NSM of client.
+type ConnectionDescription struct {
+	Connection *admin.ClientConnectionInfo `json:"connection"`
+	Hops       []*Hop                      `json:"hops"`
+}
+
+// Hop - part of connection served by one NSM, Error is set if NSM could not be inspected. Connection is set instead
+// of CrossConnect for NSM of another domain, as it is seen through proxy NSMD only.
+type Hop struct {
+	Nsm          string                        `json:"nsm,omitempty"`
+	Url          string                        `json:"url"`
+	ConnectionId string                        `json:"connectionId"`
+	CrossConnect *crossconnect.CrossConnect    `json:"crossConnect,omitempty"`
+	Connection   *remote_connection.Connection `json:"connection,omitempty"`
+	Events       []*journal.JournalEvent       `json:"events,omitempty"`
+	Error        string                        `json:"error,omitempty"`
+}
+
+// maxHops - limits number of NSMs connection is followed through in each direction.
+const maxHops = 16
+
+func describeConnection(ctx context.Context, c *Client, p *printer, args []string) error {
+	connectionId, err := connectionIdArg(args)
+	if err != nil {
+		return err
+	}
+	description, err := c.describe(ctx, connectionId)
+	if err != nil {
+		return err
+	}
+
+	rows := table{{"NSM", "URL", "CONNECTION", "SOURCE", "DESTINATION", "LAST EVENT"}}
+	for _, hop := range description.Hops {
+		lastEvent := hop.Error
+		if hop.Connection != nil && lastEvent == "" {
+			lastEvent = hop.Connection.GetState().String()
+		}
+		if len(hop.Events) > 0 {
+			event := hop.Events[len(hop.Events)-1]
+			lastEvent = fmt.Sprintf("%s %s", eventTime(event), event.GetType())
+		}
+		rows = append(rows, []string{hop.Nsm, hop.Url, hop.ConnectionId, sourceString(hop.CrossConnect),
+			destinationString(hop.CrossConnect), lastEvent})
+	}
+	return p.print(description, rows)
+}
+
+// describe - follows connection from local NSM both to NSMs it is requested from and to NSMs requested it.
+func (c *Client) describe(ctx context.Context, connectionId string) (*ConnectionDescription, error) {
+	adminClient, conn, err := c.adminClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	connections, err := adminClient.GetConnections(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	description := &ConnectionDescription{}
+	for _, info := range connections.GetConnections() {
+		if info.GetConnectionId() == connectionId {
+			description.Connection = info
+		}
+	}
+	if description.Connection == nil {
+		return nil, status.Errorf(codes.NotFound, "there is no such client connection %s", connectionId)
+	}
+
+	local := &Hop{
+		Nsm:          localNsm(ctx, adminClient).GetName(),
+		Url:          c.config.PublicAPI,
+		ConnectionId: connectionId,
+		CrossConnect: description.Connection.GetXcon(),
+	}
+	c.inspectHop(ctx, local, nil)
+	local.Events, err = hopEvents(ctx, conn, connectionId)
+	if err != nil {
+		local.Error = fmt.Sprintf("failed to get journal events: %v", err)
+	}
+
+	resolver := &nsmResolver{client: c}
+	defer resolver.close()
+	description.Hops = append(c.sourceHops(ctx, resolver, local), local)
+	description.Hops = append(description.Hops, c.destinationHops(ctx, resolver, local, description.Connection.GetRemoteNsmUrl())...)
+	return description, nil
+}
+
+// sourceHops - follows connection from hop to NSMs requested it, hops are returned starting from NSM of client.
+func (c *Client) sourceHops(ctx context.Context, resolver *nsmResolver, hop *Hop) []*Hop {
+	var hops []*Hop
+	for len(hops) < maxHops {
+		remoteSource := hop.CrossConnect.GetRemoteSource()
+		if remoteSource == nil {
+			break
+		}
+		hop = &Hop{
+			Nsm: remoteSource.GetSourceNetworkServiceManagerName(),
+		}
+		hops = append([]*Hop{hop}, hops...)
+		url, err := resolver.url(ctx, remoteSource.GetNetworkService(), hop.Nsm)
+		if err != nil {
+			hop.Error = err.Error()
+			break
+		}
+		hop.Url = url
+		// Source NSM has the same remote connection as destination of its cross connect.
+		c.inspectHop(ctx, hop, func(xcon *crossconnect.CrossConnect) bool {
+			remoteDestination := xcon.GetRemoteDestination()
+			return remoteDestination.GetId() == remoteSource.GetId() &&
+				remoteDestination.GetDestinationNetworkServiceManagerName() == remoteSource.GetDestinationNetworkServiceManagerName()
+		})
+		hop.ConnectionId = hop.CrossConnect.GetId()
+	}
+	return hops
+}
+
+// destinationHops - follows connection from hop to NSMs it is requested from, url is public API of the first of them
+// as known by NSM of hop. NSMs of another domain are not known by our registry, so connection to another domain is
+// followed to its proxy NSMD only.
+func (c *Client) destinationHops(ctx context.Context, resolver *nsmResolver, hop *Hop, url string) []*Hop {
+	var hops []*Hop
+	for len(hops) < maxHops {
+		remoteDestination := hop.CrossConnect.GetRemoteDestination()
+		if remoteDestination == nil {
+			break
+		}
+		hop = &Hop{
+			Nsm:          remoteDestination.GetDestinationNetworkServiceManagerName(),
+			Url:          url,
+			ConnectionId: remoteDestination.GetId(),
+		}
+		hops = append(hops, hop)
+		if hop.Url == "" {
+			var err error
+			if hop.Url, err = resolver.url(ctx, remoteDestination.GetNetworkService(), hop.Nsm); err != nil {
+				hop.Error = err.Error()
+				break
+			}
+		}
+		url = ""
+		if _, domain := interdomain.SplitDomain(hop.Nsm); domain != "" {
+			c.inspectProxyHop(ctx, hop)
+			break
+		}
+		c.inspectHop(ctx, hop, func(xcon *crossconnect.CrossConnect) bool {
+			return xcon.GetId() == hop.ConnectionId
+		})
+	}
+	return hops
+}
+
+// inspectHop - finds cross connect of hop matching match if it is not known yet. Admin API and journal of remote NSM
+// are not reachable, so cross connect is taken from cross connects reported by its dataplane.
+func (c *Client) inspectHop(ctx context.Context, hop *Hop, match func(xcon *crossconnect.CrossConnect) bool) {
+	conn, err := c.dialNsm(ctx, hop.Url, hop.Nsm)
+	if err != nil {
+		hop.Error = fmt.Sprintf("failed to connect NSM: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	if hop.CrossConnect == nil {
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := crossconnect.NewMonitorCrossConnectClient(conn).MonitorCrossConnects(streamCtx, &empty.Empty{})
+		if err == nil {
+			// First event is a snapshot of all cross connects.
+			var event *crossconnect.CrossConnectEvent
+			if event, err = stream.Recv(); err == nil {
+				for _, xcon := range event.GetCrossConnects() {
+					if match(xcon) {
+						hop.CrossConnect = xcon
+					}
+				}
+				if hop.CrossConnect == nil {
+					hop.Error = "cross connect is not found"
+				}
+			}
+		}
+		if err != nil {
+			hop.Error = fmt.Sprintf("failed to get cross connects: %v", err)
+		}
+	}
+}
+
+// inspectProxyHop - finds connection relayed by proxy NSMD to NSM of another domain. Proxy reports connection events
+// of NSMs it relays to instead of cross connects, it is dialed without verifying its name, as hop is named by NSM.
+func (c *Client) inspectProxyHop(ctx context.Context, hop *Hop) {
+	conn, err := c.dialNsm(ctx, hop.Url, "")
+	if err != nil {
+		hop.Error = fmt.Sprintf("failed to connect proxy NSMD: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := remote_connection.NewMonitorConnectionClient(conn).MonitorConnections(streamCtx, &remote_connection.MonitorScopeSelector{
+		NetworkServiceManagerName: hop.Nsm,
+	})
+	if err == nil {
+		// First event is a snapshot of all connections.
+		var event *remote_connection.ConnectionEvent
+		if event, err = stream.Recv(); err == nil {
+			hop.Connection = event.GetConnections()[hop.ConnectionId]
+			if hop.Connection == nil {
+				hop.Error = "connection is not found"
+			}
+		}
+	}
+	if err != nil {
+		hop.Error = fmt.Sprintf("failed to get connections: %v", err)
+	}
+}
+
+// nsmResolver - resolves public API of NSMs by name through registry. Registry knows NSMs of endpoints only, they are
+// looked up among NSMs of connection Network Service first and then among NSMs of all Network Services.
+type nsmResolver struct {
+	client    *Client
+	discovery registry.NetworkServiceDiscoveryClient
+	conn      *grpc.ClientConn
+	managers  map[string]*registry.NetworkServiceManager
+}
+
+func (r *nsmResolver) url(ctx context.Context, networkService, nsmName string) (string, error) {
+	if nsm := r.managers[nsmName]; nsm != nil {
+		return nsm.GetUrl(), nil
+	}
+	if r.discovery == nil {
+		discovery, conn, err := r.client.discoveryClient(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to connect registry to find NSM %s: %v", nsmName, err)
+		}
+		r.discovery, r.conn = discovery, conn
+		r.managers = map[string]*registry.NetworkServiceManager{}
+	}
+	networkServices := []string{networkService}
+	if services, err := r.discovery.ListNetworkServices(ctx, &empty.Empty{}); err == nil {
+		for _, service := range services.GetNetworkServices() {
+			networkServices = append(networkServices, service.GetName())
+		}
+	}
+	for _, service := range networkServices {
+		response, err := r.discovery.FindNetworkService(ctx, &registry.FindNetworkServiceRequest{
+			NetworkServiceName: service,
+		})
+		if err != nil {
+			continue
+		}
+		for name, nsm := range response.GetNetworkServiceManagers() {
+			r.managers[name] = nsm
+		}
+		if nsm := r.managers[nsmName]; nsm != nil {
+			return nsm.GetUrl(), nil
+		}
+	}
+	return "", fmt.Errorf("NSM %s is not found in registry", nsmName)
+}
+
+func (r *nsmResolver) close() {
+	if r.conn != nil {
+		_ = r.conn.Close()
+	}
+}
+
+// hopEvents - returns journal events of connection, journal is served on NSM server socket only, as admin API is.
+func hopEvents(ctx context.Context, conn *grpc.ClientConn, connectionId string) ([]*journal.JournalEvent, error) {
+	events, err := journal.NewConnectionJournalClient(conn).GetEvents(ctx, &journal.JournalQuery{
+		ConnectionId: connectionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events.GetEvents(), nil
+}
+
+// localNsm - returns NSM serving admin API, nil if it is unknown yet.
+func localNsm(ctx context.Context, adminClient admin.NSMAdminClient) *registry.NetworkServiceManager {
+	dump, err := adminClient.DumpModel(ctx, &empty.Empty{})
+	if err != nil {
+		return nil
+	}
+	model := &struct {
+		Nsm *registry.NetworkServiceManager `json:"nsm"`
+	}{}
+	if err := json.Unmarshal([]byte(dump.GetJson()), model); err != nil {
+		return nil
+	}
+	return model.Nsm
+}
+
+func eventTime(event *journal.JournalEvent) string {
+	t, err := ptypes.Timestamp(event.GetTime())
+	if err != nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,109 @@
+package nsmctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	OutputTable = "table"
+	OutputJson  = "json"
+	OutputYaml  = "yaml"
+)
+
+// table - rows to print in table output, first row is a header.
+type table [][]string
+
+// printer - prints values in one of output formats, values are printed as table rows in table format and
+// marshalled as is otherwise.
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+func newPrinter(out io.Writer, format string) (*printer, error) {
+	switch format {
+	case "", OutputTable:
+		format = OutputTable
+	case OutputJson, OutputYaml:
+	default:
+		return nil, fmt.Errorf("unknown output format %s, should be one of %s, %s, %s", format, OutputTable, OutputJson, OutputYaml)
+	}
+	return &printer{
+		out:    out,
+		format: format,
+	}, nil
+}
+
+func (p *printer) print(value interface{}, rows table) error {
+	switch p.format {
+	case OutputJson:
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(data))
+		return err
+	case OutputYaml:
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprint(p.out, string(data))
+		return err
+	}
+	return p.printRows(rows)
+}
+
+// printEvent - prints one event of monitor stream, events are separated to be parsed one by one.
+func (p *printer) printEvent(value interface{}, rows table, withHeader bool) error {
+	switch p.format {
+	case OutputJson:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(data))
+		return err
+	case OutputYaml:
+		if _, err := fmt.Fprintln(p.out, "---"); err != nil {
+			return err
+		}
+		return p.print(value, nil)
+	}
+	if !withHeader {
+		rows = rows[1:]
+	}
+	return p.printRows(rows)
+}
+
+func (p *printer) printRows(rows table) error {
+	w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+	for _, row := range rows {
+		for i, cell := range row {
+			if cell == "" {
+				row[i] = "-"
+			}
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// labels - formats labels as key=value list sorted by key.
+func labels(values map[string]string) string {
+	pairs := make([]string, 0, len(values))
+	for key, value := range values {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
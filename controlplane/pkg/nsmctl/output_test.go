@@ -0,0 +1,54 @@
+package nsmctl
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPrinterFormats(t *testing.T) {
+	RegisterTestingT(t)
+
+	value := []map[string]string{{"name": "nse-1", "network_service": "secure-intranet"}}
+	rows := table{{"NAME", "NETWORK SERVICE", "NSM"}, {"nse-1", "secure-intranet", ""}}
+
+	out := &bytes.Buffer{}
+	p, err := newPrinter(out, "")
+	Expect(err).To(BeNil())
+	Expect(p.print(value, rows)).To(BeNil())
+	Expect(out.String()).To(Equal("NAME   NETWORK SERVICE  NSM\nnse-1  secure-intranet  -\n"))
+
+	out.Reset()
+	p, err = newPrinter(out, OutputJson)
+	Expect(err).To(BeNil())
+	Expect(p.print(value, rows)).To(BeNil())
+	Expect(out.String()).To(ContainSubstring(`"network_service": "secure-intranet"`))
+
+	out.Reset()
+	p, err = newPrinter(out, OutputYaml)
+	Expect(err).To(BeNil())
+	Expect(p.print(value, rows)).To(BeNil())
+	Expect(out.String()).To(Equal("- name: nse-1\n  network_service: secure-intranet\n"))
+
+	_, err = newPrinter(out, "xml")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestPrinterEvents(t *testing.T) {
+	RegisterTestingT(t)
+
+	out := &bytes.Buffer{}
+	p, err := newPrinter(out, OutputTable)
+	Expect(err).To(BeNil())
+	Expect(p.printEvent(nil, table{{"EVENT", "ID"}, {"UPDATE", "1"}}, true)).To(BeNil())
+	Expect(p.printEvent(nil, table{{"EVENT", "ID"}, {"DELETE", "1"}}, false)).To(BeNil())
+	Expect(out.String()).To(Equal("EVENT   ID\nUPDATE  1\nDELETE  1\n"))
+
+	out.Reset()
+	p, err = newPrinter(out, OutputJson)
+	Expect(err).To(BeNil())
+	Expect(p.printEvent(map[string]string{"type": "UPDATE"}, nil, true)).To(BeNil())
+	Expect(p.printEvent(map[string]string{"type": "DELETE"}, nil, false)).To(BeNil())
+	Expect(out.String()).To(Equal("{\"type\":\"UPDATE\"}\n{\"type\":\"DELETE\"}\n"))
+}
@@ -0,0 +1,190 @@
+package nsmd
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// adminServer - serves inspection and manual operations of NSM, shares workspaces with NSM server.
+type adminServer struct {
+	nsm *nsmServer
+}
+
+// modelDump - JSON representation of model.
+type modelDump struct {
+	Nsm         *registry.NetworkServiceManager `json:"nsm"`
+	Endpoints   []*registry.NSERegistration     `json:"endpoints"`
+	Dataplanes  []*model.Dataplane              `json:"dataplanes"`
+	Connections []*clientConnectionDump         `json:"connections"`
+}
+
+type clientConnectionDump struct {
+	*model.ClientConnection
+	State string `json:"state"`
+}
+
+func newAdminServer(nsm *nsmServer) admin.NSMAdminServer {
+	return &adminServer{
+		nsm: nsm,
+	}
+}
+
+func (srv *adminServer) GetConnections(context.Context, *empty.Empty) (*admin.ClientConnections, error) {
+	result := &admin.ClientConnections{}
+	for _, clientConnection := range srv.nsm.model.GetAllClientConnections() {
+		info := &admin.ClientConnectionInfo{
+			ConnectionId:   clientConnection.GetId(),
+			NetworkService: clientConnection.GetNetworkService(),
+			State:          clientConnection.ConnectionState.String(),
+			Endpoint:       clientConnection.Endpoint.GetNetworkserviceEndpoint().GetEndpointName(),
+			RemoteNsm:      clientConnection.RemoteNsm.GetName(),
+			RemoteNsmUrl:   clientConnection.RemoteNsm.GetUrl(),
+			Xcon:           clientConnection.Xcon,
+		}
+		if clientConnection.Dataplane != nil {
+			info.Dataplane = clientConnection.Dataplane.RegisteredName
+		}
+		if localSource := clientConnection.Xcon.GetLocalSource(); localSource != nil {
+			info.Workspace = localSource.GetMechanism().GetWorkspace()
+		}
+		result.Connections = append(result.Connections, info)
+	}
+	sort.Slice(result.Connections, func(i, j int) bool {
+		return result.Connections[i].ConnectionId < result.Connections[j].ConnectionId
+	})
+	return result, nil
+}
+
+func (srv *adminServer) GetEndpoints(context.Context, *empty.Empty) (*admin.Endpoints, error) {
+	result := &admin.Endpoints{}
+	for _, endpoint := range srv.nsm.model.GetAllEndpoints() {
+		result.Endpoints = append(result.Endpoints, &admin.EndpointInfo{
+			Name:                  endpoint.GetNetworkserviceEndpoint().GetEndpointName(),
+			NetworkService:        endpoint.GetNetworkService().GetName(),
+			NetworkServiceManager: endpoint.GetNetworkServiceManager().GetName(),
+			Labels:                endpoint.GetNetworkserviceEndpoint().GetLabels(),
+		})
+	}
+	sort.Slice(result.Endpoints, func(i, j int) bool {
+		return result.Endpoints[i].Name < result.Endpoints[j].Name
+	})
+	return result, nil
+}
+
+func (srv *adminServer) GetDataplanes(context.Context, *empty.Empty) (*admin.Dataplanes, error) {
+	result := &admin.Dataplanes{}
+	for _, dp := range srv.nsm.model.GetAllDataplanes() {
+		info := &admin.DataplaneInfo{
+			Name:           dp.RegisteredName,
+			SocketLocation: dp.SocketLocation,
+		}
+		for _, mechanism := range dp.LocalMechanisms {
+			info.LocalMechanisms = append(info.LocalMechanisms, mechanism.GetType().String())
+		}
+		for _, mechanism := range dp.RemoteMechanisms {
+			info.RemoteMechanisms = append(info.RemoteMechanisms, mechanism.GetType().String())
+		}
+		result.Dataplanes = append(result.Dataplanes, info)
+	}
+	sort.Slice(result.Dataplanes, func(i, j int) bool {
+		return result.Dataplanes[i].Name < result.Dataplanes[j].Name
+	})
+	return result, nil
+}
+
+func (srv *adminServer) GetWorkspaces(ctx context.Context, _ *empty.Empty) (*admin.Workspaces, error) {
+	reply, err := srv.nsm.EnumConnection(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(reply.Workspace)
+	return &admin.Workspaces{Workspaces: reply.Workspace}, nil
+}
+
+func (srv *adminServer) DumpModel(context.Context, *empty.Empty) (*admin.ModelDump, error) {
+	// Model entities are copied, since they could be changed while dump is marshalled.
+	dump := &modelDump{}
+	if localNsm := srv.nsm.model.GetNsm(); localNsm != nil {
+		dump.Nsm = proto.Clone(localNsm).(*registry.NetworkServiceManager)
+	}
+	for _, endpoint := range srv.nsm.model.GetAllEndpoints() {
+		dump.Endpoints = append(dump.Endpoints, proto.Clone(endpoint).(*registry.NSERegistration))
+	}
+	for _, dp := range srv.nsm.model.GetAllDataplanes() {
+		dump.Dataplanes = append(dump.Dataplanes, dp.Clone())
+	}
+	for _, clientConnection := range srv.nsm.model.GetAllClientConnections() {
+		clientConnection = clientConnection.Clone()
+		dump.Connections = append(dump.Connections, &clientConnectionDump{
+			ClientConnection: clientConnection,
+			State:            clientConnection.ConnectionState.String(),
+		})
+	}
+	data, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to dump model: %v", err)
+	}
+	return &admin.ModelDump{Json: string(data)}, nil
+}
+
+func (srv *adminServer) CloseConnection(ctx context.Context, request *admin.ConnectionId) (*empty.Empty, error) {
+	ctx, closeId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("NSMD Admin:(%v) Force close of connection %s", closeId, request.GetConnectionId())
+	clientConnection, err := srv.getClientConnection(request.GetConnectionId())
+	if err != nil {
+		return nil, err
+	}
+	if err := srv.nsm.manager.Close(ctx, clientConnection); err != nil {
+		log.Errorf("NSMD Admin:(%v) Error during connection close: %v", closeId, err)
+	}
+	// Local client is notified connection is closed, as if it is closed by client itself.
+	if localSource := clientConnection.Xcon.GetLocalSource(); localSource != nil {
+		srv.nsm.Lock()
+		workspace := srv.nsm.workspaces[localSource.GetMechanism().GetWorkspace()]
+		srv.nsm.Unlock()
+		if workspace != nil {
+			workspace.MonitorConnectionServer().Delete(localSource)
+		}
+	}
+	return &empty.Empty{}, nil
+}
+
+func (srv *adminServer) HealConnection(ctx context.Context, request *admin.ConnectionId) (*empty.Empty, error) {
+	logrus.Infof("NSMD Admin: Force heal of connection %s", request.GetConnectionId())
+	clientConnection, err := srv.getClientConnection(request.GetConnectionId())
+	if err != nil {
+		return nil, err
+	}
+	if clientConnection.ConnectionState != model.ClientConnection_Ready {
+		return nil, status.Errorf(codes.FailedPrecondition, "connection %s is %s, only ready connections could be healed",
+			clientConnection.GetId(), clientConnection.ConnectionState)
+	}
+	// Connection requested by remote NSM is healed by its source NSM, it would only be asked to re-request it.
+	if remoteSource := clientConnection.Xcon.GetRemoteSource(); remoteSource != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "connection %s is requested by NSM %s, it could be healed only by source NSM",
+			clientConnection.GetId(), remoteSource.GetSourceNetworkServiceManagerName())
+	}
+	srv.nsm.manager.Heal(clientConnection, nsm.HealState_DstDown)
+	return &empty.Empty{}, nil
+}
+
+func (srv *adminServer) getClientConnection(connectionId string) (*model.ClientConnection, error) {
+	clientConnection := srv.nsm.model.GetClientConnection(connectionId)
+	if clientConnection == nil {
+		return nil, status.Errorf(codes.NotFound, "there is no such client connection %s", connectionId)
+	}
+	return clientConnection, nil
+}
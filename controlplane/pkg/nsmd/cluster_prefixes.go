@@ -0,0 +1,54 @@
+package nsmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StartClusterPrefixesMonitor - passes prefixes used by cluster, published by registry, to manager until context is
+// done. Last known prefixes are kept while registry is not available, monitor stops if registry does not publish them.
+func StartClusterPrefixesMonitor(ctx context.Context, serviceRegistry serviceregistry.ServiceRegistry, manager nsm.NetworkServiceManager) {
+	go func() {
+		for {
+			err := monitorClusterPrefixes(ctx, serviceRegistry, manager)
+			if ctx.Err() != nil {
+				return
+			}
+			if status.Code(err) == codes.Unimplemented {
+				logrus.Warnf("Registry does not publish cluster prefixes, only configured prefixes are excluded")
+				return
+			}
+			logrus.Errorf("Failed to monitor cluster prefixes, retrying in %v: %v", ClusterPrefixesRetryInterval, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(ClusterPrefixesRetryInterval):
+			}
+		}
+	}()
+}
+
+func monitorClusterPrefixes(ctx context.Context, serviceRegistry serviceregistry.ServiceRegistry, manager nsm.NetworkServiceManager) error {
+	client, err := serviceRegistry.ClusterInfoClient()
+	if err != nil {
+		return err
+	}
+	stream, err := client.MonitorExcludedPrefixes(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	for {
+		prefixes, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		manager.UpdateClusterPrefixes(prefixes.GetPrefixes())
+	}
+}
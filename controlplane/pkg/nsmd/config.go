@@ -0,0 +1,223 @@
+package nsmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/policy"
+	"github.com/sirupsen/logrus"
+)
+
+// Config - NSMD settings, read from YAML or JSON configuration file. Environment variables override values of file.
+type Config struct {
+	// ExcludedPrefixes - prefixes endpoints should not allocate addresses from.
+	ExcludedPrefixes []string `json:"excludedPrefixes,omitempty"`
+	// ApiAddress - address public NSMD API is listening on, restart is required to change it.
+	ApiAddress string `json:"apiAddress,omitempty"`
+	// RegistryAddress - address of Network Service Registry, restart is required to change it.
+	RegistryAddress string `json:"registryAddress,omitempty"`
+	// ProbesAddress - address liveness/readiness probes and metrics are served on, restart is required to change it.
+	ProbesAddress        string   `json:"probesAddress,omitempty"`
+	HealTimeout          Duration `json:"healTimeout,omitempty"`
+	NseConnectionTimeout Duration `json:"nseConnectionTimeout,omitempty"`
+	// ConnectionLease - lease of connections, leases are disabled if it is zero. Interval of checks for expired
+	// connections follows lease after reload.
+	ConnectionLease Duration `json:"connectionLease,omitempty"`
+	// EndpointProbeInterval - interval between health checks of local endpoints, restart is required to change it.
+	EndpointProbeInterval Duration `json:"endpointProbeInterval,omitempty"`
+	// PolicyFile - file with authorization policy, all requests are allowed if it is not set.
+	PolicyFile string `json:"policyFile,omitempty"`
+
+	policy *policy.Policy
+}
+
+// Duration - time.Duration written in configuration as a string, like "15s" or "1m".
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalJSON - parses duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration should be a string like \"15s\": %v", err)
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	d.Duration = duration
+	return nil
+}
+
+// MarshalJSON - writes duration as a string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
+}
+
+var (
+	currentConfig      *Config
+	currentConfigMutex sync.RWMutex
+)
+
+// DefaultConfig - returns configuration used if neither file nor environment set values.
+func DefaultConfig() *Config {
+	return &Config{
+		ExcludedPrefixes:      []string{},
+		ApiAddress:            NsmdApiAddressDefaults,
+		RegistryAddress:       DefaultRegistryAddress,
+		ProbesAddress:         DefaultProbesAddress,
+		HealTimeout:           Duration{DefaultHealTimeout},
+		NseConnectionTimeout:  Duration{DefaultNseConnectionTimeout},
+		ConnectionLease:       Duration{DefaultConnectionLease},
+		EndpointProbeInterval: Duration{DefaultEndpointProbeInterval},
+	}
+}
+
+// LoadConfig - reads configuration file, if file is set, applies environment overrides and validates result.
+func LoadConfig(file string) (*Config, error) {
+	config := DefaultConfig()
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("failed to parse configuration %s: %v", file, err)
+		}
+	}
+	if err := config.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+	if config.PolicyFile != "" {
+		var err error
+		if config.policy, err = policy.LoadPolicy(config.PolicyFile); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// Validate - checks prefixes, addresses and timeouts of configuration are valid.
+func (c *Config) Validate() error {
+	for _, prefix := range c.ExcludedPrefixes {
+		if _, _, err := net.ParseCIDR(prefix); err != nil {
+			return fmt.Errorf("excluded prefix %q is not a valid CIDR", prefix)
+		}
+	}
+	addresses := map[string]string{
+		"apiAddress":      c.ApiAddress,
+		"registryAddress": c.RegistryAddress,
+		"probesAddress":   c.ProbesAddress,
+	}
+	for name, address := range addresses {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return fmt.Errorf("%s %q should be host:port: %v", name, address, err)
+		}
+	}
+	durations := map[string]Duration{
+		"healTimeout":           c.HealTimeout,
+		"nseConnectionTimeout":  c.NseConnectionTimeout,
+		"endpointProbeInterval": c.EndpointProbeInterval,
+	}
+	for name, duration := range durations {
+		if duration.Duration <= 0 {
+			return fmt.Errorf("%s should be positive, got %v", name, duration.Duration)
+		}
+	}
+	if lease := c.ConnectionLease.Duration; lease < 0 || (lease > 0 && lease < MinConnectionLease) {
+		return fmt.Errorf("connectionLease should be at least %v or zero to disable leases, got %v", MinConnectionLease, lease)
+	}
+	return nil
+}
+
+// Policy - returns authorization policy loaded from PolicyFile, nil if policy file is not set.
+func (c *Config) Policy() *policy.Policy {
+	return c.policy
+}
+
+// ManagerSettings - returns settings of Network Service Manager.
+func (c *Config) ManagerSettings() nsm.Settings {
+	return nsm.Settings{
+		ExcludedPrefixes:     append([]string{}, c.ExcludedPrefixes...),
+		LeaseDuration:        c.ConnectionLease.Duration,
+		HealTimeout:          c.HealTimeout.Duration,
+		NseConnectionTimeout: c.NseConnectionTimeout.Duration,
+	}
+}
+
+// applyEnv - overrides values set by environment, values which could not be parsed are not applied.
+func (c *Config) applyEnv() error {
+	var result error
+	if value, ok := os.LookupEnv(ExcludedPrefixesEnv); ok {
+		c.ExcludedPrefixes = []string{}
+		for _, s := range strings.Split(value, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				c.ExcludedPrefixes = append(c.ExcludedPrefixes, s)
+			}
+		}
+	}
+	applyStringEnv(NsmdApiAddressEnv, &c.ApiAddress)
+	applyStringEnv(RegistryAddressEnv, &c.RegistryAddress)
+	applyStringEnv(ProbesAddressEnv, &c.ProbesAddress)
+	applyStringEnv(PolicyFileEnv, &c.PolicyFile)
+	durations := map[string]*Duration{
+		HealTimeoutEnv:           &c.HealTimeout,
+		NseConnectionTimeoutEnv:  &c.NseConnectionTimeout,
+		ConnectionLeaseEnv:       &c.ConnectionLease,
+		EndpointProbeIntervalEnv: &c.EndpointProbeInterval,
+	}
+	for env, duration := range durations {
+		value := strings.TrimSpace(os.Getenv(env))
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			result = fmt.Errorf("invalid %s value %s: %v", env, value, err)
+			continue
+		}
+		duration.Duration = parsed
+	}
+	return result
+}
+
+func applyStringEnv(env string, value *string) {
+	if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+		*value = v
+	}
+}
+
+// SetConfig - sets configuration returned by GetConfig.
+func SetConfig(config *Config) {
+	currentConfigMutex.Lock()
+	defer currentConfigMutex.Unlock()
+	currentConfig = config
+}
+
+// GetConfig - returns configuration set by SetConfig. If it is not set, default configuration overridden by
+// environment is returned without validation.
+func GetConfig() *Config {
+	currentConfigMutex.RLock()
+	config := currentConfig
+	currentConfigMutex.RUnlock()
+	if config != nil {
+		return config
+	}
+	config = DefaultConfig()
+	if err := config.applyEnv(); err != nil {
+		logrus.Errorf("Using default value: %v", err)
+	}
+	return config
+}
@@ -0,0 +1,117 @@
+package nsmd
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ConfigWatcher - reloads NSMD configuration and notifies listeners, so settings are changed without restart.
+type ConfigWatcher struct {
+	sync.Mutex
+	file      string
+	config    *Config
+	modTimes  map[string]time.Time
+	listeners []func(config *Config)
+}
+
+// NewConfigWatcher - loads configuration from file and sets it as current one, error is returned if configuration
+// is invalid. Configuration is taken from environment only if file is empty.
+func NewConfigWatcher(file string) (*ConfigWatcher, error) {
+	config, err := LoadConfig(file)
+	if err != nil {
+		return nil, err
+	}
+	w := &ConfigWatcher{
+		file:   file,
+		config: config,
+	}
+	w.modTimes = w.readModTimes(config)
+	SetConfig(config)
+	return w, nil
+}
+
+// Config - returns current configuration.
+func (w *ConfigWatcher) Config() *Config {
+	w.Lock()
+	defer w.Unlock()
+	return w.config
+}
+
+// AddListener - adds listener called with new configuration every time it is reloaded.
+func (w *ConfigWatcher) AddListener(listener func(config *Config)) {
+	w.Lock()
+	defer w.Unlock()
+	w.listeners = append(w.listeners, listener)
+}
+
+// Reload - loads configuration again, current configuration is kept if new one is invalid.
+func (w *ConfigWatcher) Reload() error {
+	w.Lock()
+	defer w.Unlock()
+	// Remember files are seen even if they are invalid, so they are not reloaded again until changed.
+	w.modTimes = w.readModTimes(w.config)
+	config, err := LoadConfig(w.file)
+	if err != nil {
+		logrus.Errorf("Failed to reload configuration, keeping current one: %v", err)
+		return err
+	}
+	w.modTimes = w.readModTimes(config)
+	if config.ApiAddress != w.config.ApiAddress || config.RegistryAddress != w.config.RegistryAddress ||
+		config.ProbesAddress != w.config.ProbesAddress {
+		logrus.Warnf("Configuration addresses are changed, NSMD restart is required to apply them")
+	}
+	w.config = config
+	SetConfig(config)
+	logrus.Infof("Configuration is reloaded: %+v", config)
+	for _, listener := range w.listeners {
+		listener(config)
+	}
+	return nil
+}
+
+// Start - reloads configuration every time configuration or policy file is changed, until context is done.
+func (w *ConfigWatcher) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if w.isChanged() {
+					_ = w.Reload()
+				}
+			}
+		}
+	}()
+}
+
+func (w *ConfigWatcher) isChanged() bool {
+	w.Lock()
+	defer w.Unlock()
+	modTimes := w.readModTimes(w.config)
+	for file, modTime := range modTimes {
+		if !modTime.Equal(w.modTimes[file]) {
+			return true
+		}
+	}
+	return len(modTimes) != len(w.modTimes)
+}
+
+func (w *ConfigWatcher) readModTimes(config *Config) map[string]time.Time {
+	modTimes := map[string]time.Time{}
+	for _, file := range []string{w.file, config.PolicyFile} {
+		if file == "" {
+			continue
+		}
+		if info, err := os.Stat(file); err == nil {
+			modTimes[file] = info.ModTime()
+		}
+	}
+	return modTimes
+}
@@ -1,8 +1,52 @@
 package nsmd
 
+import "time"
+
 const (
-	NsmServerSocketEnv  = "NSM_SERVER_SOCKET"
-	NsmClientSocketEnv  = "NSM_CLIENT_SOCKET"
-	WorkspaceEnv        = "WORKSPACE"
-	ExcludedPrefixesEnv = "EXCLUDED_PREFIXES"
+	NsmServerSocketEnv       = "NSM_SERVER_SOCKET"
+	NsmClientSocketEnv       = "NSM_CLIENT_SOCKET"
+	WorkspaceEnv             = "WORKSPACE"
+	ExcludedPrefixesEnv      = "EXCLUDED_PREFIXES"
+	NsmBaseDirEnv            = "NSM_BASEDIR"
+	VniRangesEnv             = "VNI_RANGES"
+	ConnectionLeaseEnv       = "NSM_CONNECTION_LEASE"
+	EndpointProbeIntervalEnv = "NSE_PROBE_INTERVAL"
+	DomainsEnv               = "NSM_DOMAINS"
+	JournalSizeEnv           = "NSM_JOURNAL_SIZE"
+	JournalFileEnv           = "NSM_JOURNAL_FILE"
+	JournalFileSizeEnv       = "NSM_JOURNAL_FILE_SIZE"
+	PolicyFileEnv            = "NSM_POLICY_FILE"
+	ConfigFileEnv            = "NSM_CONFIG_FILE"
+	RegistryAddressEnv       = "NSM_REGISTRY_ADDRESS"
+	ProbesAddressEnv         = "NSM_PROBES_ADDRESS"
+	HealTimeoutEnv           = "NSM_HEAL_TIMEOUT"
+	NseConnectionTimeoutEnv  = "NSE_CONNECTION_TIMEOUT"
+
+	DefaultNsmBaseDir = "/var/lib/networkservicemesh/"
+	VniStateFile      = "nsmd.vni.json"
+
+	// DefaultConnectionLease - time connection is alive without refresh by its source.
+	DefaultConnectionLease = 10 * time.Minute
+	// MinConnectionLease - shortest lease accepted, sources could not refresh connections more often.
+	MinConnectionLease = time.Second
+	// MaxConnectionReaperInterval - longest interval between checks of expired connections and remote leases.
+	MaxConnectionReaperInterval = 30 * time.Second
+
+	// DefaultEndpointProbeInterval - interval between health checks of local endpoints.
+	DefaultEndpointProbeInterval = 5 * time.Second
+	// EndpointProbeTimeout - time to wait for endpoint health check response.
+	EndpointProbeTimeout = 2 * time.Second
+	// EndpointProbeFailureThreshold - number of consecutive failed health checks to mark endpoint as unhealthy.
+	EndpointProbeFailureThreshold = 3
+
+	DefaultRegistryAddress = "127.0.0.1:5000"
+	DefaultProbesAddress   = "0.0.0.0:5555"
+	// DefaultHealTimeout - time to recover connection before it is closed.
+	DefaultHealTimeout = time.Minute
+	// DefaultNseConnectionTimeout - time for NSM to succeed connection to NSE.
+	DefaultNseConnectionTimeout = 15 * time.Second
+	// ConfigCheckInterval - interval between checks configuration file is changed.
+	ConfigCheckInterval = 5 * time.Second
+	// ClusterPrefixesRetryInterval - interval between attempts to monitor prefixes used by cluster.
+	ClusterPrefixesRetryInterval = 5 * time.Second
 )
@@ -0,0 +1,142 @@
+package nsmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// EndpointHealthMonitor - checks health of local endpoints, marks failed ones as unhealthy in model
+// and heals their connections before clients notice endpoint is gone.
+type EndpointHealthMonitor struct {
+	model.ModelListenerImpl
+	model           model.Model
+	serviceRegistry serviceregistry.ServiceRegistry
+	xconManager     *services.ClientConnectionManager
+	endpoints       map[string]int // endpoint name -> number of consecutive failed checks
+	sync.Mutex
+}
+
+func NewEndpointHealthMonitor(model model.Model, serviceRegistry serviceregistry.ServiceRegistry, xconManager *services.ClientConnectionManager) *EndpointHealthMonitor {
+	return &EndpointHealthMonitor{
+		model:           model,
+		serviceRegistry: serviceRegistry,
+		xconManager:     xconManager,
+		endpoints:       map[string]int{},
+	}
+}
+
+func (m *EndpointHealthMonitor) EndpointAdded(endpoint *registry.NetworkServiceEndpoint) {
+	m.Lock()
+	defer m.Unlock()
+	m.endpoints[endpoint.GetEndpointName()] = 0
+}
+
+func (m *EndpointHealthMonitor) EndpointDeleted(endpoint *registry.NetworkServiceEndpoint) {
+	m.Lock()
+	defer m.Unlock()
+	delete(m.endpoints, endpoint.GetEndpointName())
+}
+
+// Start - checks health of endpoints every interval until context is done.
+func (m *EndpointHealthMonitor) Start(ctx context.Context, interval time.Duration) {
+	m.model.AddListener(m)
+	go func() {
+		defer m.model.RemoveListener(m)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.ProbeEndpoints(ctx)
+			}
+		}
+	}()
+}
+
+// ProbeEndpoints - checks health of all known endpoints once.
+func (m *EndpointHealthMonitor) ProbeEndpoints(ctx context.Context) {
+	m.Lock()
+	names := make([]string, 0, len(m.endpoints))
+	for name := range m.endpoints {
+		names = append(names, name)
+	}
+	m.Unlock()
+
+	for _, name := range names {
+		endpoint := m.model.GetEndpoint(name)
+		if endpoint == nil {
+			continue
+		}
+		err := m.probe(ctx, endpoint)
+		m.Lock()
+		if _, ok := m.endpoints[name]; !ok {
+			// Endpoint is removed during the check.
+			m.Unlock()
+			continue
+		}
+		if err == nil {
+			m.endpoints[name] = 0
+			m.Unlock()
+			if !m.model.IsEndpointHealthy(name) {
+				logrus.Infof("NSE_Health: Endpoint %s is healthy again", name)
+				m.model.SetEndpointHealthy(name, true)
+			}
+			continue
+		}
+		m.endpoints[name]++
+		failures := m.endpoints[name]
+		m.Unlock()
+		logrus.Warnf("NSE_Health: Endpoint %s health check failed %d times: %v", name, failures, err)
+		if failures >= EndpointProbeFailureThreshold && m.model.IsEndpointHealthy(name) {
+			m.markUnhealthy(name)
+		}
+	}
+}
+
+func (m *EndpointHealthMonitor) probe(ctx context.Context, endpoint *registry.NSERegistration) error {
+	client, conn, err := m.serviceRegistry.EndpointHealthClient(endpoint)
+	if err != nil {
+		return err
+	}
+	if conn != nil {
+		defer conn.Close()
+	}
+	probeCtx, cancel := context.WithTimeout(ctx, EndpointProbeTimeout)
+	defer cancel()
+	response, err := client.Check(probeCtx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		if status.Code(err) == codes.Unimplemented {
+			// Endpoint does not expose health service, but it is responding, so consider it alive.
+			return nil
+		}
+		return err
+	}
+	if response.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("endpoint status is %v", response.GetStatus())
+	}
+	return nil
+}
+
+func (m *EndpointHealthMonitor) markUnhealthy(name string) {
+	logrus.Errorf("NSE_Health: Endpoint %s is unhealthy, healing its connections", name)
+	m.model.SetEndpointHealthy(name, false)
+	for _, clientConnection := range m.xconManager.GetClientConnectionsByEndpoint(name) {
+		if clientConnection.ConnectionState != model.ClientConnection_Ready {
+			continue
+		}
+		go m.xconManager.UpdateClientConnectionDstStateDown(clientConnection)
+	}
+}
@@ -2,42 +2,55 @@ package nsmd
 
 import (
 	"fmt"
-	"time"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/policy"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 )
 
-const (
-	// nseConnectionTimeout defines a timoute for NSM to succeed connection to NSE (seconds)
-	nseConnectionTimeout = 15 * time.Second
-)
-
 type networkServiceServer struct {
 	model           model.Model
 	workspace       *Workspace
 	serviceRegistry serviceregistry.ServiceRegistry
 	manager         nsm.NetworkServiceManager
+	policyEngine    *policy.Engine
+	// pod - pod workspace is allocated to, it is resolved once it is required by policy.
+	pod      string
+	podMutex sync.Mutex
 }
 
-func NewNetworkServiceServer(model model.Model, workspace *Workspace, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry) networkservice.NetworkServiceServer {
+func NewNetworkServiceServer(model model.Model, workspace *Workspace, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, policyEngine *policy.Engine) networkservice.NetworkServiceServer {
 	rv := &networkServiceServer{
 		model:           model,
 		workspace:       workspace,
 		serviceRegistry: serviceRegistry,
 		manager:         manager,
+		policyEngine:    policyEngine,
 	}
 	return rv
 }
 
 func (srv *networkServiceServer) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
-	logrus.Infof("Received request from client to connect to NetworkService: %v", request)
+	ctx, requestId := tools.EnsureCorrelationId(ctx)
+	tools.Log(ctx).Infof("NSMD:(%v) Received request from client to connect to NetworkService: %v", requestId, request)
+	if err := srv.policyEngine.Authorize(&policy.Request{
+		ConnectionId:   request.GetConnection().GetId(),
+		NetworkService: request.GetConnection().GetNetworkService(),
+		Workspace:      srv.workspace.Name(),
+		Pod:            srv.workspacePod(requestId),
+		Labels:         request.GetConnection().GetLabels(),
+		SourceNsm:      srv.model.GetNsm().GetName(),
+	}); err != nil {
+		return nil, err
+	}
 	srv.updateMechanisms(request)
 
 	conn, err := srv.manager.Request(ctx, request)
@@ -49,6 +62,26 @@ func (srv *networkServiceServer) Request(ctx context.Context, request *networkse
 	return result, nil
 }
 
+// workspacePod - returns pod workspace is allocated to, requests are denied by pod rules if it is unknown.
+// Pod is resolved only if policy has pod rules, workspace is allocated to the same pod until it is deleted.
+func (srv *networkServiceServer) workspacePod(requestId string) string {
+	if !srv.policyEngine.UsesPods() {
+		return ""
+	}
+	srv.podMutex.Lock()
+	defer srv.podMutex.Unlock()
+	if srv.pod != "" {
+		return srv.pod
+	}
+	pod, err := srv.serviceRegistry.WorkspacePod(srv.workspace.Name())
+	if err != nil {
+		logrus.Warnf("NSMD:(%v) Pod of workspace %s is unknown: %v", requestId, srv.workspace.Name(), err)
+		return ""
+	}
+	srv.pod = pod
+	return pod
+}
+
 func (srv *networkServiceServer) updateMechanisms(request *networkservice.NetworkServiceRequest) {
 	// Update passed local mechanism paramaters to contains a workspace name
 	for _, mechanism := range request.MechanismPreferences {
@@ -60,14 +93,16 @@ func (srv *networkServiceServer) updateMechanisms(request *networkservice.Networ
 }
 
 func (srv *networkServiceServer) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
-	logrus.Infof("Closing connection: %v", *connection)
+	ctx, closeId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("NSMD:(%v) Closing connection: %v", closeId, *connection)
 	clientConnection := srv.model.GetClientConnection(connection.GetId())
 	if clientConnection == nil {
 		return nil, fmt.Errorf("There is no such client connection %v", connection)
 	}
 	err := srv.manager.Close(ctx, clientConnection)
 	if err != nil {
-		logrus.Errorf("Error during connection close: %v", err)
+		log.Errorf("NSMD:(%v) Error during connection close: %v", closeId, err)
 	}
 	srv.workspace.MonitorConnectionServer().Delete(connection)
 	return &empty.Empty{}, nil
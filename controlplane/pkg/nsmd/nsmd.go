@@ -3,6 +3,7 @@ package nsmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,13 +16,19 @@ import (
 	opentracing "github.com/opentracing/opentracing-go"
 
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/admin"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connection_journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/policy"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/remote/network_service_server"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -34,6 +41,7 @@ type nsmServer struct {
 	model           model.Model
 	serviceRegistry serviceregistry.ServiceRegistry
 	manager         nsm.NetworkServiceManager
+	policyEngine    *policy.Engine
 }
 
 func RequestWorkspace(serviceRegistry serviceregistry.ServiceRegistry, id string) (*nsmdapi.ClientConnectionReply, error) {
@@ -54,7 +62,7 @@ func RequestWorkspace(serviceRegistry serviceregistry.ServiceRegistry, id string
 func (nsm *nsmServer) RequestClientConnection(context context.Context, request *nsmdapi.ClientConnectionRequest) (*nsmdapi.ClientConnectionReply, error) {
 	logrus.Infof("Requested client connection to nsmd : %+v", request)
 
-	workspace, err := NewWorkSpace(nsm.model, nsm.manager, nsm.serviceRegistry, request.Workspace)
+	workspace, err := NewWorkSpace(nsm.model, nsm.manager, nsm.serviceRegistry, request.Workspace, nsm.policyEngine)
 	if err != nil {
 		logrus.Error(err)
 		return nil, err
@@ -95,14 +103,14 @@ func (nsm *nsmServer) DeleteClientConnection(context context.Context, request *n
 func (nsm *nsmServer) EnumConnection(context context.Context, request *nsmdapi.EnumConnectionRequest) (*nsmdapi.EnumConnectionReply, error) {
 	nsm.Lock()
 	defer nsm.Unlock()
-	workspaces := make([]string, len(nsm.workspaces), len(nsm.workspaces))
+	workspaces := make([]string, 0, len(nsm.workspaces))
 	for w := range nsm.workspaces {
 		workspaces = append(workspaces, w)
 	}
 	return &nsmdapi.EnumConnectionReply{Workspace: workspaces}, nil
 }
 
-func StartNSMServer(model model.Model, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, apiRegistry serviceregistry.ApiRegistry) error {
+func StartNSMServer(model model.Model, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, apiRegistry serviceregistry.ApiRegistry, policyEngine *policy.Engine, connectionJournal *connection_journal.Journal) error {
 	if err := tools.SocketCleanup(ServerSock); err != nil {
 		return err
 	}
@@ -122,8 +130,12 @@ func StartNSMServer(model model.Model, manager nsm.NetworkServiceManager, servic
 		model:           model,
 		serviceRegistry: serviceRegistry,
 		manager:         manager,
+		policyEngine:    policyEngine,
 	}
 	nsmdapi.RegisterNSMDServer(grpcServer, &nsm)
+	admin.RegisterNSMAdminServer(grpcServer, newAdminServer(&nsm))
+	// Journal exposes connections of all clients, so it is served on local socket only, as admin API is.
+	journal.RegisterConnectionJournalServer(grpcServer, connection_journal.NewJournalServer(connectionJournal))
 
 	sock, err := apiRegistry.NewNSMServerListener()
 	if err != nil {
@@ -172,18 +184,19 @@ func setLocalNSM(model model.Model, serviceRegistry serviceregistry.ServiceRegis
 	return nil
 }
 
-func StartAPIServer(model model.Model, manager nsm.NetworkServiceManager, apiRegistry serviceregistry.ApiRegistry, serviceRegistry serviceregistry.ServiceRegistry) error {
+func StartAPIServer(model model.Model, manager nsm.NetworkServiceManager, apiRegistry serviceregistry.ApiRegistry, serviceRegistry serviceregistry.ServiceRegistry, policyEngine *policy.Engine) error {
 	sock, err := apiRegistry.NewPublicListener()
 	if err != nil {
 		return err
 	}
 	xconManager := services.NewClientConnectionManager(model, manager, serviceRegistry)
 	tracer := opentracing.GlobalTracer()
-	grpcServer := grpc.NewServer(
+	grpcServer := grpc.NewServer(append([]grpc.ServerOption{
 		grpc.UnaryInterceptor(
 			otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads())),
 		grpc.StreamInterceptor(
-			otgrpc.OpenTracingStreamServerInterceptor(tracer)))
+			otgrpc.OpenTracingStreamServerInterceptor(tracer))},
+		serviceRegistry.SecurityProvider().ServerOptions()...)...)
 
 	// Start CrossConnect monitor server
 	//monitorCrossConnectServer := monitor_crossconnect_server.NewMonitorCrossConnectServer()
@@ -193,15 +206,17 @@ func StartAPIServer(model model.Model, manager nsm.NetworkServiceManager, apiReg
 	// Start Connection monitor server
 	//monitorConnectionServer := monitor_connection_server.NewMonitorConnectionServer()
 	monitorConnectionServer := remote_connection_monitor.NewRemoteConnectionMonitor()
-	connection.RegisterMonitorConnectionServer(grpcServer, monitorConnectionServer)
 
 	// Register CrossConnect monitorCrossConnectServer client as ModelListener
 	monitorCrossConnectClient := NewMonitorCrossConnectClient(monitorCrossConnectServer, monitorConnectionServer, xconManager)
+	monitorCrossConnectClient.SetSecurityProvider(serviceRegistry.SecurityProvider())
 	model.AddListener(monitorCrossConnectClient)
 
 	// Register Remote NetworkServiceManager
-	remoteServer := network_service_server.NewRemoteNetworkServiceServer(model, manager, serviceRegistry, monitorConnectionServer)
+	remoteServer := network_service_server.NewRemoteNetworkServiceServer(model, manager, serviceRegistry, monitorConnectionServer, policyEngine)
 	networkservice.RegisterNetworkServiceServer(grpcServer, remoteServer)
+	// Connection events are served to remote NSMs only about their connections.
+	connection.RegisterMonitorConnectionServer(grpcServer, remoteServer)
 
 	// TODO: Add more public API services here.
 
@@ -215,14 +230,125 @@ func StartAPIServer(model model.Model, manager nsm.NetworkServiceManager, apiReg
 	return nil
 }
 
+// GetExcludedPrefixes - returns prefixes endpoints should not allocate addresses from.
 func GetExcludedPrefixes() []string {
-	//TODO: Add a better way to pass this value to NSMD
-	excluded_prefixes := []string{}
-	exclude_prefixes_env, ok := os.LookupEnv(ExcludedPrefixesEnv)
-	if ok {
-		for _, s := range strings.Split(exclude_prefixes_env, ",") {
-			excluded_prefixes = append(excluded_prefixes, strings.TrimSpace(s))
+	return GetConfig().ManagerSettings().ExcludedPrefixes
+}
+
+func GetNsmBaseDir() string {
+	baseDir, ok := os.LookupEnv(NsmBaseDirEnv)
+	if !ok || strings.TrimSpace(baseDir) == "" {
+		return DefaultNsmBaseDir
+	}
+	return strings.TrimSpace(baseDir)
+}
+
+// GetConnectionLease - returns a duration of connection lease, sources should refresh connections before it is expired.
+// Leases are disabled if it is zero.
+func GetConnectionLease() time.Duration {
+	return GetConfig().ConnectionLease.Duration
+}
+
+// GetEndpointProbeInterval - returns an interval between health checks of local endpoints.
+func GetEndpointProbeInterval() time.Duration {
+	return GetConfig().EndpointProbeInterval.Duration
+}
+
+// GetDomainResolver - returns a resolver of domains configured by environment, other domains are not reachable.
+func GetDomainResolver() interdomain.Resolver {
+	domains, err := interdomain.ParseDomains(os.Getenv(DomainsEnv))
+	if err != nil {
+		logrus.Errorf("Invalid %s value, inter-domain requests are disabled: %v", DomainsEnv, err)
+		domains = nil
+	}
+	return interdomain.NewStaticResolver(domains)
+}
+
+// GetSecurityProvider - returns mutual TLS credentials configured by environment, nil if TLS is not configured.
+// NSMD is not started with invalid configuration, to not fall back to plaintext silently.
+func GetSecurityProvider() *security.Provider {
+	provider, err := security.NewProviderFromEnv()
+	if err != nil {
+		logrus.Fatalf("Invalid TLS configuration: %v", err)
+	}
+	return provider
+}
+
+// NewPolicyEngine - creates engine authorizing requests with policy file set by configuration, all requests
+// are allowed if policy file is not set. Denials are recorded to connectionJournal.
+func NewPolicyEngine(connectionJournal *connection_journal.Journal) *policy.Engine {
+	config := GetConfig()
+	requestPolicy := config.Policy()
+	if requestPolicy == nil && config.PolicyFile != "" {
+		var err error
+		if requestPolicy, err = policy.LoadPolicy(config.PolicyFile); err != nil {
+			logrus.Fatalf("Failed to load authorization policy: %v", err)
+		}
+	}
+	if requestPolicy != nil {
+		logrus.Infof("Authorization policy is loaded from %s: %d rules", config.PolicyFile, len(requestPolicy.Rules))
+	}
+	return policy.NewEngine(requestPolicy, connectionJournal)
+}
+
+// NewConnectionJournal - creates a journal of connection lifecycle events stored in model, journal size, file to write
+// events to and size the file is rotated at are configured by environment.
+func NewConnectionJournal(model model.Model) *connection_journal.Journal {
+	size := connection_journal.DefaultCapacity
+	if value, ok := os.LookupEnv(JournalSizeEnv); ok && strings.TrimSpace(value) != "" {
+		parsed, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || parsed <= 0 {
+			logrus.Errorf("Invalid %s value %s, using default %v: %v", JournalSizeEnv, value, size, err)
+		} else {
+			size = parsed
+		}
+	}
+	journal := connection_journal.NewJournal(size)
+	if file := strings.TrimSpace(os.Getenv(JournalFileEnv)); file != "" {
+		maxFileSize := int64(connection_journal.DefaultMaxFileSize)
+		if value, ok := os.LookupEnv(JournalFileSizeEnv); ok && strings.TrimSpace(value) != "" {
+			parsed, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil || parsed <= 0 {
+				logrus.Errorf("Invalid %s value %s, using default %v: %v", JournalFileSizeEnv, value, maxFileSize, err)
+			} else {
+				maxFileSize = parsed
+			}
+		}
+		output, err := connection_journal.NewRotatingFile(file, maxFileSize)
+		if err != nil {
+			logrus.Errorf("Failed to open journal file %s, events are kept in memory only: %v", file, err)
+		} else {
+			journal.SetOutput(output)
 		}
 	}
-	return excluded_prefixes
+	model.AddListener(connection_journal.NewJournalListener(journal))
+	return journal
+}
+
+// StartConnectionReaper - periodically closes connections which leases are expired and refreshes leases granted by
+// remote NSMs, until context is done. Interval between checks depends on connection lease of current configuration,
+// so leases enabled by configuration reload are handled as well.
+func StartConnectionReaper(ctx context.Context, manager nsm.NetworkServiceManager) {
+	go func() {
+		for {
+			interval := connectionReaperInterval(GetConnectionLease())
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-time.After(interval):
+				manager.ReapExpiredConnections(now)
+				// Remote lease could be expired before next check, so it is refreshed in advance.
+				manager.RefreshRemoteLeases(ctx, now.Add(2*interval))
+			}
+		}
+	}()
+}
+
+// connectionReaperInterval - returns a quarter of lease, but checks are done at least every MaxConnectionReaperInterval
+// since remote NSMs grant their own leases and connections restored from previous run could have leases.
+func connectionReaperInterval(lease time.Duration) time.Duration {
+	if lease <= 0 || lease/4 > MaxConnectionReaperInterval {
+		return MaxConnectionReaperInterval
+	}
+	return lease / 4
 }
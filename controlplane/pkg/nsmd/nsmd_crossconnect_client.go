@@ -16,6 +16,7 @@ package nsmd
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
@@ -42,9 +44,23 @@ type NsmMonitorCrossConnectClient struct {
 	remotePeers         map[string]*remotePeerDescriptor
 	dataplanes          map[string]context.CancelFunc
 	xconManager         *services.ClientConnectionManager
+	securityProvider    *security.Provider
 	model.ModelListenerImpl
+
+	remotePeerMinBackoff  time.Duration
+	remotePeerMaxBackoff  time.Duration
+	remotePeerGracePeriod time.Duration
 }
 
+const (
+	// DefaultRemotePeerMinBackoff - initial delay before reconnect to remote NSM
+	DefaultRemotePeerMinBackoff = 1 * time.Second
+	// DefaultRemotePeerMaxBackoff - max delay between reconnects to remote NSM
+	DefaultRemotePeerMaxBackoff = 30 * time.Second
+	// DefaultRemotePeerGracePeriod - time remote NSM could be unavailable before its connections are healed
+	DefaultRemotePeerGracePeriod = 60 * time.Second
+)
+
 type remotePeerDescriptor struct {
 	xconCounter int
 	cancel      context.CancelFunc
@@ -58,13 +74,29 @@ func NewMonitorCrossConnectClient(crossConnectMonitor *crossconnect_monitor.Cros
 		remotePeers:         map[string]*remotePeerDescriptor{},
 		dataplanes:          map[string]context.CancelFunc{},
 		xconManager:         xconManager,
+
+		remotePeerMinBackoff:  DefaultRemotePeerMinBackoff,
+		remotePeerMaxBackoff:  DefaultRemotePeerMaxBackoff,
+		remotePeerGracePeriod: DefaultRemotePeerGracePeriod,
 	}
 	return rv
 }
 
-func dial(ctx context.Context, network string, address string) (*grpc.ClientConn, error) {
+// SetRemotePeerTimeouts - configures reconnect backoff and grace period used to detect remote NSM death.
+func (client *NsmMonitorCrossConnectClient) SetRemotePeerTimeouts(minBackoff, maxBackoff, gracePeriod time.Duration) {
+	client.remotePeerMinBackoff = minBackoff
+	client.remotePeerMaxBackoff = maxBackoff
+	client.remotePeerGracePeriod = gracePeriod
+}
+
+// SetSecurityProvider - configures mutual TLS credentials used to connect remote NSMs.
+func (client *NsmMonitorCrossConnectClient) SetSecurityProvider(securityProvider *security.Provider) {
+	client.securityProvider = securityProvider
+}
+
+func dial(ctx context.Context, network string, address string, credentials grpc.DialOption) (*grpc.ClientConn, error) {
 	tracer := opentracing.GlobalTracer()
-	conn, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock(),
+	conn, err := grpc.DialContext(ctx, address, credentials, grpc.WithBlock(),
 		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
 			return net.Dial(network, addr)
 		}),
@@ -139,7 +171,7 @@ func (client *NsmMonitorCrossConnectClient) ClientConnectionDeleted(clientConnec
 // monitor will remove all dataplane connections and will terminate itself.
 func (client *NsmMonitorCrossConnectClient) dataplaneCrossConnectMonitor(dataplane *model.Dataplane, ctx context.Context) {
 	logrus.Infof("Connecting to Dataplane %s %s", dataplane.RegisteredName, dataplane.SocketLocation)
-	conn, err := dial(context.Background(), "unix", dataplane.SocketLocation)
+	conn, err := dial(context.Background(), "unix", dataplane.SocketLocation, grpc.WithInsecure())
 	if err != nil {
 		logrus.Errorf("failure to communicate with the socket %s with error: %+v", dataplane.SocketLocation, err)
 		return
@@ -177,6 +209,9 @@ func (client *NsmMonitorCrossConnectClient) dataplaneCrossConnectMonitor(datapla
 					if src := xcon.GetLocalSource(); src != nil && src.State == local_connection.State_DOWN {
 						client.xconManager.UpdateClientConnectionSrcStateDown(clientConnection)
 					}
+					if src := xcon.GetRemoteSource(); src != nil && src.State == remote_connection.State_DOWN {
+						client.xconManager.UpdateClientConnectionSrcStateDown(clientConnection)
+					}
 					if dst := xcon.GetLocalDestination(); dst != nil && dst.State == local_connection.State_DOWN {
 						client.xconManager.UpdateClientConnectionDstStateDown(clientConnection)
 					}
@@ -193,49 +228,118 @@ func (client *NsmMonitorCrossConnectClient) dataplaneCrossConnectMonitor(datapla
 	}
 }
 
+// remotePeerConnectionMonitor is per remote NSM connection monitoring routine.
+// If connection to remote NSM is lost, it will try to reconnect with backoff, in case remote NSM is not available
+// longer than a grace period, all connections to remote NSM are healed.
 func (client *NsmMonitorCrossConnectClient) remotePeerConnectionMonitor(remotePeer *registry.NetworkServiceManager, ctx context.Context) {
+	defer func() {
+		logrus.Infof("Remote monitor closed... %v", remotePeer)
+	}()
+
+	backoff := client.remotePeerMinBackoff
+	var downSince time.Time
+	healed := false
+	for {
+		// Dial is bounded by the rest of grace period, so not responding remote NSM could not delay healing.
+		dialTimeout := client.remotePeerMaxBackoff
+		if !healed {
+			remaining := client.remotePeerGracePeriod
+			if !downSince.IsZero() {
+				remaining -= time.Since(downSince)
+			}
+			if remaining < dialTimeout {
+				dialTimeout = remaining
+			}
+		}
+		attempt := time.Now()
+		connected, err := client.monitorRemotePeer(remotePeer, ctx, dialTimeout)
+		select {
+		case <-ctx.Done():
+			logrus.Info("Context timeout exceeded...")
+			return
+		default:
+		}
+		if connected {
+			// Remote NSM was available till now, so start counting from scratch.
+			backoff = client.remotePeerMinBackoff
+			downSince = time.Now()
+			healed = false
+		} else if downSince.IsZero() {
+			// Remote NSM is down since the first failed attempt to connect.
+			downSince = attempt
+		}
+		logrus.Errorf("Connection to remote NSM %s is lost: %v, reconnecting in %v", remotePeer.GetName(), err, backoff)
+
+		if !healed && time.Since(downSince) >= client.remotePeerGracePeriod {
+			logrus.Errorf("Remote NSM %s is not available for %v, healing its connections", remotePeer.GetName(), time.Since(downSince))
+			for _, clientConnection := range client.xconManager.GetClientConnectionsByRemoteNsm(remotePeer.GetName()) {
+				client.xconManager.UpdateClientConnectionDstStateDown(clientConnection)
+			}
+			healed = true
+		}
+
+		delay := backoff
+		if !healed {
+			if remaining := client.remotePeerGracePeriod - time.Since(downSince); remaining < delay {
+				delay = remaining
+			}
+		}
+		select {
+		case <-ctx.Done():
+			logrus.Info("Context timeout exceeded...")
+			return
+		case <-time.After(delay):
+		}
+		backoff *= 2
+		if backoff > client.remotePeerMaxBackoff {
+			backoff = client.remotePeerMaxBackoff
+		}
+	}
+}
+
+// monitorRemotePeer - subscribes for connection events of remote NSM and processes them until stream is broken,
+// returns true if at least one event was received. Remote NSM not connected within dialTimeout is considered down.
+func (client *NsmMonitorCrossConnectClient) monitorRemotePeer(remotePeer *registry.NetworkServiceManager, ctx context.Context, dialTimeout time.Duration) (bool, error) {
 	logrus.Infof("Connecting to Remote NSM: %s", remotePeer.Name)
-	conn, err := grpc.Dial(remotePeer.Url, grpc.WithInsecure())
+	dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	conn, err := dial(dialCtx, "tcp", remotePeer.Url, client.securityProvider.DialOption(remotePeer.GetName()))
 	if err != nil {
-		logrus.Errorf("Failed to dial Network Service Registry %s at %s: %s", remotePeer.GetName(), remotePeer.Url, err)
-		return
+		return false, fmt.Errorf("failed to dial Remote NSM %s at %s: %v", remotePeer.GetName(), remotePeer.Url, err)
 	}
 	defer conn.Close()
 
-	defer func() {
-		logrus.Infof("Remote monitor closed... %v", remotePeer)
-	}()
-
 	monitorClient := remote_connection.NewMonitorConnectionClient(conn)
 	selector := &remote_connection.MonitorScopeSelector{NetworkServiceManagerName: remotePeer.Name}
-	stream, err := monitorClient.MonitorConnections(context.Background(), selector)
+	stream, err := monitorClient.MonitorConnections(ctx, selector)
 	if err != nil {
-		logrus.Error(err)
-		return
+		return false, err
 	}
 
+	connected := false
 	for {
 		select {
 		case <-ctx.Done():
-			logrus.Info("Context timeout exceeded...")
-			return
+			return connected, ctx.Err()
 		default:
 			logrus.Info("Recv Connection event...")
 			event, err := stream.Recv()
 			if err != nil {
-				logrus.Error(err)
-				//TODO (lobkovilya): handle remote NSM dies
-				return
+				return connected, err
 			}
+			connected = true
 			logrus.Infof("Receive event from remote NSM %s: %s %s", remotePeer.GetName(), event.Type, event.Connections)
 
+			if event.GetType() == connection.ConnectionEventType_INITIAL_STATE_TRANSFER {
+				client.reconcileRemotePeer(remotePeer, event)
+			}
 			for _, remoteConnection := range event.GetConnections() {
 				clientConnection := client.xconManager.GetClientConnectionByDst(remoteConnection.GetId())
 				if clientConnection == nil {
 					continue
 				}
 				switch event.GetType() {
-				case connection.ConnectionEventType_UPDATE:
+				case connection.ConnectionEventType_UPDATE, connection.ConnectionEventType_INITIAL_STATE_TRANSFER:
 					// DST connection is updated, we most probable need to re-programm our data plane.
 					client.xconManager.UpdateClientConnectionDstUpdated(clientConnection, remoteConnection)
 				case connection.ConnectionEventType_DELETE:
@@ -246,3 +350,23 @@ func (client *NsmMonitorCrossConnectClient) remotePeerConnectionMonitor(remotePe
 		}
 	}
 }
+
+// reconcileRemotePeer - heals connections to remote NSM, which are not known by remote NSM anymore,
+// it could happen if remote NSM was restarted while we were disconnected.
+func (client *NsmMonitorCrossConnectClient) reconcileRemotePeer(remotePeer *registry.NetworkServiceManager, event *remote_connection.ConnectionEvent) {
+	known := map[string]bool{}
+	for _, remoteConnection := range event.GetConnections() {
+		known[remoteConnection.GetId()] = true
+	}
+	for _, clientConnection := range client.xconManager.GetClientConnectionsByRemoteNsm(remotePeer.GetName()) {
+		remoteDestination := clientConnection.Xcon.GetRemoteDestination()
+		if remoteDestination == nil || known[remoteDestination.GetId()] {
+			continue
+		}
+		if clientConnection.ConnectionState != model.ClientConnection_Ready {
+			continue
+		}
+		logrus.Infof("Connection %s is not known by remote NSM %s, healing", clientConnection.ConnectionId, remotePeer.GetName())
+		client.xconManager.UpdateClientConnectionDstStateDown(clientConnection)
+	}
+}
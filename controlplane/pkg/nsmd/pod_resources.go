@@ -0,0 +1,45 @@
+package nsmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	podresources "k8s.io/kubernetes/pkg/kubelet/apis/podresources/v1alpha1"
+)
+
+const (
+	// NsmResourceName - resource workspaces are allocated to pods as by device plugin.
+	NsmResourceName = "networkservicemesh.io/socket"
+	// PodResourcesSocket - kubelet socket listing devices allocated to pods.
+	PodResourcesSocket = "/var/lib/kubelet/pod-resources/kubelet.sock"
+)
+
+// workspacePod - returns namespace/name of pod workspace is allocated to, workspace name is an id of device
+// allocated by device plugin, so kubelet knows the pod regardless of what the pod claims.
+func workspacePod(workspace string) (string, error) {
+	conn, err := tools.SocketOperationCheck(PodResourcesSocket)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	response, err := podresources.NewPodResourcesListerClient(conn).List(context.Background(), &podresources.ListPodResourcesRequest{})
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range response.GetPodResources() {
+		for _, container := range pod.GetContainers() {
+			for _, devices := range container.GetDevices() {
+				if devices.GetResourceName() != NsmResourceName {
+					continue
+				}
+				for _, id := range devices.GetDeviceIds() {
+					if id == workspace {
+						return pod.GetNamespace() + "/" + pod.GetName(), nil
+					}
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("no pod is allocated workspace %s", workspace)
+}
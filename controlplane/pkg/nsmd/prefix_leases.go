@@ -0,0 +1,103 @@
+package nsmd
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// prefixLeaseServer - passes prefix leases of local endpoints through to upstream registry, so replicas of endpoint
+// running on different nodes get disjoint sub-prefixes. Leases are bound to endpoints registered from workspace.
+type prefixLeaseServer struct {
+	model           model.Model
+	workspace       *Workspace
+	serviceRegistry serviceregistry.ServiceRegistry
+}
+
+// NewPrefixLeaseServer - creates server passing prefix leases of endpoints registered from workspace through to upstream registry.
+func NewPrefixLeaseServer(model model.Model, workspace *Workspace, serviceRegistry serviceregistry.ServiceRegistry) registry.PrefixLeaseRegistryServer {
+	return &prefixLeaseServer{
+		model:           model,
+		workspace:       workspace,
+		serviceRegistry: serviceRegistry,
+	}
+}
+
+func (s *prefixLeaseServer) LeasePrefixes(ctx context.Context, request *registry.PrefixLeaseRequest) (*registry.PrefixLease, error) {
+	logrus.Infof("Received LeasePrefixes request: %v", request)
+	owner, err := s.bindOwner(request.NetworkServiceName, request.Owner)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+	client, err := s.serviceRegistry.PrefixLeaseClient()
+	if err != nil {
+		err = fmt.Errorf("attempt to pass through from nsm to upstream registry failed with: %v", err)
+		logrus.Error(err)
+		return nil, err
+	}
+	boundRequest := *request
+	boundRequest.Owner = owner
+	lease, err := client.LeasePrefixes(ctx, &boundRequest)
+	if err != nil {
+		err = fmt.Errorf("attempt to pass through from nsm to upstream registry failed with: %v", err)
+		logrus.Error(err)
+		return nil, err
+	}
+	return lease, nil
+}
+
+func (s *prefixLeaseServer) ReleasePrefixes(ctx context.Context, lease *registry.PrefixLease) (*empty.Empty, error) {
+	logrus.Infof("Received ReleasePrefixes request: %v", lease)
+	owner, err := s.bindOwner(lease.NetworkServiceName, lease.Owner)
+	if err != nil {
+		logrus.Error(err)
+		return nil, err
+	}
+	client, err := s.serviceRegistry.PrefixLeaseClient()
+	if err != nil {
+		err = fmt.Errorf("attempt to pass through from nsm to upstream registry failed with: %v", err)
+		logrus.Error(err)
+		return nil, err
+	}
+	boundLease := *lease
+	boundLease.Owner = owner
+	if _, err := client.ReleasePrefixes(ctx, &boundLease); err != nil {
+		err = fmt.Errorf("attempt to pass through from nsm to upstream registry failed with: %v", err)
+		logrus.Error(err)
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// bindOwner - returns name of endpoint of network service registered from workspace, owner claimed by client selects
+// one of them if there are several. Clients could not lease or release prefixes on behalf of other endpoints.
+func (s *prefixLeaseServer) bindOwner(networkServiceName, owner string) (string, error) {
+	var endpoints []string
+	for _, endpoint := range s.model.GetNetworkServiceEndpoints(networkServiceName) {
+		nse := endpoint.GetNetworkserviceEndpoint()
+		if WorkSpaceRegistry().WorkspaceByEndpoint(nse) != s.workspace {
+			continue
+		}
+		if nse.GetEndpointName() == owner {
+			return owner, nil
+		}
+		endpoints = append(endpoints, nse.GetEndpointName())
+	}
+	switch len(endpoints) {
+	case 0:
+		return "", status.Errorf(codes.PermissionDenied, "no endpoint of network service %s is registered from workspace %s", networkServiceName, s.workspace.Name())
+	case 1:
+		return endpoints[0], nil
+	default:
+		return "", status.Errorf(codes.PermissionDenied, "owner %q is not one of endpoints %v of network service %s registered from workspace %s",
+			owner, endpoints, networkServiceName, s.workspace.Name())
+	}
+}
@@ -19,13 +19,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
-const (
-	healthcheckProbesPort = "0.0.0.0:5555"
-)
-
 var (
 	dpStatusOK  = true
 	nsmStatusOK = true
@@ -58,8 +55,9 @@ func liveness(w http.ResponseWriter, r *http.Request) {
 }
 
 func BeginHealthCheck() {
-	logrus.Debug("Starting NSMD liveness/readiness healthcheck")
+	logrus.Debug("Starting NSMD liveness/readiness healthcheck and metrics")
 	http.HandleFunc("/liveness", liveness)
 	http.HandleFunc("/readiness", readiness)
-	http.ListenAndServe(healthcheckProbesPort, nil)
+	http.Handle("/metrics", promhttp.Handler())
+	http.ListenAndServe(GetConfig().ProbesAddress, nil)
 }
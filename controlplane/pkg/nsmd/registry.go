@@ -17,9 +17,11 @@ package nsmd
 import (
 	"fmt"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"sort"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/sirupsen/logrus"
@@ -29,13 +31,15 @@ import (
 type registryServer struct {
 	model           model.Model
 	workspace       *Workspace
+	manager         nsm.NetworkServiceManager
 	serviceRegistry serviceregistry.ServiceRegistry
 }
 
-func NewRegistryServer(model model.Model, workspace *Workspace, serviceRegistry serviceregistry.ServiceRegistry) registry.NetworkServiceRegistryServer {
+func NewRegistryServer(model model.Model, workspace *Workspace, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry) registry.NetworkServiceRegistryServer {
 	return &registryServer{
 		model:           model,
 		workspace:       workspace,
+		manager:         manager,
 		serviceRegistry: serviceRegistry,
 	}
 }
@@ -57,8 +61,10 @@ func (es *registryServer) RegisterNSE(ctx context.Context, request *registry.NSE
 	//     from the NSE.  NSE's shouldn't specify NetworkServiceManager
 	// 2)  We are not specifying Name or LastSeen, the nsmd-k8s will fill those
 	//     in
+	// 3)  Remote mechanisms of our dataplanes are advertised, so peers select dataplanes compatible with them.
 	request.NetworkServiceManager = &registry.NetworkServiceManager{
-		Url: es.serviceRegistry.GetPublicAPI(),
+		Url:              es.serviceRegistry.GetPublicAPI(),
+		RemoteMechanisms: remoteMechanismTypes(es.model.GetAllDataplanes()),
 	}
 
 	registration, err := client.RegisterNSE(context.Background(), request)
@@ -102,6 +108,44 @@ func (es *registryServer) RemoveNSE(ctx context.Context, request *registry.Remov
 	return &empty.Empty{}, nil
 }
 
+// DrainNSE - marks endpoint as draining in upstream registry, so other NSMs stop to use it, and migrates
+// its local connections to other endpoints. Endpoint should be removed after it is drained.
+func (es *registryServer) DrainNSE(ctx context.Context, request *registry.DrainNSERequest) (*empty.Empty, error) {
+	logrus.Infof("Received Endpoint Drain request: %+v", request)
+	client, err := es.serviceRegistry.RegistryClient()
+	if err != nil {
+		err = fmt.Errorf("attempt to pass through from nsm to upstream registry failed with: %v", err)
+		logrus.Error(err)
+		return nil, err
+	}
+	_, err = client.DrainNSE(context.Background(), request)
+	if err != nil {
+		err = fmt.Errorf("attempt to pass through from nsm to upstream registry failed with: %v", err)
+		logrus.Error(err)
+		return nil, err
+	}
+	if err := es.manager.DrainEndpoint(ctx, request.EndpointName); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// remoteMechanismTypes - returns sorted names of remote mechanism types supported by dataplanes.
+func remoteMechanismTypes(dataplanes []*model.Dataplane) []string {
+	unique := map[string]bool{}
+	for _, dp := range dataplanes {
+		for _, m := range dp.RemoteMechanisms {
+			unique[m.GetType().String()] = true
+		}
+	}
+	result := []string{}
+	for mechanismType := range unique {
+		result = append(result, mechanismType)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func (es *registryServer) Close() {
 
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
@@ -14,15 +13,18 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
 	dataplaneapi "github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 const (
@@ -37,12 +39,7 @@ type apiRegistry struct {
 }
 
 func (*apiRegistry) NewPublicListener() (net.Listener, error) {
-	nsmdApiAddress := os.Getenv(NsmdApiAddressEnv)
-	if strings.TrimSpace(nsmdApiAddress) == "" {
-		nsmdApiAddress = NsmdApiAddressDefaults
-	}
-
-	return net.Listen("tcp", nsmdApiAddress)
+	return net.Listen("tcp", GetConfig().ApiAddress)
 }
 
 func (*apiRegistry) NewNSMServerListener() (net.Listener, error) {
@@ -63,6 +60,8 @@ type nsmdServiceRegistry struct {
 	stopRedial               bool
 	vniAllocator             vni.VniAllocator
 	registryAddress          string
+	domainResolver           interdomain.Resolver
+	securityProvider         *security.Provider
 }
 
 func (impl *nsmdServiceRegistry) NewWorkspaceProvider() serviceregistry.WorkspaceLocationProvider {
@@ -77,7 +76,7 @@ func (impl *nsmdServiceRegistry) RemoteNetworkServiceClient(nsm *registry.Networ
 
 	logrus.Infof("Remote Network Service %s is available at %s, attempting to connect...", nsm.GetName(), nsm.GetUrl())
 	tracer := opentracing.GlobalTracer()
-	conn, err := grpc.Dial(nsm.Url, grpc.WithInsecure(),
+	conn, err := grpc.Dial(nsm.Url, impl.securityProvider.DialOption(nsm.GetName()),
 		grpc.WithUnaryInterceptor(
 			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
 		grpc.WithStreamInterceptor(
@@ -107,6 +106,18 @@ func (impl *nsmdServiceRegistry) EndpointConnection(endpoint *registry.NSERegist
 	return client, nseConn, nil
 }
 
+func (impl *nsmdServiceRegistry) EndpointHealthClient(endpoint *registry.NSERegistration) (grpc_health_v1.HealthClient, *grpc.ClientConn, error) {
+	workspace := WorkSpaceRegistry().WorkspaceByEndpoint(endpoint.GetNetworkserviceEndpoint())
+	if workspace == nil {
+		return nil, nil, fmt.Errorf("cannot find workspace for endpoint %v", endpoint.GetNetworkserviceEndpoint().GetEndpointName())
+	}
+	nseConn, err := tools.SocketOperationCheck(workspace.NsmClientSocket())
+	if err != nil {
+		return nil, nil, err
+	}
+	return grpc_health_v1.NewHealthClient(nseConn), nseConn, nil
+}
+
 func (impl *nsmdServiceRegistry) WorkspaceName(endpoint *registry.NSERegistration) string {
 	// TODO - this is terribly dirty and needs to be fixed
 	workspace := WorkSpaceRegistry().WorkspaceByEndpoint(endpoint.GetNetworkserviceEndpoint())
@@ -154,7 +165,11 @@ func (impl *nsmdServiceRegistry) RegistryClient() (registry.NetworkServiceRegist
 }
 
 func (impl *nsmdServiceRegistry) GetPublicAPI() string {
-	return GetLocalIPAddress() + ":5001"
+	_, port, err := net.SplitHostPort(GetConfig().ApiAddress)
+	if err != nil {
+		_, port, _ = net.SplitHostPort(NsmdApiAddressDefaults)
+	}
+	return net.JoinHostPort(GetLocalIPAddress(), port)
 }
 
 func (impl *nsmdServiceRegistry) NetworkServiceDiscovery() (registry.NetworkServiceDiscoveryClient, error) {
@@ -170,6 +185,55 @@ func (impl *nsmdServiceRegistry) NetworkServiceDiscovery() (registry.NetworkServ
 	return nil, fmt.Errorf("Connection to Network Registry Server is not available")
 }
 
+func (impl *nsmdServiceRegistry) ClusterInfoClient() (registry.ClusterInfoClient, error) {
+	impl.RWMutex.Lock()
+	defer impl.RWMutex.Unlock()
+
+	logrus.Info("Requesting ClusterInfoClient...")
+
+	impl.initRegistryClient()
+	if impl.registryClientConnection != nil {
+		return registry.NewClusterInfoClient(impl.registryClientConnection), nil
+	}
+	return nil, fmt.Errorf("Connection to Network Registry Server is not available")
+}
+
+func (impl *nsmdServiceRegistry) PrefixLeaseClient() (registry.PrefixLeaseRegistryClient, error) {
+	impl.RWMutex.Lock()
+	defer impl.RWMutex.Unlock()
+
+	logrus.Info("Requesting PrefixLeaseClient...")
+
+	impl.initRegistryClient()
+	if impl.registryClientConnection != nil {
+		return registry.NewPrefixLeaseRegistryClient(impl.registryClientConnection), nil
+	}
+	return nil, fmt.Errorf("Connection to Network Registry Server is not available")
+}
+
+func (impl *nsmdServiceRegistry) SecurityProvider() *security.Provider {
+	return impl.securityProvider
+}
+
+func (impl *nsmdServiceRegistry) DomainResolver() interdomain.Resolver {
+	return impl.domainResolver
+}
+
+func (impl *nsmdServiceRegistry) DomainDiscovery(domain *interdomain.Domain) (registry.NetworkServiceDiscoveryClient, *grpc.ClientConn, error) {
+	logrus.Infof("Connecting to Network Service Registry of domain %s at %s...", domain.Name, domain.RegistryUrl)
+	tracer := opentracing.GlobalTracer()
+	conn, err := grpc.Dial(domain.RegistryUrl, impl.securityProvider.DialOption(""),
+		grpc.WithUnaryInterceptor(
+			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
+		grpc.WithStreamInterceptor(
+			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+	if err != nil {
+		logrus.Errorf("Failed to dial Network Service Registry of domain %s at %s: %s", domain.Name, domain.RegistryUrl, err)
+		return nil, nil, err
+	}
+	return registry.NewNetworkServiceDiscoveryClient(conn), conn, nil
+}
+
 func (impl *nsmdServiceRegistry) initRegistryClient() {
 	var err error
 	if impl.registryClientConnection != nil && impl.registryClientConnection.GetState() == connectivity.Ready {
@@ -180,7 +244,7 @@ func (impl *nsmdServiceRegistry) initRegistryClient() {
 		tools.WaitForPortAvailable(context.Background(), "tcp", impl.registryAddress, 1*time.Second)
 		logrus.Println("Registry port now available, attempting to connect...")
 		tracer := opentracing.GlobalTracer()
-		conn, err := grpc.Dial(impl.registryAddress, grpc.WithInsecure(),
+		conn, err := grpc.Dial(impl.registryAddress, impl.securityProvider.DialOption(""),
 			grpc.WithUnaryInterceptor(
 				otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
 			grpc.WithStreamInterceptor(
@@ -210,32 +274,43 @@ func (impl *nsmdServiceRegistry) Stop() {
 }
 
 func NewServiceRegistry() serviceregistry.ServiceRegistry {
-	registryAddress := os.Getenv("NSM_REGISTRY_ADDRESS")
-	registryAddress = strings.TrimSpace(registryAddress)
-	if registryAddress == "" {
-		registryAddress = "127.0.0.1:5000"
-	}
+	return NewServiceRegistryAt(getRegistryAddress())
+}
 
-	return NewServiceRegistryAt(registryAddress)
+// NewServiceRegistryWithVniAllocator - creates a service registry using passed VNI allocator.
+func NewServiceRegistryWithVniAllocator(vniAllocator vni.VniAllocator) serviceregistry.ServiceRegistry {
+	return &nsmdServiceRegistry{
+		stopRedial:       true,
+		vniAllocator:     vniAllocator,
+		registryAddress:  getRegistryAddress(),
+		domainResolver:   GetDomainResolver(),
+		securityProvider: GetSecurityProvider(),
+	}
 }
 
 func NewServiceRegistryAt(nsmAddress string) serviceregistry.ServiceRegistry {
 	return &nsmdServiceRegistry{
-		stopRedial:      true,
-		vniAllocator:    vni.NewVniAllocator(),
-		registryAddress: nsmAddress,
+		stopRedial:       true,
+		vniAllocator:     vni.NewVniAllocator(),
+		registryAddress:  nsmAddress,
+		domainResolver:   GetDomainResolver(),
+		securityProvider: GetSecurityProvider(),
 	}
 }
 
+func getRegistryAddress() string {
+	return GetConfig().RegistryAddress
+}
+
 func (impl *nsmdServiceRegistry) WaitForDataplaneAvailable(model model.Model, timeout time.Duration) error {
 	logrus.Info("Waiting for dataplane available...")
 	st := time.Now()
 	for ; true; <-time.After(100 * time.Millisecond) {
-		if dp, _ := model.SelectDataplane(); dp != nil {
+		if dp, _ := model.SelectDataplane(nil); dp != nil {
 			break
 		}
 		if time.Since(st) > timeout {
-			break
+			return fmt.Errorf("no dataplane is registered for %v", timeout)
 		}
 	}
 	return nil
@@ -245,6 +320,10 @@ func (impl *nsmdServiceRegistry) VniAllocator() vni.VniAllocator {
 	return impl.vniAllocator
 }
 
+func (impl *nsmdServiceRegistry) WorkspacePod(workspace string) (string, error) {
+	return workspacePod(workspace)
+}
+
 type defaultWorkspaceProvider struct {
 	hostBaseDir     string
 	nsmBaseDir      string
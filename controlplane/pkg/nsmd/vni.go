@@ -0,0 +1,50 @@
+package nsmd
+
+import (
+	"os"
+	"path"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
+)
+
+// NewVniAllocator - creates a VNI allocator keeping its state inside NSM base dir, VNI ranges per tenant
+// are configured with VNI_RANGES environment variable, VNIs of connections known by model are never allocated.
+func NewVniAllocator(model model.Model) (vni.VniAllocator, error) {
+	ranges, err := vni.ParseVniRanges(os.Getenv(VniRangesEnv))
+	if err != nil {
+		return nil, err
+	}
+	return vni.NewVniAllocatorWithConfig(&vni.VniConfig{
+		Ranges:    ranges,
+		StateFile: path.Join(GetNsmBaseDir(), VniStateFile),
+		Usage:     newModelVniUsage(model),
+	})
+}
+
+// newModelVniUsage - checks if VNI is used by a tunnel of any client connection known by model.
+func newModelVniUsage(model model.Model) vni.VniUsage {
+	return func(localIp string, remoteIp string, value uint32) bool {
+		for _, cc := range model.GetAllClientConnections() {
+			for _, m := range []*connection.Mechanism{
+				cc.Xcon.GetRemoteSource().GetMechanism(),
+				cc.Xcon.GetRemoteDestination().GetMechanism(),
+			} {
+				if m == nil || m.GetType() != connection.MechanismType_VXLAN || !isTunnelBetween(m, localIp, remoteIp) {
+					continue
+				}
+				if used, err := m.VNI(); err == nil && used == value {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+func isTunnelBetween(m *connection.Mechanism, localIp string, remoteIp string) bool {
+	srcIp := m.GetParameters()[connection.VXLANSrcIP]
+	dstIp := m.GetParameters()[connection.VXLANDstIP]
+	return (srcIp == localIp && dstIp == remoteIp) || (srcIp == remoteIp && dstIp == localIp)
+}
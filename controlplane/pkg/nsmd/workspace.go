@@ -27,6 +27,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	. "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/policy"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	opentracing "github.com/opentracing/opentracing-go"
@@ -46,6 +47,7 @@ type Workspace struct {
 	name                    string
 	listener                net.Listener
 	registryServer          registry.NetworkServiceRegistryServer
+	prefixLeaseServer       registry.PrefixLeaseRegistryServer
 	networkServiceServer    networkservice.NetworkServiceServer
 	monitorConnectionServer *local_connection_monitor.LocalConnectionMonitor
 	grpcServer              *grpc.Server
@@ -54,7 +56,7 @@ type Workspace struct {
 	locationProvider serviceregistry.WorkspaceLocationProvider
 }
 
-func NewWorkSpace(model Model, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, name string) (*Workspace, error) {
+func NewWorkSpace(model Model, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, name string, policyEngine *policy.Engine) (*Workspace, error) {
 	logrus.Infof("Creating new workspace: %s", name)
 	w := &Workspace{
 		locationProvider: serviceRegistry.NewWorkspaceProvider(),
@@ -77,13 +79,16 @@ func NewWorkSpace(model Model, manager nsm.NetworkServiceManager, serviceRegistr
 	}
 	w.listener = listener
 	logrus.Infof("Creating new NetworkServiceRegistryServer")
-	w.registryServer = NewRegistryServer(model, w, serviceRegistry)
+	w.registryServer = NewRegistryServer(model, w, manager, serviceRegistry)
+
+	logrus.Infof("Creating new PrefixLeaseRegistryServer")
+	w.prefixLeaseServer = NewPrefixLeaseServer(model, w, serviceRegistry)
 
 	logrus.Infof("Creating new MonitorConnectionServer")
 	w.monitorConnectionServer = local_connection_monitor.NewLocalConnectionMonitor()
 
 	logrus.Infof("Creating new NetworkServiceServer")
-	w.networkServiceServer = NewNetworkServiceServer(model, w, manager, serviceRegistry)
+	w.networkServiceServer = NewNetworkServiceServer(model, w, manager, serviceRegistry, policyEngine)
 
 	logrus.Infof("Creating new GRPC Server")
 	tracer := opentracing.GlobalTracer()
@@ -95,6 +100,8 @@ func NewWorkSpace(model Model, manager nsm.NetworkServiceManager, serviceRegistr
 
 	logrus.Infof("Registering NetworkServiceRegistryServer with grpcServer")
 	registry.RegisterNetworkServiceRegistryServer(w.grpcServer, w.registryServer)
+	logrus.Infof("Registering PrefixLeaseRegistryServer with grpcServer")
+	registry.RegisterPrefixLeaseRegistryServer(w.grpcServer, w.prefixLeaseServer)
 	logrus.Infof("Registering NetworkServiceServer with grpcServer")
 	networkservice.RegisterNetworkServiceServer(w.grpcServer, w.networkServiceServer)
 	logrus.Infof("Registering MonitorConnectionServer with grpcServer")
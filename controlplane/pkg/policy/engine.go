@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connection_journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Engine - authorizes Network Service requests with policy, denials are recorded to connection journal.
+// Nil engine allows all requests.
+type Engine struct {
+	sync.RWMutex
+	policy  *Policy
+	journal *connection_journal.Journal
+}
+
+// NewEngine - creates engine authorizing requests with policy.
+func NewEngine(policy *Policy, journal *connection_journal.Journal) *Engine {
+	return &Engine{
+		policy:  policy,
+		journal: journal,
+	}
+}
+
+// SetPolicy - replaces policy requests are authorized with.
+func (e *Engine) SetPolicy(policy *Policy) {
+	e.Lock()
+	defer e.Unlock()
+	e.policy = policy
+}
+
+// UsesPods - checks if policy has pod scoped rules, so pod of request should be resolved.
+func (e *Engine) UsesPods() bool {
+	if e == nil {
+		return false
+	}
+	e.RLock()
+	defer e.RUnlock()
+	return e.policy != nil && e.policy.UsesPods()
+}
+
+// Authorize - returns PermissionDenied status if request is denied by policy.
+func (e *Engine) Authorize(request *Request) error {
+	if e == nil {
+		return nil
+	}
+	e.RLock()
+	policy := e.policy
+	e.RUnlock()
+	if policy == nil {
+		return nil
+	}
+
+	action, rule := policy.Evaluate(request)
+	if action == ActionAllow {
+		return nil
+	}
+	reason := "denied by default policy"
+	if rule != nil {
+		reason = fmt.Sprintf("denied by policy rule %s", rule.Name)
+	}
+	reason = fmt.Sprintf("request of %s from workspace %q, pod %s, source NSM %s is %s",
+		request.NetworkService, request.Workspace, request.Pod, request.SourceNsm, reason)
+	logrus.Warnf("Policy: %s", reason)
+	e.journal.Record(request.ConnectionId, journal.EventRequestDenied, reason)
+	metrics.PolicyDenialsTotal.WithLabelValues(request.NetworkService).Inc()
+	return status.Error(codes.PermissionDenied, reason)
+}
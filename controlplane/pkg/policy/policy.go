@@ -0,0 +1,161 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// ActionAllow - request matched by rule is allowed.
+	ActionAllow = "allow"
+	// ActionDeny - request matched by rule is denied.
+	ActionDeny = "deny"
+)
+
+// Policy - ordered rules to authorize Network Service requests, first matching rule decides, Default action
+// is applied if no rule matches.
+type Policy struct {
+	Default string  `json:"default,omitempty"`
+	Rules   []*Rule `json:"rules,omitempty"`
+}
+
+// Rule - matches requests by all of its selectors, empty selector matches any request. Selectors support glob
+// patterns, i.e. "secure-*".
+type Rule struct {
+	Name           string            `json:"name,omitempty"`
+	Action         string            `json:"action"`
+	NetworkService string            `json:"networkService,omitempty"`
+	Workspaces     []string          `json:"workspaces,omitempty"`
+	Pods           []string          `json:"pods,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	SourceNsms     []string          `json:"sourceNsms,omitempty"`
+}
+
+// Request - Network Service request to authorize.
+type Request struct {
+	ConnectionId   string
+	NetworkService string
+	// Workspace - workspace request is received from, empty for requests of remote NSMs.
+	Workspace string
+	// Pod - identity of pod request is received from as namespace/name, it is resolved by NSM from workspace, so
+	// it is not claimed by client. Empty if pod is unknown, not required by policy or for requests of remote NSMs.
+	Pod    string
+	Labels map[string]string
+	// SourceNsm - name of NSM request is originated from.
+	SourceNsm string
+}
+
+// LoadPolicy - reads policy from YAML or JSON file.
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	policy := &Policy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %v", file, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %v", file, err)
+	}
+	return policy, nil
+}
+
+// Validate - checks actions and patterns of policy are valid.
+func (p *Policy) Validate() error {
+	if p.Default != "" && p.Default != ActionAllow && p.Default != ActionDeny {
+		return fmt.Errorf("unknown default action %s", p.Default)
+	}
+	for i, rule := range p.Rules {
+		if rule.Action != ActionAllow && rule.Action != ActionDeny {
+			return fmt.Errorf("rule %d %s: unknown action %s", i, rule.Name, rule.Action)
+		}
+		patterns := append([]string{rule.NetworkService}, rule.Workspaces...)
+		patterns = append(patterns, rule.Pods...)
+		patterns = append(patterns, rule.SourceNsms...)
+		for _, value := range rule.Labels {
+			patterns = append(patterns, value)
+		}
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rule %d %s: invalid pattern %s: %v", i, rule.Name, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// UsesPods - checks if any rule selects requests by pod, so pod of request should be resolved.
+func (p *Policy) UsesPods() bool {
+	for _, rule := range p.Rules {
+		if len(rule.Pods) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate - returns action for request and the rule matched, rule is nil if default action is applied.
+// Pod scoped rule matching other selectors of request received from workspace with unknown pod denies request,
+// since it is not known if the rule should be applied.
+func (p *Policy) Evaluate(request *Request) (string, *Rule) {
+	for _, rule := range p.Rules {
+		if len(rule.Pods) > 0 && request.Workspace != "" && request.Pod == "" {
+			if rule.matches(request, false) {
+				return ActionDeny, rule
+			}
+			continue
+		}
+		if rule.Matches(request) {
+			return rule.Action, rule
+		}
+	}
+	if p.Default == "" {
+		return ActionAllow, nil
+	}
+	return p.Default, nil
+}
+
+// Matches - checks request is matched by all selectors of rule.
+func (r *Rule) Matches(request *Request) bool {
+	return r.matches(request, true)
+}
+
+func (r *Rule) matches(request *Request, matchPods bool) bool {
+	if r.NetworkService != "" && !match(r.NetworkService, request.NetworkService) {
+		return false
+	}
+	if len(r.Workspaces) > 0 && !matchAny(r.Workspaces, request.Workspace) {
+		return false
+	}
+	if matchPods && len(r.Pods) > 0 && !matchAny(r.Pods, request.Pod) {
+		return false
+	}
+	if len(r.SourceNsms) > 0 && !matchAny(r.SourceNsms, request.SourceNsm) {
+		return false
+	}
+	for key, pattern := range r.Labels {
+		value, ok := request.Labels[key]
+		if !ok || !match(pattern, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if match(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func match(pattern, value string) bool {
+	matched, _ := path.Match(pattern, value)
+	return matched
+}
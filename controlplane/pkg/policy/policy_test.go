@@ -0,0 +1,132 @@
+package policy
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func writePolicy(content string) string {
+	file, err := ioutil.TempFile("", "nsm_policy")
+	Expect(err).To(BeNil())
+	defer file.Close()
+	_, err = file.WriteString(content)
+	Expect(err).To(BeNil())
+	return file.Name()
+}
+
+func TestLoadPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	file := writePolicy(`
+default: deny
+rules:
+- name: secure-from-frontend
+  action: allow
+  networkService: secure-*
+  pods: ["frontend/*"]
+  labels:
+    app: web
+- name: remote-golden
+  action: allow
+  networkService: golden_network
+  sourceNsms: ["node-1", "node-2"]
+- name: no-workspace-1
+  action: deny
+  workspaces: ["nsm-1"]
+- action: allow
+  networkService: golden_network
+`)
+	defer os.Remove(file)
+	policy, err := LoadPolicy(file)
+	Expect(err).To(BeNil())
+	Expect(len(policy.Rules)).To(Equal(4))
+
+	action, rule := policy.Evaluate(&Request{
+		NetworkService: "secure-intranet",
+		Workspace:      "nsm-1",
+		Pod:            "frontend/web-1",
+		Labels:         map[string]string{"app": "web"},
+	})
+	Expect(action).To(Equal(ActionAllow))
+	Expect(rule.Name).To(Equal("secure-from-frontend"))
+
+	// Label does not match, pod is not allowed by other rules.
+	action, rule = policy.Evaluate(&Request{
+		NetworkService: "secure-intranet",
+		Workspace:      "nsm-2",
+		Pod:            "frontend/web-1",
+		Labels:         map[string]string{"app": "db"},
+	})
+	Expect(action).To(Equal(ActionDeny))
+	Expect(rule).To(BeNil())
+
+	_, rule = policy.Evaluate(&Request{NetworkService: "golden_network", SourceNsm: "node-2"})
+	Expect(rule.Name).To(Equal("remote-golden"))
+
+	action, rule = policy.Evaluate(&Request{NetworkService: "golden_network", Workspace: "nsm-1"})
+	Expect(action).To(Equal(ActionDeny))
+	Expect(rule.Name).To(Equal("no-workspace-1"))
+
+	action, _ = policy.Evaluate(&Request{NetworkService: "golden_network", Workspace: "nsm-2"})
+	Expect(action).To(Equal(ActionAllow))
+	Expect(policy.UsesPods()).To(BeTrue())
+}
+
+func TestUnknownPodIsDenied(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := &Policy{
+		Rules: []*Rule{
+			{Name: "no-backend", Action: ActionDeny, NetworkService: "secure-*", Pods: []string{"backend/*"}},
+			{Name: "frontend", Action: ActionAllow, Pods: []string{"frontend/*"}},
+		},
+	}
+
+	// Pod scoped rules deny requests from workspace with unknown pod.
+	action, rule := policy.Evaluate(&Request{NetworkService: "secure-intranet", Workspace: "nsm-1"})
+	Expect(action).To(Equal(ActionDeny))
+	Expect(rule.Name).To(Equal("no-backend"))
+
+	action, rule = policy.Evaluate(&Request{NetworkService: "golden_network", Workspace: "nsm-1"})
+	Expect(action).To(Equal(ActionDeny))
+	Expect(rule.Name).To(Equal("frontend"))
+
+	// Requests of remote NSMs have no pod, pod rules don't match them.
+	action, rule = policy.Evaluate(&Request{NetworkService: "secure-intranet", SourceNsm: "node-1"})
+	Expect(action).To(Equal(ActionAllow))
+	Expect(rule).To(BeNil())
+
+	Expect((&Policy{Rules: []*Rule{{Action: ActionDeny}}}).UsesPods()).To(BeFalse())
+}
+
+func TestDefaultPolicyAllows(t *testing.T) {
+	RegisterTestingT(t)
+
+	action, rule := (&Policy{}).Evaluate(&Request{NetworkService: "golden_network"})
+	Expect(action).To(Equal(ActionAllow))
+	Expect(rule).To(BeNil())
+
+	// Nil engine and engine without policy allow everything.
+	var engine *Engine
+	Expect(engine.Authorize(&Request{NetworkService: "golden_network"})).To(BeNil())
+	Expect(NewEngine(nil, nil).Authorize(&Request{NetworkService: "golden_network"})).To(BeNil())
+}
+
+func TestInvalidPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	for _, content := range []string{
+		"default: reject",
+		"rules:\n- action: drop",
+		"rules:\n- action: deny\n  networkService: \"[\"",
+		"rules: 1",
+	} {
+		file := writePolicy(content)
+		_, err := LoadPolicy(file)
+		Expect(err).NotTo(BeNil())
+		os.Remove(file)
+	}
+}
@@ -0,0 +1,137 @@
+package prefix_pool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"reflect"
+	"sort"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/sirupsen/logrus"
+)
+
+// filePrefixPool - prefix pool persisting allocations into JSON file, so endpoint keeps addresses of its connections
+// across restarts.
+type filePrefixPool struct {
+	*prefixPool
+	file string
+}
+
+type prefixPoolState struct {
+	BasePrefixes []string                    `json:"basePrefixes"`
+	Prefixes     []string                    `json:"prefixes"`
+	Connections  map[string]*connectionState `json:"connections"`
+}
+
+type connectionState struct {
+	IpNets   []string `json:"ipNets"`
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+// NewFilePrefixPool - creates prefix pool persisting allocations into file. Allocations stored in file are restored,
+// unless pool was created with other prefixes before.
+func NewFilePrefixPool(file string, prefixes ...string) (PrefixPool, error) {
+	pool, err := NewPrefixPool(prefixes...)
+	if err != nil {
+		return nil, err
+	}
+	impl := &filePrefixPool{
+		prefixPool: pool.(*prefixPool),
+		file:       file,
+	}
+	if err := impl.load(); err != nil {
+		return nil, err
+	}
+	return impl, nil
+}
+
+func (impl *filePrefixPool) Extract(connectionId string, family connectioncontext.IpFamily_Family, requests ...*connectioncontext.ExtraPrefixRequest) (*net.IPNet, *net.IPNet, []string, error) {
+	srcIP, dstIP, requested, err := impl.prefixPool.Extract(connectionId, family, requests...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	impl.save()
+	return srcIP, dstIP, requested, nil
+}
+
+func (impl *filePrefixPool) Release(connectionId string) error {
+	err := impl.prefixPool.Release(connectionId)
+	impl.save()
+	return err
+}
+
+func (impl *filePrefixPool) save() {
+	impl.RLock()
+	state := &prefixPoolState{
+		BasePrefixes: impl.basePrefixes,
+		Prefixes:     impl.prefixes,
+		Connections:  map[string]*connectionState{},
+	}
+	for id, record := range impl.connections {
+		connection := &connectionState{Prefixes: record.prefixes}
+		for _, ipNet := range record.ipNets {
+			connection.IpNets = append(connection.IpNets, ipNet.String())
+		}
+		state.Connections[id] = connection
+	}
+	data, err := json.Marshal(state)
+	impl.RUnlock()
+	if err != nil {
+		logrus.Errorf("Failed to persist prefix pool: %v", err)
+		return
+	}
+	// Write into temporary file and rename, so partially written state will never be loaded.
+	tmpFile := impl.file + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, 0600); err != nil {
+		logrus.Errorf("Failed to persist prefix pool: %v", err)
+		return
+	}
+	if err := os.Rename(tmpFile, impl.file); err != nil {
+		logrus.Errorf("Failed to persist prefix pool: %v", err)
+	}
+}
+
+func (impl *filePrefixPool) load() error {
+	data, err := ioutil.ReadFile(impl.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	state := &prefixPoolState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return fmt.Errorf("failed to parse prefix pool %s: %v", impl.file, err)
+	}
+	if !samePrefixes(state.BasePrefixes, impl.basePrefixes) {
+		logrus.Warnf("Prefix pool %s was created with prefixes %v, allocations are dropped", impl.file, state.BasePrefixes)
+		return nil
+	}
+	connections := map[string]*connectionRecord{}
+	for id, connection := range state.Connections {
+		record := &connectionRecord{prefixes: connection.Prefixes}
+		for _, value := range connection.IpNets {
+			_, ipNet, err := net.ParseCIDR(value)
+			if err != nil {
+				return fmt.Errorf("failed to parse prefix pool %s: %v", impl.file, err)
+			}
+			record.ipNets = append(record.ipNets, ipNet)
+		}
+		connections[id] = record
+	}
+	impl.prefixes = state.Prefixes
+	impl.connections = connections
+	logrus.Infof("Prefix pool %s is restored with %d connections", impl.file, len(connections))
+	return nil
+}
+
+func samePrefixes(a []string, b []string) bool {
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,70 @@
+package prefix_pool
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	. "github.com/onsi/gomega"
+)
+
+func TestFilePrefixPoolRestore(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "prefix_pool")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "ipam.json")
+
+	pool, err := NewFilePrefixPool(file, "10.20.0.0/24", "fd00:20::/64")
+	Expect(err).To(BeNil())
+	srcIP, _, _, err := pool.Extract("c1", connectioncontext.IpFamily_IPV4)
+	Expect(err).To(BeNil())
+	Expect(srcIP.String()).To(Equal("10.20.0.1/30"))
+	_, _, _, err = pool.Extract("c1", connectioncontext.IpFamily_IPV6)
+	Expect(err).To(BeNil())
+	_, _, _, err = pool.Extract("c2", connectioncontext.IpFamily_IPV4)
+	Expect(err).To(BeNil())
+	Expect(pool.Release("c2")).To(BeNil())
+
+	// Restarted endpoint keeps allocations and does not hand out addresses of existing connections.
+	restored, err := NewFilePrefixPool(file, "10.20.0.0/24", "fd00:20::/64")
+	Expect(err).To(BeNil())
+	ipNets, _, err := restored.GetConnectionInformation("c1")
+	Expect(err).To(BeNil())
+	Expect(ipNets).To(Equal("10.20.0.0/30,fd00:20::/126"))
+	_, _, err = restored.GetConnectionInformation("c2")
+	Expect(err).NotTo(BeNil())
+	srcIP, _, _, err = restored.Extract("c3", connectioncontext.IpFamily_IPV4)
+	Expect(err).To(BeNil())
+	Expect(srcIP.String()).To(Equal("10.20.0.5/30"))
+
+	Expect(restored.Release("c1")).To(BeNil())
+	Expect(restored.Release("c3")).To(BeNil())
+	restored, err = NewFilePrefixPool(file, "10.20.0.0/24", "fd00:20::/64")
+	Expect(err).To(BeNil())
+	Expect(restored.GetPrefixes()).To(ConsistOf("10.20.0.0/24", "fd00:20::/64"))
+}
+
+func TestFilePrefixPoolOtherPrefixes(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "prefix_pool")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "ipam.json")
+
+	pool, err := NewFilePrefixPool(file, "10.20.0.0/24")
+	Expect(err).To(BeNil())
+	_, _, _, err = pool.Extract("c1", connectioncontext.IpFamily_IPV4)
+	Expect(err).To(BeNil())
+
+	// Allocations from other prefixes are dropped.
+	pool, err = NewFilePrefixPool(file, "10.30.0.0/24")
+	Expect(err).To(BeNil())
+	_, _, err = pool.GetConnectionInformation("c1")
+	Expect(err).NotTo(BeNil())
+	Expect(pool.GetPrefixes()).To(Equal([]string{"10.30.0.0/24"}))
+}
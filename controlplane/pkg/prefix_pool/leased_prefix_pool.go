@@ -0,0 +1,202 @@
+package prefix_pool
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultIPv4LeasePrefixLen - length of IPv4 sub-prefix leased by replica, it fits 64 connections.
+	DefaultIPv4LeasePrefixLen = 24
+	// DefaultIPv6LeasePrefixLen - length of IPv6 sub-prefix leased by replica, it fits 16384 connections.
+	DefaultIPv6LeasePrefixLen = 112
+
+	minRenewInterval = time.Second
+)
+
+// LeasedPrefixPool - prefix pool allocating addresses from sub-prefixes leased through Network Service Registry.
+// Registry leases disjoint sub-prefixes to different owners, so replicas of endpoint providing the same network
+// service never allocate overlapping addresses. Lease is renewed until pool is closed, lease of replica gone away
+// expires and its sub-prefixes could be leased to other replicas. Addresses are not allocated while lease is expired,
+// if other sub-prefixes are leased on renewal, addresses are allocated from them.
+type LeasedPrefixPool struct {
+	sync.RWMutex
+	pool PrefixPool
+	// connections - pools addresses of connections are allocated from, pool of expired lease is kept until its
+	// connections are released.
+	connections map[string]PrefixPool
+	newPool func(prefixes ...string) (PrefixPool, error)
+	client  registry.PrefixLeaseRegistryClient
+	lease   *registry.PrefixLease
+	expires time.Time
+	cancel  context.CancelFunc
+}
+
+// NewLeasedPrefixPool - leases sub-prefixes of prefixes for owner and creates pool of them by newPool. NSM binds lease
+// to endpoint registered through it, owner selects one of endpoints if several of them are registered.
+// Default lengths of sub-prefixes are used if ipv4PrefixLen or ipv6PrefixLen is zero.
+func NewLeasedPrefixPool(client registry.PrefixLeaseRegistryClient, networkService string, owner string,
+	ipv4PrefixLen, ipv6PrefixLen uint32, newPool func(prefixes ...string) (PrefixPool, error), prefixes ...string) (*LeasedPrefixPool, error) {
+	if ipv4PrefixLen == 0 {
+		ipv4PrefixLen = DefaultIPv4LeasePrefixLen
+	}
+	if ipv6PrefixLen == 0 {
+		ipv6PrefixLen = DefaultIPv6LeasePrefixLen
+	}
+	request := &registry.PrefixLeaseRequest{
+		NetworkServiceName: networkService,
+		Owner:              owner,
+		Prefixes:           prefixes,
+		Ipv4PrefixLen:      ipv4PrefixLen,
+		Ipv6PrefixLen:      ipv6PrefixLen,
+	}
+	lease, err := client.LeasePrefixes(context.Background(), request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lease prefixes of %v: %v", prefixes, err)
+	}
+	expires, err := ptypes.Timestamp(lease.Expires)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lease of prefixes %v: %v", lease.Prefixes, err)
+	}
+	logrus.Infof("Prefixes %v of network service %s are leased till %v", lease.Prefixes, networkService, expires)
+
+	pool, err := newPool(lease.Prefixes...)
+	if err != nil {
+		_, _ = client.ReleasePrefixes(context.Background(), lease)
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	impl := &LeasedPrefixPool{
+		pool:        pool,
+		connections: map[string]PrefixPool{},
+		newPool:     newPool,
+		client:      client,
+		lease:       lease,
+		expires:     expires,
+		cancel:      cancel,
+	}
+	go impl.renew(ctx, request)
+	return impl, nil
+}
+
+// Extract - allocates addresses from leased sub-prefixes, fails if lease is expired.
+func (impl *LeasedPrefixPool) Extract(connectionId string, family connectioncontext.IpFamily_Family, requests ...*connectioncontext.ExtraPrefixRequest) (*net.IPNet, *net.IPNet, []string, error) {
+	impl.Lock()
+	defer impl.Unlock()
+	if time.Now().After(impl.expires) {
+		return nil, nil, nil, fmt.Errorf("lease of prefixes %v is expired at %v, addresses could overlap with other replicas", impl.lease.Prefixes, impl.expires)
+	}
+	srcIP, dstIP, requested, err := impl.pool.Extract(connectionId, family, requests...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	impl.connections[connectionId] = impl.pool
+	return srcIP, dstIP, requested, nil
+}
+
+// Release - releases addresses of connection to pool they are allocated from, it could be pool of expired lease.
+func (impl *LeasedPrefixPool) Release(connectionId string) error {
+	impl.Lock()
+	defer impl.Unlock()
+	pool := impl.connectionPool(connectionId)
+	delete(impl.connections, connectionId)
+	return pool.Release(connectionId)
+}
+
+func (impl *LeasedPrefixPool) GetConnectionInformation(connectionId string) (string, []string, error) {
+	impl.RLock()
+	defer impl.RUnlock()
+	return impl.connectionPool(connectionId).GetConnectionInformation(connectionId)
+}
+
+func (impl *LeasedPrefixPool) GetPrefixes() []string {
+	impl.RLock()
+	defer impl.RUnlock()
+	return impl.pool.GetPrefixes()
+}
+
+func (impl *LeasedPrefixPool) Families() []connectioncontext.IpFamily_Family {
+	impl.RLock()
+	defer impl.RUnlock()
+	return impl.pool.Families()
+}
+
+// Close - stops renewing lease and releases leased sub-prefixes.
+func (impl *LeasedPrefixPool) Close() error {
+	impl.cancel()
+	impl.RLock()
+	lease := impl.lease
+	impl.RUnlock()
+	_, err := impl.client.ReleasePrefixes(context.Background(), lease)
+	return err
+}
+
+func (impl *LeasedPrefixPool) renew(ctx context.Context, request *registry.PrefixLeaseRequest) {
+	for {
+		// Lease is renewed when half of its duration is left, failed renewal is retried the same way.
+		impl.RLock()
+		interval := time.Until(impl.expires) / 2
+		impl.RUnlock()
+		if interval < minRenewInterval {
+			interval = minRenewInterval
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+		lease, err := impl.client.LeasePrefixes(ctx, request)
+		if err != nil {
+			logrus.Errorf("Failed to renew lease of prefixes %v: %v", impl.getLease().Prefixes, err)
+			continue
+		}
+		if err := impl.update(lease); err != nil {
+			logrus.Errorf("Lease of prefixes %v is not renewed: %v", lease.Prefixes, err)
+		}
+	}
+}
+
+// update - applies renewed lease, pool is replaced if other sub-prefixes are leased.
+func (impl *LeasedPrefixPool) update(lease *registry.PrefixLease) error {
+	expires, err := ptypes.Timestamp(lease.Expires)
+	if err != nil {
+		return err
+	}
+	impl.Lock()
+	defer impl.Unlock()
+	if !samePrefixes(lease.Prefixes, impl.lease.Prefixes) {
+		pool, err := impl.newPool(lease.Prefixes...)
+		if err != nil {
+			return err
+		}
+		logrus.Warnf("Lease of prefixes %v is expired and prefixes %v are leased instead, existing connections keep addresses of expired lease",
+			impl.lease.Prefixes, lease.Prefixes)
+		impl.pool = pool
+	}
+	impl.lease = lease
+	impl.expires = expires
+	return nil
+}
+
+// connectionPool - returns pool addresses of connection are allocated from, connections restored by pool itself are
+// allocated from current one.
+func (impl *LeasedPrefixPool) connectionPool(connectionId string) PrefixPool {
+	if pool, ok := impl.connections[connectionId]; ok {
+		return pool
+	}
+	return impl.pool
+}
+
+func (impl *LeasedPrefixPool) getLease() *registry.PrefixLease {
+	impl.RLock()
+	defer impl.RUnlock()
+	return impl.lease
+}
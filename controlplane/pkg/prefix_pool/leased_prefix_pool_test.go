@@ -0,0 +1,178 @@
+package prefix_pool
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+// testPrefixLeases - leases every owner the next sub-prefix of requested length, leases expire in a second.
+type testPrefixLeases struct {
+	sync.Mutex
+	leases   map[string][]string
+	free     []string
+	renewals int
+	released []string
+	// unavailable - leases are not renewed, registry respond with error.
+	unavailable bool
+}
+
+func (c *testPrefixLeases) LeasePrefixes(ctx context.Context, request *registry.PrefixLeaseRequest, opts ...grpc.CallOption) (*registry.PrefixLease, error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.unavailable {
+		return nil, fmt.Errorf("registry is not available")
+	}
+	if c.free == nil {
+		c.free = request.Prefixes
+	}
+	prefixes, ok := c.leases[request.Owner]
+	if ok {
+		c.renewals++
+	} else {
+		var err error
+		prefixes, c.free, err = ExtractPrefixes(c.free, &connectioncontext.ExtraPrefixRequest{
+			AddrFamily:      &connectioncontext.IpFamily{Family: connectioncontext.IpFamily_IPV4},
+			PrefixLen:       request.Ipv4PrefixLen,
+			RequiredNumber:  1,
+			RequestedNumber: 1,
+		})
+		if err != nil {
+			return nil, err
+		}
+		c.leases[request.Owner] = prefixes
+	}
+	expires, _ := ptypes.TimestampProto(time.Now().Add(time.Second))
+	return &registry.PrefixLease{
+		NetworkServiceName: request.NetworkServiceName,
+		Owner:              request.Owner,
+		Prefixes:           prefixes,
+		Expires:            expires,
+	}, nil
+}
+
+func (c *testPrefixLeases) ReleasePrefixes(ctx context.Context, lease *registry.PrefixLease, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.leases, lease.Owner)
+	c.released = append(c.released, lease.Owner)
+	return &empty.Empty{}, nil
+}
+
+// expire - makes registry forget lease of owner, so owner gets the next sub-prefix on renewal.
+func (c *testPrefixLeases) expire(owner string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.leases, owner)
+}
+
+func (c *testPrefixLeases) setUnavailable(unavailable bool) {
+	c.Lock()
+	defer c.Unlock()
+	c.unavailable = unavailable
+}
+
+func (c *testPrefixLeases) getRenewals() int {
+	c.Lock()
+	defer c.Unlock()
+	return c.renewals
+}
+
+func TestLeasedPrefixPoolReplicas(t *testing.T) {
+	RegisterTestingT(t)
+
+	client := &testPrefixLeases{leases: map[string][]string{}}
+	replica1, err := NewLeasedPrefixPool(client, "icmp-responder", "nse-1", 0, 0, NewPrefixPool, "10.20.0.0/16")
+	Expect(err).To(BeNil())
+	defer replica1.Close()
+	replica2, err := NewLeasedPrefixPool(client, "icmp-responder", "nse-2", 0, 0, NewPrefixPool, "10.20.0.0/16")
+	Expect(err).To(BeNil())
+	defer replica2.Close()
+
+	// Replicas allocate the same connection addresses from their own sub-prefixes.
+	srcIP1, _, _, err := replica1.Extract("c1", connectioncontext.IpFamily_IPV4)
+	Expect(err).To(BeNil())
+	srcIP2, _, _, err := replica2.Extract("c1", connectioncontext.IpFamily_IPV4)
+	Expect(err).To(BeNil())
+	Expect(srcIP1.String()).To(Equal("10.20.0.1/30"))
+	Expect(srcIP2.String()).To(Equal("10.20.1.1/30"))
+
+	// Lease is renewed before it expires.
+	Eventually(client.getRenewals, 3*time.Second, 100*time.Millisecond).Should(BeNumerically(">=", 2))
+
+	Expect(replica1.Close()).To(BeNil())
+	Expect(client.released).To(Equal([]string{"nse-1"}))
+}
+
+func TestLeasedPrefixPoolNoRoom(t *testing.T) {
+	RegisterTestingT(t)
+
+	client := &testPrefixLeases{leases: map[string][]string{}}
+	_, err := NewLeasedPrefixPool(client, "icmp-responder", "nse-1", 0, 0, NewPrefixPool, "10.20.0.0/25")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestLeasedPrefixPoolPrefixLen(t *testing.T) {
+	RegisterTestingT(t)
+
+	client := &testPrefixLeases{leases: map[string][]string{}}
+	pool, err := NewLeasedPrefixPool(client, "icmp-responder", "nse-1", 28, 0, NewPrefixPool, "10.20.0.0/16")
+	Expect(err).To(BeNil())
+	defer pool.Close()
+	Expect(pool.GetPrefixes()).To(Equal([]string{"10.20.0.0/28"}))
+}
+
+func TestLeasedPrefixPoolExpired(t *testing.T) {
+	RegisterTestingT(t)
+
+	client := &testPrefixLeases{leases: map[string][]string{}}
+	pool, err := NewLeasedPrefixPool(client, "icmp-responder", "nse-1", 0, 0, NewPrefixPool, "10.20.0.0/16")
+	Expect(err).To(BeNil())
+	defer pool.Close()
+
+	// Addresses are not allocated after lease is expired, since they could be leased to other replica.
+	client.setUnavailable(true)
+	extract := func() error {
+		_, _, _, err := pool.Extract(fmt.Sprintf("c%d", time.Now().UnixNano()), connectioncontext.IpFamily_IPV4)
+		return err
+	}
+	Eventually(extract, 3*time.Second, 100*time.Millisecond).ShouldNot(BeNil())
+
+	// Allocation is resumed once lease is renewed.
+	client.setUnavailable(false)
+	Eventually(extract, 3*time.Second, 100*time.Millisecond).Should(BeNil())
+}
+
+func TestLeasedPrefixPoolPrefixesChanged(t *testing.T) {
+	RegisterTestingT(t)
+
+	client := &testPrefixLeases{leases: map[string][]string{}}
+	pool, err := NewLeasedPrefixPool(client, "icmp-responder", "nse-1", 0, 0, NewPrefixPool, "10.20.0.0/16")
+	Expect(err).To(BeNil())
+	defer pool.Close()
+	_, _, _, err = pool.Extract("c0", connectioncontext.IpFamily_IPV4)
+	Expect(err).To(BeNil())
+
+	// Other sub-prefix is leased on renewal, addresses are allocated from it.
+	client.expire("nse-1")
+	Eventually(pool.GetPrefixes, 3*time.Second, 100*time.Millisecond).Should(Equal([]string{"10.20.1.0/24"}))
+	srcIP, _, _, err := pool.Extract("c1", connectioncontext.IpFamily_IPV4)
+	Expect(err).To(BeNil())
+	Expect(srcIP.String()).To(Equal("10.20.1.1/30"))
+
+	// Connection allocated before renewal keeps addresses of expired lease until it is released.
+	prefix, _, err := pool.GetConnectionInformation("c0")
+	Expect(err).To(BeNil())
+	Expect(prefix).To(Equal("10.20.0.0/30"))
+	Expect(pool.Release("c0")).To(BeNil())
+	Expect(pool.Release("c1")).To(BeNil())
+}
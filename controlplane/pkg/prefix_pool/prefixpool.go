@@ -5,6 +5,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	"math/big"
 	"net"
+	"strings"
 	"sync"
 )
 
@@ -19,6 +20,11 @@ type PrefixPool interface {
 	Release(connectionId string) error
 	GetConnectionInformation(connectionId string) (string, []string, error)
 	GetPrefixes() []string
+	/*
+		Families of prefixes pool was created with, IPv4 goes first. Connection gets address pair of every family, by
+		extracting them with the same connection id.
+	*/
+	Families() []connectioncontext.IpFamily_Family
 }
 type prefixPool struct {
 	sync.RWMutex
@@ -32,13 +38,27 @@ func (impl *prefixPool) GetPrefixes() []string {
 	return impl.prefixes
 }
 
+func (impl *prefixPool) Families() []connectioncontext.IpFamily_Family {
+	families := []connectioncontext.IpFamily_Family{}
+	for _, family := range []connectioncontext.IpFamily_Family{connectioncontext.IpFamily_IPV4, connectioncontext.IpFamily_IPV6} {
+		if familyPrefixes, _ := splitByFamily(impl.basePrefixes, family); len(familyPrefixes) > 0 {
+			families = append(families, family)
+		}
+	}
+	return families
+}
+
 type connectionRecord struct {
-	ipNet    *net.IPNet
+	ipNets   []*net.IPNet
 	prefixes []string
 }
 
 func NewPrefixPool(prefixes ...string) (PrefixPool, error) {
-	//TODO: Add validation of input prefixes.
+	for _, prefix := range prefixes {
+		if _, _, err := net.ParseCIDR(prefix); err != nil {
+			return nil, fmt.Errorf("Invalid prefix %s: %v", prefix, err)
+		}
+	}
 	return &prefixPool{
 		basePrefixes: prefixes,
 		prefixes:     prefixes,
@@ -88,10 +108,14 @@ func (impl *prefixPool) Extract(connectionId string, family connectioncontext.Ip
 
 	impl.prefixes = remaining
 
-	impl.connections[connectionId] = &connectionRecord{
-		ipNet:    ipNet,
-		prefixes: requested,
+	// Connection with address pair of other family already extracted is dual-stack one.
+	record := impl.connections[connectionId]
+	if record == nil {
+		record = &connectionRecord{}
+		impl.connections[connectionId] = record
 	}
+	record.ipNets = append(record.ipNets, ipNet)
+	record.prefixes = append(record.prefixes, requested...)
 	return &net.IPNet{IP: src, Mask: ipNet.Mask}, &net.IPNet{IP: dst, Mask: ipNet.Mask}, requested, nil
 }
 func (impl *prefixPool) Release(connectionId string) error {
@@ -109,9 +133,11 @@ func (impl *prefixPool) Release(connectionId string) error {
 		return err
 	}
 
-	remaining, err = ReleasePrefixes(remaining, conn.ipNet.String())
-	if err != nil {
-		return err
+	for _, ipNet := range conn.ipNets {
+		remaining, err = ReleasePrefixes(remaining, ipNet.String())
+		if err != nil {
+			return err
+		}
 	}
 
 	impl.prefixes = remaining
@@ -125,7 +151,11 @@ func (impl *prefixPool) GetConnectionInformation(connectionId string) (string, [
 	if conn == nil {
 		return "", nil, fmt.Errorf("No connection with id: %s is found", connectionId)
 	}
-	return conn.ipNet.String(), conn.prefixes, nil
+	ipNets := []string{}
+	for _, ipNet := range conn.ipNets {
+		ipNets = append(ipNets, ipNet.String())
+	}
+	return strings.Join(ipNets, ","), conn.prefixes, nil
 }
 
 func ExtractPrefixes(prefixes []string, requests ...*connectioncontext.ExtraPrefixRequest) (requested []string, remaining []string, err error) {
@@ -147,8 +177,8 @@ func ExtractPrefixes(prefixes []string, requests ...*connectioncontext.ExtraPref
 
 	// We need to firstly find required prefixes available.
 	for _, request := range requests {
-		for i := uint32(0); i < request.RequiredNumber; i++ {
-			prefix, leftPrefixes, error := ExtractPrefix(newPrefixes, request.PrefixLen)
+		for i := uint32(0); i < request.GetRequiredNumber(); i++ {
+			prefix, leftPrefixes, error := extractFamilyPrefix(newPrefixes, request.GetAddrFamily().GetFamily(), request.GetPrefixLen())
 			if error != nil {
 				return nil, prefixes, error
 			}
@@ -158,8 +188,8 @@ func ExtractPrefixes(prefixes []string, requests ...*connectioncontext.ExtraPref
 	}
 	// We need to fit some more prefies up to Requested ones
 	for _, request := range requests {
-		for i := request.RequiredNumber; i < request.RequestedNumber; i++ {
-			prefix, leftPrefixes, error := ExtractPrefix(newPrefixes, request.PrefixLen)
+		for i := request.GetRequiredNumber(); i < request.GetRequestedNumber(); i++ {
+			prefix, leftPrefixes, error := extractFamilyPrefix(newPrefixes, request.GetAddrFamily().GetFamily(), request.GetPrefixLen())
 			if error != nil {
 				// It seems there is no more prefixes available, but since we have all Required already we could go.
 				break
@@ -174,6 +204,28 @@ func ExtractPrefixes(prefixes []string, requests ...*connectioncontext.ExtraPref
 	return result, newPrefixes, nil
 }
 
+// extractFamilyPrefix - extracts prefix of family from prefixes, prefixes of other families are kept as is.
+func extractFamilyPrefix(prefixes []string, family connectioncontext.IpFamily_Family, prefixLen uint32) (string, []string, error) {
+	familyPrefixes, otherPrefixes := splitByFamily(prefixes, family)
+	prefix, leftPrefixes, err := ExtractPrefix(familyPrefixes, prefixLen)
+	if err != nil {
+		return "", prefixes, err
+	}
+	return prefix, append(leftPrefixes, otherPrefixes...), nil
+}
+
+func splitByFamily(prefixes []string, family connectioncontext.IpFamily_Family) (familyPrefixes []string, otherPrefixes []string) {
+	for _, prefix := range prefixes {
+		ip, _, err := net.ParseCIDR(prefix)
+		if err == nil && (ip.To4() != nil) == (family == connectioncontext.IpFamily_IPV4) {
+			familyPrefixes = append(familyPrefixes, prefix)
+		} else {
+			otherPrefixes = append(otherPrefixes, prefix)
+		}
+	}
+	return familyPrefixes, otherPrefixes
+}
+
 func ExtractPrefix(prefixes []string, prefixLen uint32) (string, []string, error) {
 	// Check if we already have required CIDR
 	max_prefix := 0
@@ -185,7 +237,11 @@ func ExtractPrefix(prefixes []string, prefixLen uint32) (string, []string, error
 		if error != nil {
 			continue
 		}
-		parentLen, _ := netip.Mask.Size()
+		parentLen, addrLen := netip.Mask.Size()
+		if prefixLen > uint32(addrLen) {
+			// Prefix of other family could not be split to required prefix len.
+			continue
+		}
 		// Check if some of requests are fit into this prefix.
 		if prefixLen == uint32(parentLen) {
 			// We found required one.
@@ -338,6 +394,54 @@ func ReleasePrefixes(prefixes []string, released ...string) (remaining []string,
 	}
 }
 
+// ExcludePrefixes - removes excluded prefixes from prefixes. Prefix partially covered by excluded one is split, so
+// only parts of it not intersecting with excluded one are kept.
+func ExcludePrefixes(prefixes []string, excluded ...string) ([]string, error) {
+	result := append([]string{}, prefixes...)
+	for _, prefix := range excluded {
+		_, excludedNet, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, err
+		}
+		excludedLen, _ := excludedNet.Mask.Size()
+		remaining := []string{}
+		for _, value := range result {
+			_, ipNet, err := net.ParseCIDR(value)
+			if err != nil {
+				return nil, err
+			}
+			if !ipNet.Contains(excludedNet.IP) && !excludedNet.Contains(ipNet.IP) {
+				remaining = append(remaining, value)
+				continue
+			}
+			// Split prefix until excluded one is reached, keeping halves not containing it.
+			for {
+				prefixLen, _ := ipNet.Mask.Size()
+				if prefixLen >= excludedLen {
+					break
+				}
+				sub1, err := subnet(ipNet, 0)
+				if err != nil {
+					return nil, err
+				}
+				sub2, err := subnet(ipNet, 1)
+				if err != nil {
+					return nil, err
+				}
+				if sub1.Contains(excludedNet.IP) {
+					remaining = append(remaining, sub2.String())
+					ipNet = sub1
+				} else {
+					remaining = append(remaining, sub1.String())
+					ipNet = sub2
+				}
+			}
+		}
+		result = remaining
+	}
+	return result, nil
+}
+
 func subnet(ipnet *net.IPNet, subnet_index int) (*net.IPNet, error) {
 	mask := ipnet.Mask
 
@@ -289,3 +289,115 @@ func TestRelease2(t *testing.T) {
 	Expect(err).To(BeNil())
 	Expect(newPrefixes).To(Equal([]string{"10.10.1.0/24"}))
 }
+
+func TestNetExtractIPv6(t *testing.T) {
+	RegisterTestingT(t)
+
+	pool, err := NewPrefixPool("fd00:10:1::/64")
+	Expect(err).To(BeNil())
+	Expect(pool.Families()).To(Equal([]connectioncontext.IpFamily_Family{connectioncontext.IpFamily_IPV6}))
+
+	srcIP, dstIP, _, err := pool.Extract("c1", connectioncontext.IpFamily_IPV6)
+	Expect(err).To(BeNil())
+	Expect(srcIP.String()).To(Equal("fd00:10:1::1/126"))
+	Expect(dstIP.String()).To(Equal("fd00:10:1::2/126"))
+
+	_, _, _, err = pool.Extract("c2", connectioncontext.IpFamily_IPV4)
+	Expect(err).NotTo(BeNil())
+
+	Expect(pool.Release("c1")).To(BeNil())
+	Expect(pool.GetPrefixes()).To(Equal([]string{"fd00:10:1::/64"}))
+}
+
+func TestNetExtractDualStack(t *testing.T) {
+	RegisterTestingT(t)
+
+	pool, err := NewPrefixPool("fd00:10:1::/64", "10.10.1.0/24")
+	Expect(err).To(BeNil())
+	Expect(pool.Families()).To(Equal([]connectioncontext.IpFamily_Family{connectioncontext.IpFamily_IPV4, connectioncontext.IpFamily_IPV6}))
+
+	srcIP, dstIP, requested, err := pool.Extract("c1", connectioncontext.IpFamily_IPV4, &connectioncontext.ExtraPrefixRequest{
+		AddrFamily:      &connectioncontext.IpFamily{Family: connectioncontext.IpFamily_IPV6},
+		RequiredNumber:  1,
+		RequestedNumber: 1,
+		PrefixLen:       120,
+	})
+	Expect(err).To(BeNil())
+	Expect(srcIP.String()).To(Equal("10.10.1.1/30"))
+	Expect(dstIP.String()).To(Equal("10.10.1.2/30"))
+	Expect(requested).To(Equal([]string{"fd00:10:1::/120"}))
+
+	srcIP, dstIP, _, err = pool.Extract("c1", connectioncontext.IpFamily_IPV6)
+	Expect(err).To(BeNil())
+	Expect(srcIP.String()).To(Equal("fd00:10:1::101/126"))
+	Expect(dstIP.String()).To(Equal("fd00:10:1::102/126"))
+
+	ipNets, prefixes, err := pool.GetConnectionInformation("c1")
+	Expect(err).To(BeNil())
+	Expect(ipNets).To(Equal("10.10.1.0/30,fd00:10:1::100/126"))
+	Expect(prefixes).To(Equal([]string{"fd00:10:1::/120"}))
+
+	Expect(pool.Release("c1")).To(BeNil())
+	Expect(pool.GetPrefixes()).To(ConsistOf("fd00:10:1::/64", "10.10.1.0/24"))
+}
+
+func TestExtractPrefixesOfFamily(t *testing.T) {
+	RegisterTestingT(t)
+
+	// IPv4 prefix could not be split to /64, so only IPv6 one is used.
+	requested, remaining, err := ExtractPrefixes([]string{"10.10.1.0/24", "100::/63"},
+		&connectioncontext.ExtraPrefixRequest{
+			AddrFamily:      &connectioncontext.IpFamily{Family: connectioncontext.IpFamily_IPV6},
+			RequiredNumber:  1,
+			RequestedNumber: 1,
+			PrefixLen:       64,
+		},
+	)
+	Expect(err).To(BeNil())
+	Expect(requested).To(Equal([]string{"100::/64"}))
+	Expect(remaining).To(ConsistOf("10.10.1.0/24", "100:0:0:1::/64"))
+
+	// IPv6 prefix is not used for IPv4 request.
+	_, _, err = ExtractPrefixes([]string{"100::/63"},
+		&connectioncontext.ExtraPrefixRequest{
+			AddrFamily:      &connectioncontext.IpFamily{Family: connectioncontext.IpFamily_IPV4},
+			RequiredNumber:  1,
+			RequestedNumber: 1,
+			PrefixLen:       30,
+		},
+	)
+	Expect(err).NotTo(BeNil())
+
+	// Request without family is refused.
+	_, remaining, err = ExtractPrefixes([]string{"10.10.1.0/24"},
+		&connectioncontext.ExtraPrefixRequest{
+			RequiredNumber:  1,
+			RequestedNumber: 1,
+			PrefixLen:       30,
+		},
+	)
+	Expect(err).NotTo(BeNil())
+	Expect(remaining).To(Equal([]string{"10.10.1.0/24"}))
+}
+
+func TestNewPrefixPoolInvalidPrefix(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := NewPrefixPool("10.10.1.0/24", "abc")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestExcludePrefixes(t *testing.T) {
+	RegisterTestingT(t)
+
+	remaining, err := ExcludePrefixes([]string{"10.10.0.0/22", "10.20.0.0/24", "100::/64"}, "10.10.1.0/24", "10.20.0.0/16")
+	Expect(err).To(BeNil())
+	Expect(remaining).To(ConsistOf("10.10.2.0/23", "10.10.0.0/24", "100::/64"))
+
+	remaining, err = ExcludePrefixes([]string{"10.10.0.0/24"}, "100::/64")
+	Expect(err).To(BeNil())
+	Expect(remaining).To(Equal([]string{"10.10.0.0/24"}))
+
+	_, err = ExcludePrefixes([]string{"10.10.0.0/24"}, "abc")
+	Expect(err).NotTo(BeNil())
+}
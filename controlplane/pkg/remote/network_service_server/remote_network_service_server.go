@@ -3,7 +3,6 @@ package network_service_server
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/remote_connection_monitor"
@@ -12,13 +11,11 @@ import (
 	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
 	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/policy"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
-	"github.com/sirupsen/logrus"
-)
-
-const (
-	// nseConnectionTimeout defines a timoute for NSM to succeed connection to NSE (seconds)
-	nseConnectionTimeout = 15 * time.Second
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type remoteNetworkServiceServer struct {
@@ -26,43 +23,96 @@ type remoteNetworkServiceServer struct {
 	serviceRegistry serviceregistry.ServiceRegistry
 	monitor         *remote_connection_monitor.RemoteConnectionMonitor
 	manager         nsm.NetworkServiceManager
+	policyEngine    *policy.Engine
 }
 
-func NewRemoteNetworkServiceServer(model model.Model, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, connectionMonitor *remote_connection_monitor.RemoteConnectionMonitor) remote_networkservice.NetworkServiceServer {
+// RemoteNetworkServiceServer - serves connections requested by remote NSMs and monitoring of them.
+type RemoteNetworkServiceServer interface {
+	remote_networkservice.NetworkServiceServer
+	remote_connection.MonitorConnectionServer
+}
+
+func NewRemoteNetworkServiceServer(model model.Model, manager nsm.NetworkServiceManager, serviceRegistry serviceregistry.ServiceRegistry, connectionMonitor *remote_connection_monitor.RemoteConnectionMonitor, policyEngine *policy.Engine) RemoteNetworkServiceServer {
 	server := &remoteNetworkServiceServer{
 		model:           model,
 		serviceRegistry: serviceRegistry,
 		monitor:         connectionMonitor,
 		manager:         manager,
+		policyEngine:    policyEngine,
 	}
 	return server
 }
 
 func (srv *remoteNetworkServiceServer) Request(ctx context.Context, request *remote_networkservice.NetworkServiceRequest) (*remote_connection.Connection, error) {
-	logrus.Infof("RemoteNSMD: Received request from client to connect to NetworkService: %v", request)
+	ctx, requestId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("RemoteNSMD:(%v) Received request from client to connect to NetworkService: %v", requestId, request)
+	if err := srv.verifyPeer(ctx, request.GetConnection()); err != nil {
+		log.Errorf("RemoteNSMD:(%v) %v", requestId, err)
+		return nil, err
+	}
+	if err := srv.policyEngine.Authorize(&policy.Request{
+		ConnectionId:   request.GetConnection().GetId(),
+		NetworkService: request.GetConnection().GetNetworkService(),
+		Labels:         request.GetConnection().GetLabels(),
+		SourceNsm:      request.GetConnection().GetSourceNetworkServiceManagerName(),
+	}); err != nil {
+		return nil, err
+	}
 	conn, err := srv.manager.Request(ctx, request)
 	if err != nil {
-		logrus.Error(err)
+		log.Errorf("RemoteNSMD:(%v) %v", requestId, err)
 		return nil, err
 	}
 
 	result := conn.(*remote_connection.Connection)
 	srv.monitor.Update(result)
 
-	logrus.Info("RemoteNSMD: Dataplane configuration done...")
+	log.Infof("RemoteNSMD:(%v) Dataplane configuration done...", requestId)
 	return result, nil
 }
 
 func (srv *remoteNetworkServiceServer) Close(ctx context.Context, connection *remote_connection.Connection) (*empty.Empty, error) {
-	logrus.Infof("Remote closing connection: %v", *connection)
+	ctx, closeId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("RemoteNSMD:(%v) Remote closing connection: %v", closeId, *connection)
+	if err := srv.verifyPeer(ctx, connection); err != nil {
+		log.Errorf("RemoteNSMD:(%v) %v", closeId, err)
+		return nil, err
+	}
 	clientConnection := srv.model.GetClientConnection(connection.GetId())
 	if clientConnection == nil {
 		return nil, fmt.Errorf("There is no such client connection %v", connection)
 	}
 	err := srv.manager.Close(ctx, clientConnection)
 	if err != nil {
-		logrus.Errorf("Error during connection close: %v", err)
+		log.Errorf("RemoteNSMD:(%v) Error during connection close: %v", closeId, err)
 	}
 	srv.monitor.Delete(connection)
 	return &empty.Empty{}, nil
 }
+
+// MonitorConnections - streams events of selected connections, which are requested by the peer.
+func (srv *remoteNetworkServiceServer) MonitorConnections(selector *remote_connection.MonitorScopeSelector, recipient remote_connection.MonitorConnection_MonitorConnectionsServer) error {
+	return srv.monitor.MonitorConnections(selector, remote_connection_monitor.NewPeerConnectionFilter(srv.serviceRegistry.SecurityProvider(), recipient))
+}
+
+// verifyPeer - checks connection is requested by its source NSM, if TLS is enabled. Source of existing connection
+// is taken from model, so peer can't act on connections of other NSMs by reusing their ids. Trusted proxy verifies
+// its peer is source NSM, so source should match for proxied connections as well.
+func (srv *remoteNetworkServiceServer) verifyPeer(ctx context.Context, connection *remote_connection.Connection) error {
+	provider := srv.serviceRegistry.SecurityProvider()
+	if provider == nil {
+		return nil
+	}
+	source := connection.GetSourceNetworkServiceManagerName()
+	if clientConnection := srv.model.GetClientConnection(connection.GetId()); clientConnection != nil {
+		if stored := clientConnection.Xcon.GetRemoteSource().GetSourceNetworkServiceManagerName(); stored != source {
+			return status.Errorf(codes.PermissionDenied, "connection %s is not requested by %s", connection.GetId(), source)
+		}
+	}
+	if err := provider.VerifyPeer(ctx, source); err != nil {
+		return status.Errorf(codes.Unauthenticated, "connection is refused: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Default values used if NetworkService does not define own retry policy.
+const (
+	DefaultMaxAttempts       = 3
+	DefaultInitialBackoff    = 100 * time.Millisecond
+	DefaultMaxBackoff        = 2 * time.Second
+	DefaultBackoffMultiplier = 2.0
+	DefaultJitter            = 0.2
+)
+
+// DefaultRetryableCodes - gRPC codes considered as transient failures. Errors returned by servers without
+// a status have codes.Unknown and are not retried by default, since they are most probable application errors.
+// Dataplane returns codes.Unavailable if it failed to program cross connect, so such failures are retried.
+var DefaultRetryableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.DeadlineExceeded,
+	codes.ResourceExhausted,
+	codes.Aborted,
+}
+
+// Policy describes how failed operation should be retried.
+type Policy struct {
+	// MaxAttempts - a total number of attempts, including first one.
+	MaxAttempts int
+	// InitialBackoff - a delay before second attempt.
+	InitialBackoff time.Duration
+	// MaxBackoff - a maximum delay between attempts.
+	MaxBackoff time.Duration
+	// Multiplier - backoff is multiplied by it after every attempt.
+	Multiplier float64
+	// Jitter - a fraction of backoff randomly added or subtracted from it.
+	Jitter float64
+	// RetryableCodes - gRPC codes of errors to retry, errors without gRPC status are never retried.
+	RetryableCodes []codes.Code
+}
+
+// NewDefaultPolicy creates a policy with default values.
+func NewDefaultPolicy() *Policy {
+	return &Policy{
+		MaxAttempts:    DefaultMaxAttempts,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+		Multiplier:     DefaultBackoffMultiplier,
+		Jitter:         DefaultJitter,
+		RetryableCodes: DefaultRetryableCodes,
+	}
+}
+
+// NewPolicy creates a policy for NetworkService, values not defined by NetworkService are taken from default policy.
+func NewPolicy(networkService *registry.NetworkService) *Policy {
+	policy := NewDefaultPolicy()
+	config := networkService.GetRetryPolicy()
+	if config == nil {
+		return policy
+	}
+	if config.GetMaxAttempts() > 0 {
+		policy.MaxAttempts = int(config.GetMaxAttempts())
+	}
+	if config.GetInitialBackoffMs() > 0 {
+		policy.InitialBackoff = time.Duration(config.GetInitialBackoffMs()) * time.Millisecond
+	}
+	if config.GetMaxBackoffMs() > 0 {
+		policy.MaxBackoff = time.Duration(config.GetMaxBackoffMs()) * time.Millisecond
+	}
+	if config.GetBackoffMultiplier() >= 1 {
+		policy.Multiplier = config.GetBackoffMultiplier()
+	}
+	if config.GetJitter() > 0 && config.GetJitter() <= 1 {
+		policy.Jitter = config.GetJitter()
+	}
+	if len(config.GetRetryableCodes()) > 0 {
+		policy.RetryableCodes = parseCodes(networkService.GetName(), config.GetRetryableCodes())
+	}
+	return policy
+}
+
+func parseCodes(networkService string, names []string) []codes.Code {
+	byName := map[string]codes.Code{}
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		byName[strings.ToUpper(c.String())] = c
+	}
+	result := []codes.Code{}
+	for _, name := range names {
+		key := strings.ToUpper(strings.Replace(name, "_", "", -1))
+		if c, ok := byName[key]; ok {
+			result = append(result, c)
+		} else {
+			logrus.Errorf("Unknown gRPC code %s in retry policy of Network Service %s", name, networkService)
+		}
+	}
+	return result
+}
+
+// IsRetryable checks if error is a transient one and operation could be retried.
+func (p *Policy) IsRetryable(err error) bool {
+	if err == nil || err == context.Canceled || err == context.DeadlineExceeded {
+		return false
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	for _, c := range p.RetryableCodes {
+		if st.Code() == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns a delay before next attempt, attempt is counted from 1.
+func (p *Policy) Backoff(attempt int) time.Duration {
+	backoff := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= p.Multiplier
+		if backoff >= float64(p.MaxBackoff) {
+			backoff = float64(p.MaxBackoff)
+			break
+		}
+	}
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(backoff)
+}
+
+// Do calls operation until it succeeds, returns non retryable error, attempts are exhausted or context is done.
+// The last error is returned.
+func (p *Policy) Do(ctx context.Context, name string, operation func(attempt int) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = operation(attempt)
+		if err == nil {
+			return nil
+		}
+		if attempt >= p.MaxAttempts || !p.IsRetryable(err) {
+			return err
+		}
+		backoff := p.Backoff(attempt)
+		logrus.Warnf("%s attempt %d/%d failed: %v, retrying in %v", name, attempt, p.MaxAttempts, err, backoff)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
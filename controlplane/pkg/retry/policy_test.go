@@ -0,0 +1,101 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicyFromNetworkService(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := NewPolicy(&registry.NetworkService{Name: "golden_network"})
+	Expect(policy).To(Equal(NewDefaultPolicy()))
+
+	policy = NewPolicy(&registry.NetworkService{
+		Name: "golden_network",
+		RetryPolicy: &registry.RetryPolicy{
+			MaxAttempts:      5,
+			InitialBackoffMs: 10,
+			RetryableCodes:   []string{"UNKNOWN", "deadline_exceeded", "wrong"},
+		},
+	})
+	Expect(policy.MaxAttempts).To(Equal(5))
+	Expect(policy.InitialBackoff).To(Equal(10 * time.Millisecond))
+	Expect(policy.MaxBackoff).To(Equal(DefaultMaxBackoff))
+	Expect(policy.RetryableCodes).To(Equal([]codes.Code{codes.Unknown, codes.DeadlineExceeded}))
+}
+
+func TestPolicyIsRetryable(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := NewDefaultPolicy()
+	Expect(policy.IsRetryable(status.Error(codes.Unavailable, "unavailable"))).To(BeTrue())
+	Expect(policy.IsRetryable(status.Error(codes.InvalidArgument, "invalid"))).To(BeFalse())
+	Expect(policy.IsRetryable(fmt.Errorf("plain error"))).To(BeFalse())
+	Expect(policy.IsRetryable(context.Canceled)).To(BeFalse())
+}
+
+func TestPolicyBackoff(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := &Policy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+	}
+	Expect(policy.Backoff(1)).To(Equal(100 * time.Millisecond))
+	Expect(policy.Backoff(3)).To(Equal(400 * time.Millisecond))
+	Expect(policy.Backoff(10)).To(Equal(time.Second))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		backoff := policy.Backoff(1)
+		Expect(backoff >= 50*time.Millisecond && backoff <= 150*time.Millisecond).To(BeTrue())
+	}
+}
+
+func TestPolicyDo(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := &Policy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     2,
+		RetryableCodes: DefaultRetryableCodes,
+	}
+
+	attempts := 0
+	err := policy.Do(context.Background(), "test", func(attempt int) error {
+		attempts++
+		if attempt < 2 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	})
+	Expect(err).To(BeNil())
+	Expect(attempts).To(Equal(2))
+
+	attempts = 0
+	err = policy.Do(context.Background(), "test", func(attempt int) error {
+		attempts++
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+	Expect(status.Code(err)).To(Equal(codes.Unavailable))
+	Expect(attempts).To(Equal(3))
+
+	attempts = 0
+	err = policy.Do(context.Background(), "test", func(attempt int) error {
+		attempts++
+		return status.Error(codes.InvalidArgument, "invalid")
+	})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	Expect(attempts).To(Equal(1))
+}
@@ -0,0 +1,52 @@
+package selector
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/sirupsen/logrus"
+)
+
+// consistentHashSelector selects endpoint by hashing of request connection labels, so connections with same labels
+// are served by same endpoint. Rendezvous hashing is used, so only connections of removed endpoint are moved
+// in case of endpoint list change.
+type consistentHashSelector struct{}
+
+// NewConsistentHashSelector creates a selector choosing endpoint by hash of connection labels.
+func NewConsistentHashSelector() Selector {
+	return &consistentHashSelector{}
+}
+
+func labelsKey(labels map[string]string) string {
+	keys := []string{}
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := ""
+	for _, k := range keys {
+		result += k + "=" + labels[k] + ";"
+	}
+	return result
+}
+
+func (ch *consistentHashSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	key := labelsKey(requestConnection.GetLabels())
+
+	var endpoint *registry.NetworkServiceEndpoint
+	var maxScore uint64
+	for _, candidate := range networkServiceEndpoints {
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		h.Write([]byte(candidate.GetEndpointName()))
+		if score := h.Sum64(); endpoint == nil || score > maxScore {
+			endpoint = candidate
+			maxScore = score
+		}
+	}
+	logrus.Infof("ConsistentHash selected %v for labels %v", endpoint, requestConnection.GetLabels())
+	return endpoint
+}
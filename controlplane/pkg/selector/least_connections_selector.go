@@ -0,0 +1,32 @@
+package selector
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/sirupsen/logrus"
+)
+
+type leastConnectionsSelector struct {
+	ctx SelectorContext
+}
+
+// NewLeastConnectionsSelector creates a selector choosing endpoint with least number of active client connections.
+func NewLeastConnectionsSelector(ctx SelectorContext) Selector {
+	return &leastConnectionsSelector{
+		ctx: ctx,
+	}
+}
+
+func (lc *leastConnectionsSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	var endpoint *registry.NetworkServiceEndpoint
+	minConnections := 0
+	for _, candidate := range networkServiceEndpoints {
+		count := lc.ctx.ConnectionsCount(candidate.GetEndpointName())
+		if endpoint == nil || count < minConnections {
+			endpoint = candidate
+			minConnections = count
+		}
+	}
+	logrus.Infof("LeastConnections selected %v with %d connections", endpoint, minConnections)
+	return endpoint
+}
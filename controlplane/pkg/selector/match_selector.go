@@ -16,6 +16,7 @@
 package selector
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
@@ -25,13 +26,20 @@ import (
 
 type matchSelector struct {
 	sync.Mutex
-	roundRobin Selector
+	strategy Selector
+	weighted *weightedSelector
 }
 
-// NewMatchSelector creates a new
+// NewMatchSelector creates a new match selector using round robin to select between matched endpoints
 func NewMatchSelector() Selector {
+	return NewMatchSelectorWithStrategy(NewRoundRobinSelector())
+}
+
+// NewMatchSelectorWithStrategy creates a new match selector using passed strategy to select between matched endpoints
+func NewMatchSelectorWithStrategy(strategy Selector) Selector {
 	return &matchSelector{
-		roundRobin: NewRoundRobinSelector(),
+		strategy: strategy,
+		weighted: newWeightedSelector(),
 	}
 }
 
@@ -48,29 +56,42 @@ func isSubset(A, B map[string]string) bool {
 	return true
 }
 
-func (m *matchSelector) matchEndpoint(nsLabels map[string]string, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+func (m *matchSelector) matchEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	nsLabels := requestConnection.GetLabels()
 	logrus.Infof("Matching ednpoint for labels %v", nsLabels)
 	//Iterate through the matches
-	for _, match := range ns.GetMatches() {
+	for matchIdx, match := range ns.GetMatches() {
 		// All match source selector labels should be present in the requested labels map
 		if !isSubset(nsLabels, match.GetSourceSelector()) {
 			continue
 		}
 
 		nseCandidates := []*registry.NetworkServiceEndpoint{}
+		destinationCandidates := make([][]*registry.NetworkServiceEndpoint, len(match.GetRoutes()))
 		// Check all Destinations in that match
-		for _, destination := range match.GetRoutes() {
+		for idx, destination := range match.GetRoutes() {
 			// Each NSE should be matched against that destination
 			for _, nse := range networkServiceEndpoints {
 				if isSubset(nse.GetLabels(), destination.GetDestinationSelector()) {
 					nseCandidates = append(nseCandidates, nse)
+					destinationCandidates[idx] = append(destinationCandidates[idx], nse)
 				}
 			}
 		}
 
+		if len(nseCandidates) > 0 && hasWeights(match.GetRoutes()) {
+			// Destinations has weights, so we select one according to weights.
+			key := fmt.Sprintf("%s/%d", ns.GetName(), matchIdx)
+			if endpoint := m.weighted.selectEndpoint(key, match.GetRoutes(), destinationCandidates); endpoint != nil {
+				return endpoint
+			}
+			// Only destinations with zero weight are matched, let's check next match.
+			continue
+		}
+
 		if len(nseCandidates) > 0 {
-			// We found candidates. Use RoundRobin to select one
-			return m.roundRobin.SelectEndpoint(nil, ns, nseCandidates)
+			// We found candidates. Use selection strategy to select one
+			return m.strategy.SelectEndpoint(requestConnection, ns, nseCandidates)
 		}
 	}
 	return nil
@@ -79,8 +100,8 @@ func (m *matchSelector) matchEndpoint(nsLabels map[string]string, ns *registry.N
 func (m *matchSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
 	logrus.Infof("Selecting endpoint for %s with %d matches.", requestConnection.GetNetworkService(), len(ns.GetMatches()))
 	if len(ns.GetMatches()) == 0 {
-		return m.roundRobin.SelectEndpoint(nil, ns, networkServiceEndpoints)
+		return m.strategy.SelectEndpoint(requestConnection, ns, networkServiceEndpoints)
 	}
 
-	return m.matchEndpoint(requestConnection.GetLabels(), ns, networkServiceEndpoints)
+	return m.matchEndpoint(requestConnection, ns, networkServiceEndpoints)
 }
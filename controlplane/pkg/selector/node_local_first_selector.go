@@ -0,0 +1,33 @@
+package selector
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+)
+
+type nodeLocalFirstSelector struct {
+	ctx        SelectorContext
+	roundRobin Selector
+}
+
+// NewNodeLocalFirstSelector creates a selector preferring endpoints registered by local NSM,
+// remote endpoints are selected only if there are no local ones. Round robin is used between candidates.
+func NewNodeLocalFirstSelector(ctx SelectorContext) Selector {
+	return &nodeLocalFirstSelector{
+		ctx:        ctx,
+		roundRobin: NewRoundRobinSelector(),
+	}
+}
+
+func (nl *nodeLocalFirstSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	localEndpoints := []*registry.NetworkServiceEndpoint{}
+	for _, candidate := range networkServiceEndpoints {
+		if candidate.GetNetworkServiceManagerName() == nl.ctx.NetworkServiceManagerName() {
+			localEndpoints = append(localEndpoints, candidate)
+		}
+	}
+	if len(localEndpoints) > 0 {
+		return nl.roundRobin.SelectEndpoint(requestConnection, ns, localEndpoints)
+	}
+	return nl.roundRobin.SelectEndpoint(requestConnection, ns, networkServiceEndpoints)
+}
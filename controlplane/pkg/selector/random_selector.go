@@ -0,0 +1,36 @@
+package selector
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/sirupsen/logrus"
+)
+
+type randomSelector struct {
+	sync.Mutex
+	rand *rand.Rand
+}
+
+// NewRandomSelector creates a selector choosing endpoint randomly.
+func NewRandomSelector() Selector {
+	return &randomSelector{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (r *randomSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	if len(networkServiceEndpoints) == 0 {
+		return nil
+	}
+	r.Lock()
+	idx := r.rand.Intn(len(networkServiceEndpoints))
+	r.Unlock()
+
+	endpoint := networkServiceEndpoints[idx]
+	logrus.Infof("Random selected %v", endpoint)
+	return endpoint
+}
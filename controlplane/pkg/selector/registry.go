@@ -0,0 +1,105 @@
+package selector
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/sirupsen/logrus"
+)
+
+// Names of selection strategies NetworkService could refer to.
+const (
+	RoundRobin       = "round-robin"
+	LeastConnections = "least-connections"
+	Random           = "random"
+	ConsistentHash   = "consistent-hash"
+	NodeLocalFirst   = "node-local-first"
+)
+
+// SelectorFactory creates a selection strategy instance.
+type SelectorFactory func(ctx SelectorContext) Selector
+
+var factories = struct {
+	sync.RWMutex
+	items map[string]SelectorFactory
+}{
+	items: map[string]SelectorFactory{
+		RoundRobin: func(ctx SelectorContext) Selector {
+			return NewRoundRobinSelector()
+		},
+		LeastConnections: NewLeastConnectionsSelector,
+		Random: func(ctx SelectorContext) Selector {
+			return NewRandomSelector()
+		},
+		ConsistentHash: func(ctx SelectorContext) Selector {
+			return NewConsistentHashSelector()
+		},
+		NodeLocalFirst: NewNodeLocalFirstSelector,
+	},
+}
+
+// RegisterSelector registers a new selection strategy with passed name, so NetworkService could refer to it.
+func RegisterSelector(name string, factory SelectorFactory) {
+	factories.Lock()
+	defer factories.Unlock()
+	factories.items[name] = factory
+}
+
+// RegisteredSelectors returns names of all registered selection strategies.
+func RegisteredSelectors() []string {
+	factories.RLock()
+	defer factories.RUnlock()
+	names := []string{}
+	for name := range factories.items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// strategySelector selects endpoints using selection strategy requested by NetworkService,
+// matches of NetworkService are applied before strategy is used.
+type strategySelector struct {
+	sync.Mutex
+	ctx       SelectorContext
+	selectors map[string]Selector
+}
+
+// NewStrategySelector creates a selector dispatching selection to a strategy named by NetworkService,
+// round robin is used if NetworkService does not specify one.
+func NewStrategySelector(ctx SelectorContext) Selector {
+	return &strategySelector{
+		ctx:       ctx,
+		selectors: make(map[string]Selector),
+	}
+}
+
+func (s *strategySelector) getSelector(strategy string) Selector {
+	if strategy == "" {
+		strategy = RoundRobin
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	if selector, ok := s.selectors[strategy]; ok {
+		return selector
+	}
+
+	factories.RLock()
+	factory, ok := factories.items[strategy]
+	if !ok {
+		logrus.Errorf("Unknown selection strategy %s, %s will be used", strategy, RoundRobin)
+		factory = factories.items[RoundRobin]
+	}
+	factories.RUnlock()
+
+	selector := NewMatchSelectorWithStrategy(factory(s.ctx))
+	s.selectors[strategy] = selector
+	return selector
+}
+
+func (s *strategySelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	return s.getSelector(ns.GetSelectionStrategy()).SelectEndpoint(requestConnection, ns, networkServiceEndpoints)
+}
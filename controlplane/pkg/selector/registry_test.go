@@ -0,0 +1,123 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+)
+
+type testSelectorContext struct {
+	nsmName     string
+	connections map[string]int
+}
+
+func (ctx *testSelectorContext) ConnectionsCount(endpointName string) int {
+	return ctx.connections[endpointName]
+}
+
+func (ctx *testSelectorContext) NetworkServiceManagerName() string {
+	return ctx.nsmName
+}
+
+func genStrategyEndpoints() []*registry.NetworkServiceEndpoint {
+	return []*registry.NetworkServiceEndpoint{
+		{EndpointName: "NSE-1", NetworkServiceManagerName: "nsm-remote"},
+		{EndpointName: "NSE-2", NetworkServiceManagerName: "nsm-local"},
+		{EndpointName: "NSE-3", NetworkServiceManagerName: "nsm-remote"},
+	}
+}
+
+func Test_strategySelector_SelectEndpoint(t *testing.T) {
+	ctx := &testSelectorContext{
+		nsmName: "nsm-local",
+		connections: map[string]int{
+			"NSE-1": 3,
+			"NSE-2": 2,
+			"NSE-3": 1,
+		},
+	}
+	tests := []struct {
+		name     string
+		strategy string
+		want     []string
+	}{
+		{
+			name:     "default round robin",
+			strategy: "",
+			want:     []string{"NSE-1", "NSE-2", "NSE-3", "NSE-1"},
+		},
+		{
+			name:     "unknown strategy falls back to round robin",
+			strategy: "unknown",
+			want:     []string{"NSE-1", "NSE-2", "NSE-3"},
+		},
+		{
+			name:     "least connections",
+			strategy: LeastConnections,
+			want:     []string{"NSE-3", "NSE-3"},
+		},
+		{
+			name:     "node local first",
+			strategy: NodeLocalFirst,
+			want:     []string{"NSE-2", "NSE-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStrategySelector(ctx)
+			ns := &registry.NetworkService{
+				Name:              "network-service-1",
+				SelectionStrategy: tt.strategy,
+			}
+			for i, want := range tt.want {
+				got := s.SelectEndpoint(&connection.Connection{}, ns, genStrategyEndpoints())
+				if got.GetEndpointName() != want {
+					t.Errorf("strategySelector.SelectEndpoint() pass %d = %v, want %v", i, got.GetEndpointName(), want)
+				}
+			}
+		})
+	}
+}
+
+func Test_consistentHashSelector_SelectEndpoint(t *testing.T) {
+	s := NewConsistentHashSelector()
+	ns := &registry.NetworkService{Name: "network-service-1"}
+	requestConnection := &connection.Connection{
+		Labels: map[string]string{"app": "client", "user": "42"},
+	}
+
+	first := s.SelectEndpoint(requestConnection, ns, genStrategyEndpoints())
+	if first == nil {
+		t.Fatalf("consistentHashSelector.SelectEndpoint() = nil")
+	}
+	for i := 0; i < 10; i++ {
+		if got := s.SelectEndpoint(requestConnection, ns, genStrategyEndpoints()); got.GetEndpointName() != first.GetEndpointName() {
+			t.Errorf("consistentHashSelector.SelectEndpoint() = %v, want %v", got.GetEndpointName(), first.GetEndpointName())
+		}
+	}
+
+	// Removing of other endpoint should not change selection.
+	endpoints := []*registry.NetworkServiceEndpoint{}
+	for _, endpoint := range genStrategyEndpoints() {
+		if endpoint.GetEndpointName() == first.GetEndpointName() || len(endpoints) == 0 {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if got := s.SelectEndpoint(requestConnection, ns, endpoints); got.GetEndpointName() != first.GetEndpointName() {
+		t.Errorf("consistentHashSelector.SelectEndpoint() after removal = %v, want %v", got.GetEndpointName(), first.GetEndpointName())
+	}
+}
+
+func Test_randomSelector_SelectEndpoint(t *testing.T) {
+	s := NewRandomSelector()
+	ns := &registry.NetworkService{Name: "network-service-1"}
+	if got := s.SelectEndpoint(nil, ns, nil); got != nil {
+		t.Errorf("randomSelector.SelectEndpoint() = %v, want nil", got)
+	}
+	for i := 0; i < 10; i++ {
+		if got := s.SelectEndpoint(nil, ns, genStrategyEndpoints()); got == nil {
+			t.Errorf("randomSelector.SelectEndpoint() = nil")
+		}
+	}
+}
@@ -8,3 +8,11 @@ import (
 type Selector interface {
 	SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint
 }
+
+// SelectorContext provides selection strategies with information about state of local NSM.
+type SelectorContext interface {
+	// ConnectionsCount returns a number of active client connections served by endpoint.
+	ConnectionsCount(endpointName string) int
+	// NetworkServiceManagerName returns a name of local NSM.
+	NetworkServiceManagerName() string
+}
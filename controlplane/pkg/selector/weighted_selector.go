@@ -0,0 +1,72 @@
+package selector
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/sirupsen/logrus"
+)
+
+// weightedSelector distributes requests between destinations of a match according to their weights using
+// smooth weighted round robin, endpoints matched by the same destination are selected using round robin.
+type weightedSelector struct {
+	sync.Mutex
+	currentWeights map[string][]int64
+	roundRobin     map[string]int
+}
+
+func newWeightedSelector() *weightedSelector {
+	return &weightedSelector{
+		currentWeights: make(map[string][]int64),
+		roundRobin:     make(map[string]int),
+	}
+}
+
+// hasWeights checks if at least one of destinations has weight defined.
+func hasWeights(destinations []*registry.Destination) bool {
+	for _, destination := range destinations {
+		if destination.GetWeight() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// selectEndpoint selects endpoint from destinationCandidates, candidates are passed for every destination in same order.
+// Destinations with zero weight or without candidates will not be selected.
+func (ws *weightedSelector) selectEndpoint(key string, destinations []*registry.Destination, destinationCandidates [][]*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	ws.Lock()
+	defer ws.Unlock()
+
+	current := ws.currentWeights[key]
+	if len(current) != len(destinations) {
+		current = make([]int64, len(destinations))
+		ws.currentWeights[key] = current
+	}
+
+	selected := -1
+	var total int64
+	for idx, destination := range destinations {
+		weight := int64(destination.GetWeight())
+		if weight == 0 || len(destinationCandidates[idx]) == 0 {
+			continue
+		}
+		current[idx] += weight
+		total += weight
+		if selected == -1 || current[idx] > current[selected] {
+			selected = idx
+		}
+	}
+	if selected == -1 {
+		return nil
+	}
+	current[selected] -= total
+
+	candidates := destinationCandidates[selected]
+	rrKey := fmt.Sprintf("%s/%d", key, selected)
+	endpoint := candidates[ws.roundRobin[rrKey]%len(candidates)]
+	ws.roundRobin[rrKey] = ws.roundRobin[rrKey] + 1
+	logrus.Infof("Weighted selected %v with weight %d", endpoint, destinations[selected].GetWeight())
+	return endpoint
+}
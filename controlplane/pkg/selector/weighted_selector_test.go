@@ -0,0 +1,98 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+)
+
+func genWeightedArgs(nsName string, stableWeight, canaryWeight uint32) args {
+	return args{
+		requestConnection: &connection.Connection{
+			Labels: map[string]string{"app": "client"},
+		},
+		ns: &registry.NetworkService{
+			Name: nsName,
+			Matches: []*registry.Match{
+				{
+					SourceSelector: map[string]string{"app": "client"},
+					Routes: []*registry.Destination{
+						{
+							DestinationSelector: map[string]string{"version": "stable"},
+							Weight:              stableWeight,
+						},
+						{
+							DestinationSelector: map[string]string{"version": "canary"},
+							Weight:              canaryWeight,
+						},
+					},
+				},
+			},
+		},
+		networkServiceEndpoints: []*registry.NetworkServiceEndpoint{
+			{EndpointName: "NSE-stable-1", Labels: map[string]string{"version": "stable"}},
+			{EndpointName: "NSE-stable-2", Labels: map[string]string{"version": "stable"}},
+			{EndpointName: "NSE-canary", Labels: map[string]string{"version": "canary"}},
+		},
+	}
+}
+
+func Test_matchSelector_WeightedSelectEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     args
+		requests int
+		want     map[string]int
+	}{
+		{
+			name:     "canary 5 percent",
+			args:     genWeightedArgs("network-service-1", 95, 5),
+			requests: 100,
+			want: map[string]int{
+				"NSE-stable-1": 48,
+				"NSE-stable-2": 47,
+				"NSE-canary":   5,
+			},
+		},
+		{
+			name:     "equal weights",
+			args:     genWeightedArgs("network-service-2", 1, 1),
+			requests: 4,
+			want: map[string]int{
+				"NSE-stable-1": 1,
+				"NSE-stable-2": 1,
+				"NSE-canary":   2,
+			},
+		},
+		{
+			name:     "zero weight is not selected",
+			args:     genWeightedArgs("network-service-3", 0, 10),
+			requests: 10,
+			want: map[string]int{
+				"NSE-canary": 10,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatchSelector()
+			got := map[string]int{}
+			for i := 0; i < tt.requests; i++ {
+				endpoint := m.SelectEndpoint(tt.args.requestConnection, tt.args.ns, tt.args.networkServiceEndpoints)
+				if endpoint == nil {
+					t.Fatalf("matchSelector.SelectEndpoint() returned nil on request %d", i)
+				}
+				got[endpoint.GetEndpointName()]++
+			}
+			for name, count := range tt.want {
+				if got[name] != count {
+					t.Errorf("matchSelector.SelectEndpoint() selected %s %d times, want %d (%v)", name, got[name], count, got)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("matchSelector.SelectEndpoint() selected = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
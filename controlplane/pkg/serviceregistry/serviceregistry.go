@@ -7,11 +7,14 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
 	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
 	dataplaneapi "github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type ApiRegistry interface {
@@ -26,21 +29,33 @@ type ServiceRegistry interface {
 	GetPublicAPI() string
 
 	NetworkServiceDiscovery() (registry.NetworkServiceDiscoveryClient, error)
+	DomainResolver() interdomain.Resolver
+	DomainDiscovery(domain *interdomain.Domain) (registry.NetworkServiceDiscoveryClient, *grpc.ClientConn, error)
 	RegistryClient() (registry.NetworkServiceRegistryClient, error)
+	// ClusterInfoClient - returns client of registry publishing prefixes used by cluster.
+	ClusterInfoClient() (registry.ClusterInfoClient, error)
+	// PrefixLeaseClient - returns client of registry leasing sub-prefixes to replicas of endpoints.
+	PrefixLeaseClient() (registry.PrefixLeaseRegistryClient, error)
 
 	Stop()
 	NSMDApiClient() (nsmdapi.NSMDClient, *grpc.ClientConn, error)
 	DataplaneConnection(dataplane *model.Dataplane) (dataplaneapi.DataplaneClient, *grpc.ClientConn, error)
 
 	EndpointConnection(endpoint *registry.NSERegistration) (networkservice.NetworkServiceClient, *grpc.ClientConn, error)
+	EndpointHealthClient(endpoint *registry.NSERegistration) (grpc_health_v1.HealthClient, *grpc.ClientConn, error)
 	RemoteNetworkServiceClient(nsm *registry.NetworkServiceManager) (remote_networkservice.NetworkServiceClient, *grpc.ClientConn, error)
 
 	WaitForDataplaneAvailable(model model.Model, timeout time.Duration) error
 
 	WorkspaceName(endpoint *registry.NSERegistration) string
+	// WorkspacePod - returns namespace/name of pod workspace is allocated to.
+	WorkspacePod(workspace string) (string, error)
 
 	VniAllocator() vni.VniAllocator
 
+	// SecurityProvider - returns mutual TLS credentials for NSMD public API and remote NSMs, nil if TLS is disabled.
+	SecurityProvider() *security.Provider
+
 	NewWorkspaceProvider() WorkspaceLocationProvider
 }
 
@@ -1,7 +1,6 @@
 package services
 
 import (
-	"context"
 	"github.com/gogo/protobuf/proto"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
@@ -33,9 +32,9 @@ func (m *ClientConnectionManager) UpdateClientConnection(clientConnection *model
 
 func (m *ClientConnectionManager) UpdateClientConnectionSrcStateDown(clientConnection *model.ClientConnection) {
 	logrus.Info("ClientConnection src state is down")
-	clientConnection.Xcon.GetLocalSource().State = connection.State_DOWN
+	m.markSourceConnectionDown(clientConnection)
 	m.model.UpdateClientConnection(clientConnection)
-	_ = m.manager.Close(context.Background(), clientConnection)
+	m.manager.Heal(clientConnection, nsm.HealState_SrcDown)
 }
 
 func (m *ClientConnectionManager) UpdateClientConnectionDataplaneStateDown(clientConnections []*model.ClientConnection) {
@@ -129,6 +128,32 @@ func (m *ClientConnectionManager) GetClientConnectionsByDataplane(name string) [
 	return rv
 }
 
+func (m *ClientConnectionManager) GetClientConnectionsByRemoteNsm(name string) []*model.ClientConnection {
+	clientConnections := m.model.GetAllClientConnections()
+
+	var rv []*model.ClientConnection
+	for _, clientConnection := range clientConnections {
+		if clientConnection.RemoteNsm != nil && clientConnection.RemoteNsm.GetName() == name {
+			rv = append(rv, clientConnection)
+		}
+	}
+
+	return rv
+}
+
+func (m *ClientConnectionManager) GetClientConnectionsByEndpoint(name string) []*model.ClientConnection {
+	clientConnections := m.model.GetAllClientConnections()
+
+	var rv []*model.ClientConnection
+	for _, clientConnection := range clientConnections {
+		if clientConnection.Endpoint.GetNetworkserviceEndpoint().GetEndpointName() == name {
+			rv = append(rv, clientConnection)
+		}
+	}
+
+	return rv
+}
+
 func (m *ClientConnectionManager) DeleteClientConnection(clientConnection *model.ClientConnection) {
 	m.model.DeleteClientConnection(clientConnection.ConnectionId)
 }
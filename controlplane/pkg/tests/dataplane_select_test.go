@@ -0,0 +1,179 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	connection2 "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	. "github.com/onsi/gomega"
+)
+
+func TestModelSelectDataplaneBySelector(t *testing.T) {
+	RegisterTestingT(t)
+
+	mdl := model.NewModel()
+	mdl.AddDataplane(&model.Dataplane{RegisteredName: "dp-1"})
+	mdl.AddDataplane(&model.Dataplane{RegisteredName: "dp-2"})
+
+	dp, err := mdl.SelectDataplane(func(dp *model.Dataplane) bool {
+		return dp.RegisteredName == "dp-2"
+	})
+	Expect(err).To(BeNil())
+	Expect(dp.RegisteredName).To(Equal("dp-2"))
+
+	dp, err = mdl.SelectDataplane(func(dp *model.Dataplane) bool {
+		return false
+	})
+	Expect(dp).To(BeNil())
+	Expect(err.Error()).To(Equal("no appropriate dataplanes found"))
+}
+
+func TestModelSelectDataplaneLeastLoaded(t *testing.T) {
+	RegisterTestingT(t)
+
+	mdl := model.NewModel()
+	dp1 := &model.Dataplane{RegisteredName: "dp-1"}
+	dp2 := &model.Dataplane{RegisteredName: "dp-2"}
+	mdl.AddDataplane(dp1)
+	mdl.AddDataplane(dp2)
+
+	// Equal load, selected by name.
+	dp, err := mdl.SelectDataplane(nil)
+	Expect(err).To(BeNil())
+	Expect(dp.RegisteredName).To(Equal("dp-1"))
+
+	mdl.AddClientConnection(&model.ClientConnection{ConnectionId: "1", Dataplane: dp1})
+	dp, err = mdl.SelectDataplane(nil)
+	Expect(err).To(BeNil())
+	Expect(dp.RegisteredName).To(Equal("dp-2"))
+}
+
+func TestNSMDRequestSelectsDataplaneByMechanism(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddDataplane(&model.Dataplane{
+		RegisteredName: "test_memif_data_plane",
+		SocketLocation: "tcp:some_addr",
+		LocalMechanisms: []*connection.Mechanism{
+			{
+				Type: connection.MechanismType_MEM_INTERFACE,
+			},
+		},
+	})
+
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	request := createRequest(false)
+	request.MechanismPreferences = []*connection.Mechanism{
+		{
+			Type: connection.MechanismType_MEM_INTERFACE,
+			Parameters: map[string]string{
+				connection.InterfaceNameKey: "icmp-responder1",
+				connection.SocketFilename:   "memif.sock",
+			},
+		},
+	}
+
+	nsmResponse, err := nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetMechanism().GetType()).To(Equal(connection.MechanismType_MEM_INTERFACE))
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(clientConnection.Dataplane.RegisteredName).To(Equal("test_memif_data_plane"))
+}
+
+func TestNSMDRequestSelectsDataplaneCompatibleWithPeer(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	// Dataplane with MPLS is preferred by name, but remote NSM supports VXLAN only.
+	for _, dp := range []struct {
+		name      string
+		mechanism connection2.MechanismType
+	}{
+		{"a_mpls_data_plane", connection2.MechanismType_MPLSoUDP},
+		{"b_vxlan_data_plane", connection2.MechanismType_VXLAN},
+	} {
+		srv.testModel.AddDataplane(&model.Dataplane{
+			RegisteredName: dp.name,
+			SocketLocation: "tcp:some_addr",
+			LocalMechanisms: []*connection.Mechanism{
+				{
+					Type: connection.MechanismType_KERNEL_INTERFACE,
+				},
+			},
+			RemoteMechanisms: []*connection2.Mechanism{
+				{
+					Type: dp.mechanism,
+					Parameters: map[string]string{
+						connection2.VXLANSrcIP: "127.0.0.1",
+					},
+				},
+			},
+		})
+	}
+	srv2.testModel.AddDataplane(testDataplane2)
+
+	nseReg := &registry.NSERegistration{
+		NetworkService: &registry.NetworkService{
+			Name:    "golden_network",
+			Payload: "test",
+		},
+		NetworkServiceManager: &registry.NetworkServiceManager{
+			Name:             srv2.serviceRegistry.GetPublicAPI(),
+			Url:              srv2.serviceRegistry.GetPublicAPI(),
+			RemoteMechanisms: []string{connection2.MechanismType_VXLAN.String()},
+		},
+		NetworkserviceEndpoint: &registry.NetworkServiceEndpoint{
+			NetworkServiceManagerName: srv2.serviceRegistry.GetPublicAPI(),
+			Payload:                   "test",
+			NetworkServiceName:        "golden_network",
+			EndpointName:              "golden_networkprovider",
+		},
+	}
+	_, err := srv.nseRegistry.RegisterNSE(context.Background(), nseReg)
+	Expect(err).To(BeNil())
+	srv2.testModel.AddEndpoint(nseReg)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(clientConnection.Dataplane.RegisteredName).To(Equal("b_vxlan_data_plane"))
+	// Endpoints discovered to select dataplane are used to select endpoint as well.
+	Expect(srv.nseRegistry.findCalls).To(Equal(1))
+}
+
+func TestNSMDRequestFailsWithoutDataplaneCompatibleWithPeer(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.testModel.AddDataplane(testDataplane1)
+
+	nseReg := srv.registerFakeEndpoint("golden_network", "test", "remote_nsm")
+	nseReg.NetworkServiceManager.RemoteMechanisms = []string{connection2.MechanismType_MPLSoUDP.String()}
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(nsmResponse).To(BeNil())
+	Expect(err.Error()).To(ContainSubstring("no appropriate dataplanes found"))
+}
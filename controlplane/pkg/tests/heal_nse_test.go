@@ -93,18 +93,23 @@ func TestHealRemoteNSE(t *testing.T) {
 	clientConnection2.Xcon.GetLocalDestination().State = connection.State_DOWN
 	srv2.manager.Heal(clientConnection2, nsm.HealState_DstDown)
 
-	// First update, is delete
-	// Second update is update
-	l1.WaitUpdate(2, timeout, t)
-
+	// Source NSM is notified and moves connection to another endpoint, connection id is kept by remote NSM.
 	// Choose a epName not first one.
 	newEpName := "ep1"
 	if epName == newEpName {
 		newEpName = "ep2"
 	}
 
+	Eventually(func() string {
+		return srv.testModel.GetClientConnection(nsmResponse.GetId()).Xcon.GetRemoteDestination().GetNetworkServiceEndpointName()
+	}, timeout).Should(Equal(newEpName))
+
 	clientConnection1_1 := srv.testModel.GetClientConnection(nsmResponse.GetId())
 	Expect(clientConnection1_1.GetId()).To(Equal("1"))
-	Expect(clientConnection1_1.Xcon.GetRemoteDestination().GetId()).To(Equal("4"))
-	Expect(clientConnection1_1.Xcon.GetRemoteDestination().GetNetworkServiceEndpointName()).To(Equal(newEpName))
+	Expect(clientConnection1_1.Xcon.GetRemoteDestination().GetId()).To(Equal("1"))
+
+	// Remote NSM connection is moved to a new endpoint in place.
+	clientConnection2_1 := srv2.testModel.GetClientConnection("1")
+	Expect(clientConnection2_1.Endpoint.GetNetworkserviceEndpoint().GetEndpointName()).To(Equal(newEpName))
+	Expect(clientConnection2_1.Xcon.GetLocalDestination().GetState()).To(Equal(connection.State_UP))
 }
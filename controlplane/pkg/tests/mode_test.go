@@ -37,7 +37,7 @@ func TestModelSelectDataplane(t *testing.T) {
 		RegisteredName: "test_name",
 		SocketLocation: "location",
 	})
-	dp, err := model.SelectDataplane()
+	dp, err := model.SelectDataplane(nil)
 	Expect(dp.RegisteredName).To(Equal("test_name"))
 	Expect(err).To(BeNil())
 }
@@ -46,7 +46,7 @@ func TestModelSelectDataplaneNone(t *testing.T) {
 
 	model := newModel()
 
-	dp, err := model.SelectDataplane()
+	dp, err := model.SelectDataplane(nil)
 	Expect(dp).To(BeNil())
 	Expect(err.Error()).To(Equal("no dataplanes registered"))
 }
@@ -108,6 +108,7 @@ func TestModelAddDeleteEndpoint(t *testing.T) {
 type ListenerImpl struct {
 	endpoints  int
 	dataplanes int
+	updated    []*registry.NetworkServiceEndpoint
 }
 
 func (impl *ListenerImpl) ClientConnectionUpdated(clientConnection *mdl.ClientConnection) {
@@ -127,6 +128,10 @@ func (impl *ListenerImpl) EndpointDeleted(endpoint *registry.NetworkServiceEndpo
 	impl.endpoints--
 }
 
+func (impl *ListenerImpl) EndpointUpdated(endpoint *registry.NetworkServiceEndpoint) {
+	impl.updated = append(impl.updated, endpoint)
+}
+
 func (impl *ListenerImpl) DataplaneAdded(dataplane *mdl.Dataplane) {
 	impl.dataplanes++
 }
@@ -196,6 +201,39 @@ func TestModelListenEndpoint(t *testing.T) {
 	model.RemoveListener(listener)
 }
 
+func TestModelListenEndpointState(t *testing.T) {
+	RegisterTestingT(t)
+
+	model := newModel()
+	listener := &ListenerImpl{}
+	model.AddListener(listener)
+
+	ep := &registry.NSERegistration{
+		NetworkService: &registry.NetworkService{
+			Name: "golden-network",
+		},
+		NetworkserviceEndpoint: &registry.NetworkServiceEndpoint{
+			NetworkServiceName: "golden-network",
+			EndpointName:       "ep1",
+		},
+	}
+	model.AddEndpoint(ep)
+
+	Expect(model.SetEndpointState("ep1", registry.EndpointStateDraining)).To(BeNil())
+
+	// Listeners are notified with updated endpoint, registration previously read is not changed.
+	Expect(len(listener.updated)).To(Equal(1))
+	Expect(listener.updated[0].GetState()).To(Equal(registry.EndpointStateDraining))
+	Expect(ep.GetNetworkserviceEndpoint().GetState()).To(BeEmpty())
+	Expect(model.GetEndpoint("ep1").GetNetworkserviceEndpoint().GetState()).To(Equal(registry.EndpointStateDraining))
+	Expect(model.GetNetworkServiceEndpoints("golden-network")[0].GetNetworkserviceEndpoint().GetState()).To(Equal(registry.EndpointStateDraining))
+
+	Expect(model.SetEndpointState("ep2", registry.EndpointStateDraining)).NotTo(BeNil())
+	Expect(len(listener.updated)).To(Equal(1))
+
+	model.RemoveListener(listener)
+}
+
 func TestModelListenExistingEndpoint(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -0,0 +1,274 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmctl"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+func (srv *nsmdFullServerImpl) runNsmctl(output string, args ...string) (string, error) {
+	return srv.runNsmctlWithRegistry("", output, args...)
+}
+
+func (srv *nsmdFullServerImpl) runNsmctlWithRegistry(registryAddress string, output string, args ...string) (string, error) {
+	client := nsmctl.NewClient(&nsmctl.Config{
+		NsmServerSocket: fmt.Sprintf("127.0.0.1:%d", srv.apiRegistry.nsmdPort),
+		PublicAPI:       srv.serviceRegistry.GetPublicAPI(),
+		Registry:        registryAddress,
+		Output:          output,
+		Timeout:         5 * time.Second,
+	}, nil)
+	out := &bytes.Buffer{}
+	err := nsmctl.Run(context.Background(), client, out, args)
+	return out.String(), err
+}
+
+// testDiscoveryServer - serves test registry of NSMD, so nsmctl could resolve NSMs through it.
+type testDiscoveryServer struct {
+	discovery *nsmdTestServiceDiscovery
+}
+
+func (s *testDiscoveryServer) FindNetworkService(ctx context.Context, request *registry.FindNetworkServiceRequest) (*registry.FindNetworkServiceResponse, error) {
+	return s.discovery.FindNetworkService(ctx, request)
+}
+
+func (s *testDiscoveryServer) ListNetworkServices(ctx context.Context, request *empty.Empty) (*registry.NetworkServiceList, error) {
+	return s.discovery.ListNetworkServices(ctx, request)
+}
+
+func (srv *nsmdFullServerImpl) serveRegistry() (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	grpcServer := grpc.NewServer()
+	registry.RegisterNetworkServiceDiscoveryServer(grpcServer, &testDiscoveryServer{discovery: srv.nseRegistry})
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	return listener.Addr().String(), grpcServer.Stop
+}
+
+// describeOutput - connection description, cross connects contain oneof fields, so it is checked as plain JSON.
+type describeOutput struct {
+	Connection map[string]interface{} `json:"connection"`
+	Hops       []struct {
+		Nsm          string                  `json:"nsm"`
+		ConnectionId string                  `json:"connectionId"`
+		CrossConnect map[string]interface{}  `json:"crossConnect"`
+		Connection   map[string]interface{}  `json:"connection"`
+		Events       []*journal.JournalEvent `json:"events"`
+		Error        string                  `json:"error"`
+	} `json:"hops"`
+}
+
+func TestNsmctlConnections(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newAdminTestServer()
+	defer srv.Stop()
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	out, err := srv.runNsmctl(nsmctl.OutputTable, "connections")
+	Expect(err).To(BeNil())
+	Expect(out).To(ContainSubstring("NETWORK SERVICE"))
+	Expect(out).To(ContainSubstring(nsmResponse.GetId()))
+	Expect(out).To(ContainSubstring("golden_networkprovider"))
+
+	out, err = srv.runNsmctl(nsmctl.OutputJson, "connections")
+	Expect(err).To(BeNil())
+	connections := []map[string]interface{}{}
+	Expect(json.Unmarshal([]byte(out), &connections)).To(BeNil())
+	Expect(len(connections)).To(Equal(1))
+	Expect(connections[0]["connection_id"]).To(Equal(nsmResponse.GetId()))
+	Expect(connections[0]["state"]).To(Equal("ready"))
+
+	out, err = srv.runNsmctl(nsmctl.OutputYaml, "dataplanes")
+	Expect(err).To(BeNil())
+	Expect(out).To(ContainSubstring("name: test_data_plane"))
+
+	_, err = srv.runNsmctl("xml", "connections")
+	Expect(err).NotTo(BeNil())
+	_, err = srv.runNsmctl(nsmctl.OutputTable, "unknown")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestNsmctlCloseConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newAdminTestServer()
+	defer srv.Stop()
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	_, err = srv.runNsmctl(nsmctl.OutputTable, "close")
+	Expect(err).NotTo(BeNil())
+
+	_, err = srv.runNsmctl(nsmctl.OutputTable, "close", nsmResponse.GetId())
+	Expect(err).To(BeNil())
+	Expect(len(srv.testModel.GetAllClientConnections())).To(Equal(0))
+}
+
+func TestNsmctlDescribeRemoteConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+	srv2.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI()))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(len(srv2.testModel.GetAllClientConnections())).To(Equal(1))
+	remoteConnectionId := srv2.testModel.GetAllClientConnections()[0].GetId()
+
+	out, err := srv.runNsmctl(nsmctl.OutputJson, "describe", nsmResponse.GetId())
+	Expect(err).To(BeNil())
+	description := describeOutput{}
+	Expect(json.Unmarshal([]byte(out), &description)).To(BeNil())
+	Expect(description.Connection["remote_nsm"]).To(Equal(srv2.serviceRegistry.GetPublicAPI()))
+	Expect(len(description.Hops)).To(Equal(2))
+
+	local, remote := description.Hops[0], description.Hops[1]
+	Expect(local.ConnectionId).To(Equal(nsmResponse.GetId()))
+	Expect(local.Error).To(Equal(""))
+	Expect(local.CrossConnect).NotTo(BeNil())
+	Expect(local.Events[0].GetType()).To(Equal(journal.EventRequestReceived))
+	Expect(remote.Nsm).To(Equal(srv2.serviceRegistry.GetPublicAPI()))
+	Expect(remote.ConnectionId).To(Equal(remoteConnectionId))
+	// Journal of remote NSM is served on its local socket only.
+	Expect(remote.Events).To(BeEmpty())
+
+	out, err = srv.runNsmctl(nsmctl.OutputTable, "describe", nsmResponse.GetId())
+	Expect(err).To(BeNil())
+	Expect(out).To(ContainSubstring(remoteConnectionId))
+
+	_, err = srv.runNsmctl(nsmctl.OutputTable, "describe", "unknown")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestNsmctlDescribeFromDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+	srv2.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI()))
+	registryAddress, stopRegistry := srv2.serveRegistry()
+	defer stopRegistry()
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(len(srv2.testModel.GetAllClientConnections())).To(Equal(1))
+	remoteConnectionId := srv2.testModel.GetAllClientConnections()[0].GetId()
+
+	describe := func() describeOutput {
+		out, err := srv2.runNsmctlWithRegistry(registryAddress, nsmctl.OutputJson, "describe", remoteConnectionId)
+		Expect(err).To(BeNil())
+		description := describeOutput{}
+		Expect(json.Unmarshal([]byte(out), &description)).To(BeNil())
+		return description
+	}
+
+	// Source NSM is reported as not found if registry does not know it.
+	description := describe()
+	Expect(len(description.Hops)).To(Equal(2))
+	Expect(description.Hops[0].Nsm).To(Equal(srv.serviceRegistry.GetPublicAPI()))
+	Expect(description.Hops[0].Error).To(ContainSubstring("is not found in registry"))
+
+	// Test dataplanes do not report cross connects, so cross connect of source NSM is served by its stub. Registry
+	// knows source NSM as NSM of endpoint of other Network Service.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	grpcServer := grpc.NewServer()
+	monitor := crossconnect_monitor.NewCrossConnectMonitor()
+	crossconnect.RegisterMonitorCrossConnectServer(grpcServer, monitor)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	defer grpcServer.Stop()
+	monitor.Update(srv.testModel.GetClientConnection(nsmResponse.GetId()).Xcon)
+	nsmName := srv.serviceRegistry.GetPublicAPI()
+	srv2.registerFakeEndpoint("silver_network", "test", nsmName)
+	srv2.nseRegistry.managers[nsmName] = &registry.NetworkServiceManager{Name: nsmName, Url: listener.Addr().String()}
+
+	// Connection is followed from NSM of endpoint to NSM of client.
+	Eventually(func() string {
+		return describe().Hops[0].ConnectionId
+	}, 3*time.Second, 100*time.Millisecond).Should(Equal(nsmResponse.GetId()))
+	description = describe()
+	Expect(len(description.Hops)).To(Equal(2))
+	source, local := description.Hops[0], description.Hops[1]
+	Expect(source.Error).To(Equal(""))
+	Expect(source.CrossConnect).NotTo(BeNil())
+	Expect(local.ConnectionId).To(Equal(remoteConnectionId))
+	Expect(local.Events).NotTo(BeEmpty())
+}
+
+func TestNsmctlDescribeInterDomainConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	srvA := newNSMDFullServer()
+	srvB := newNSMDFullServer()
+	defer srvA.Stop()
+	defer srvB.Stop()
+	srvA.testModel.AddDataplane(testDataplane1)
+	srvB.testModel.AddDataplane(testDataplane2)
+	srvB.testModel.AddEndpoint(srvB.registerFakeEndpoint("golden_network", "test", srvB.serviceRegistry.GetPublicAPI()))
+
+	proxyAddress, stopProxy := startProxyNSMD(srvB, nil)
+	defer stopProxy()
+	srvA.serviceRegistry.domains = []*interdomain.Domain{
+		{Name: "cluster-b", RegistryUrl: "registry.cluster-b:5000", ProxyNsmdUrl: proxyAddress},
+	}
+	srvA.serviceRegistry.domainRegistries["cluster-b"] = srvB.nseRegistry
+
+	nsmClient, conn := srvA.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	request := createRequest(false)
+	request.Connection.NetworkService = "golden_network@cluster-b"
+	nsmResponse, err := nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+	remoteConnectionId := srvB.testModel.GetAllClientConnections()[0].GetId()
+
+	// Connection in domain B is seen through its proxy.
+	out, err := srvA.runNsmctl(nsmctl.OutputJson, "describe", nsmResponse.GetId())
+	Expect(err).To(BeNil())
+	description := describeOutput{}
+	Expect(json.Unmarshal([]byte(out), &description)).To(BeNil())
+	Expect(len(description.Hops)).To(Equal(2))
+	remote := description.Hops[1]
+	Expect(remote.Nsm).To(Equal(srvB.serviceRegistry.GetPublicAPI() + "@cluster-b"))
+	Expect(remote.ConnectionId).To(Equal(remoteConnectionId))
+	Expect(remote.Error).To(Equal(""))
+	Expect(remote.Connection["network_service"]).To(Equal("golden_network@cluster-b"))
+}
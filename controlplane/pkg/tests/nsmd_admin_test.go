@@ -0,0 +1,183 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *nsmdFullServerImpl) adminClient() (admin.NSMAdminClient, *grpc.ClientConn) {
+	// Admin service is served on NSM server socket.
+	_, apiConn, err := srv.serviceRegistry.NSMDApiClient()
+	Expect(err).To(BeNil())
+	apiConn.Close()
+	conn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", srv.apiRegistry.nsmdPort), grpc.WithInsecure())
+	Expect(err).To(BeNil())
+	return admin.NewNSMAdminClient(conn), conn
+}
+
+func newAdminTestServer() *nsmdFullServerImpl {
+	srv := newNSMDFullServer()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+	return srv
+}
+
+func TestNSMDAdminInspect(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newAdminTestServer()
+	defer srv.Stop()
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	adminClient, adminConn := srv.adminClient()
+	defer adminConn.Close()
+
+	connections, err := adminClient.GetConnections(context.Background(), &empty.Empty{})
+	Expect(err).To(BeNil())
+	Expect(len(connections.Connections)).To(Equal(1))
+	info := connections.Connections[0]
+	Expect(info.ConnectionId).To(Equal(nsmResponse.GetId()))
+	Expect(info.NetworkService).To(Equal("golden_network"))
+	Expect(info.State).To(Equal("ready"))
+	Expect(info.Endpoint).To(Equal("golden_networkprovider"))
+	Expect(info.Dataplane).To(Equal("test_data_plane"))
+	Expect(info.RemoteNsm).To(Equal(""))
+	Expect(info.Workspace).To(Equal("nsm-1"))
+
+	endpoints, err := adminClient.GetEndpoints(context.Background(), &empty.Empty{})
+	Expect(err).To(BeNil())
+	Expect(len(endpoints.Endpoints)).To(Equal(1))
+	Expect(endpoints.Endpoints[0].Name).To(Equal("golden_networkprovider"))
+	Expect(endpoints.Endpoints[0].NetworkService).To(Equal("golden_network"))
+
+	dataplanes, err := adminClient.GetDataplanes(context.Background(), &empty.Empty{})
+	Expect(err).To(BeNil())
+	Expect(len(dataplanes.Dataplanes)).To(Equal(1))
+	Expect(dataplanes.Dataplanes[0].Name).To(Equal("test_data_plane"))
+	Expect(dataplanes.Dataplanes[0].LocalMechanisms).To(Equal([]string{"KERNEL_INTERFACE"}))
+
+	workspaces, err := adminClient.GetWorkspaces(context.Background(), &empty.Empty{})
+	Expect(err).To(BeNil())
+	Expect(workspaces.Workspaces).To(Equal([]string{"nsm-1"}))
+
+	dump, err := adminClient.DumpModel(context.Background(), &empty.Empty{})
+	Expect(err).To(BeNil())
+	parsed := map[string]interface{}{}
+	Expect(json.Unmarshal([]byte(dump.Json), &parsed)).To(BeNil())
+	Expect(parsed["connections"]).To(HaveLen(1))
+	Expect(parsed["endpoints"]).To(HaveLen(1))
+	Expect(parsed["dataplanes"]).To(HaveLen(1))
+}
+
+func TestNSMDEnumConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newAdminTestServer()
+	defer srv.Stop()
+
+	_, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	_, conn2 := srv.requestNSMConnection("nsm-2")
+	defer conn2.Close()
+
+	client, apiConn, err := srv.serviceRegistry.NSMDApiClient()
+	Expect(err).To(BeNil())
+	defer apiConn.Close()
+	reply, err := client.EnumConnection(context.Background(), &nsmdapi.EnumConnectionRequest{})
+	Expect(err).To(BeNil())
+	Expect(reply.Workspace).To(ConsistOf("nsm-1", "nsm-2"))
+}
+
+func TestNSMDAdminCloseConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newAdminTestServer()
+	defer srv.Stop()
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	adminClient, adminConn := srv.adminClient()
+	defer adminConn.Close()
+
+	_, err = adminClient.CloseConnection(context.Background(), &admin.ConnectionId{ConnectionId: "unknown"})
+	Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+	_, err = adminClient.CloseConnection(context.Background(), &admin.ConnectionId{ConnectionId: nsmResponse.GetId()})
+	Expect(err).To(BeNil())
+	Expect(len(srv.testModel.GetAllClientConnections())).To(Equal(0))
+	Expect(len(srv.serviceRegistry.testDataplaneConnection.closed)).To(Equal(1))
+}
+
+func TestNSMDAdminHealConnection(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newAdminTestServer()
+	defer srv.Stop()
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	adminClient, adminConn := srv.adminClient()
+	defer adminConn.Close()
+
+	_, err = adminClient.HealConnection(context.Background(), &admin.ConnectionId{ConnectionId: "unknown"})
+	Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+	_, err = adminClient.HealConnection(context.Background(), &admin.ConnectionId{ConnectionId: nsmResponse.GetId()})
+	Expect(err).To(BeNil())
+	events := srv.journal.Events(nsmResponse.GetId(), 0)
+	types := []string{}
+	for _, event := range events {
+		types = append(types, event.GetType())
+	}
+	Expect(types).To(ContainElement(journal.EventHealStarted))
+	Expect(types).To(ContainElement(journal.EventHealFinished))
+
+	// Connections which are not ready could not be healed.
+	srv.testModel.GetClientConnection(nsmResponse.GetId()).ConnectionState = model.ClientConnection_Closing
+	_, err = adminClient.HealConnection(context.Background(), &admin.ConnectionId{ConnectionId: nsmResponse.GetId()})
+	Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+	// Connections requested by remote NSM are healed by source NSM only.
+	srv.testModel.AddClientConnection(&model.ClientConnection{
+		ConnectionId:    "remote",
+		ConnectionState: model.ClientConnection_Ready,
+		Xcon: &crossconnect.CrossConnect{
+			Id: "remote",
+			Source: &crossconnect.CrossConnect_RemoteSource{
+				RemoteSource: &remote_connection.Connection{
+					Id:                                   "1",
+					NetworkService:                       "golden_network",
+					SourceNetworkServiceManagerName:      "remote_nsm",
+					DestinationNetworkServiceManagerName: srv.serviceRegistry.GetPublicAPI(),
+				},
+			},
+		},
+	})
+	_, err = adminClient.HealConnection(context.Background(), &admin.ConnectionId{ConnectionId: "remote"})
+	Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+	Expect(srv.testModel.GetClientConnection("remote").Xcon.GetRemoteSource().GetState()).To(Equal(remote_connection.State_UP))
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+type testClusterInfo struct {
+	updates chan []string
+}
+
+func (c *testClusterInfo) GetExcludedPrefixes(ctx context.Context, _ *empty.Empty) (*registry.ExcludedPrefixes, error) {
+	return &registry.ExcludedPrefixes{}, nil
+}
+
+func (c *testClusterInfo) MonitorExcludedPrefixes(_ *empty.Empty, stream registry.ClusterInfo_MonitorExcludedPrefixesServer) error {
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case prefixes := <-c.updates:
+			if err := stream.Send(&registry.ExcludedPrefixes{Prefixes: prefixes}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func startTestClusterInfo() (*testClusterInfo, registry.ClusterInfoClient, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	clusterInfo := &testClusterInfo{updates: make(chan []string, 1)}
+	server := grpc.NewServer()
+	registry.RegisterClusterInfoServer(server, clusterInfo)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithInsecure())
+	Expect(err).To(BeNil())
+	return clusterInfo, registry.NewClusterInfoClient(conn), func() {
+		_ = conn.Close()
+		server.Stop()
+	}
+}
+
+func TestClusterPrefixesAreExcluded(t *testing.T) {
+	RegisterTestingT(t)
+	defer clearConfigEnv()()
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+	settings := nsmd.GetConfig().ManagerSettings()
+	settings.ExcludedPrefixes = []string{"10.96.0.0/12"}
+	srv.manager.UpdateSettings(settings)
+
+	clusterInfo, client, stop := startTestClusterInfo()
+	defer stop()
+	srv.serviceRegistry.clusterInfo = client
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nsmd.StartClusterPrefixesMonitor(ctx, srv.serviceRegistry, srv.manager)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	requestExcludedPrefixes := func() []string {
+		_, err := nsmClient.Request(context.Background(), createRequest(false))
+		Expect(err).To(BeNil())
+		return srv.serviceRegistry.localTestNSE.(*localTestNSENetworkServiceClient).req.Connection.Context.ExcludedPrefixes
+	}
+
+	// Configured prefixes are kept, cluster prefixes are added once.
+	clusterInfo.updates <- []string{"10.96.0.0/12", "10.244.0.0/24"}
+	Eventually(requestExcludedPrefixes, 5*time.Second, 50*time.Millisecond).Should(Equal([]string{"10.96.0.0/12", "10.244.0.0/24"}))
+
+	clusterInfo.updates <- []string{"10.244.1.0/24"}
+	Eventually(requestExcludedPrefixes, 5*time.Second, 50*time.Millisecond).Should(Equal([]string{"10.96.0.0/12", "10.244.1.0/24"}))
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func writeConfigFile(file, content string) string {
+	if file == "" {
+		f, err := ioutil.TempFile("", "nsmd_config")
+		Expect(err).To(BeNil())
+		file = f.Name()
+		Expect(f.Close()).To(BeNil())
+	}
+	Expect(ioutil.WriteFile(file, []byte(content), 0644)).To(BeNil())
+	return file
+}
+
+// clearConfigEnv - unsets environment overriding configuration file, returned function restores it.
+func clearConfigEnv() func() {
+	envs := []string{nsmd.ExcludedPrefixesEnv, nsmd.NsmdApiAddressEnv, nsmd.RegistryAddressEnv, nsmd.ProbesAddressEnv,
+		nsmd.HealTimeoutEnv, nsmd.NseConnectionTimeoutEnv, nsmd.ConnectionLeaseEnv, nsmd.EndpointProbeIntervalEnv,
+		nsmd.PolicyFileEnv}
+	saved := map[string]string{}
+	for _, env := range envs {
+		if value, ok := os.LookupEnv(env); ok {
+			saved[env] = value
+		}
+		_ = os.Unsetenv(env)
+	}
+	return func() {
+		for _, env := range envs {
+			_ = os.Unsetenv(env)
+		}
+		for env, value := range saved {
+			_ = os.Setenv(env, value)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	RegisterTestingT(t)
+	defer clearConfigEnv()()
+
+	file := writeConfigFile("", `
+excludedPrefixes: ["10.96.0.0/12", "10.244.0.0/16"]
+apiAddress: 0.0.0.0:6001
+healTimeout: 30s
+nseConnectionTimeout: 5s
+`)
+	defer os.Remove(file)
+
+	config, err := nsmd.LoadConfig(file)
+	Expect(err).To(BeNil())
+	Expect(config.ExcludedPrefixes).To(Equal([]string{"10.96.0.0/12", "10.244.0.0/16"}))
+	Expect(config.ApiAddress).To(Equal("0.0.0.0:6001"))
+	Expect(config.RegistryAddress).To(Equal(nsmd.DefaultRegistryAddress))
+	Expect(config.HealTimeout.Duration).To(Equal(30 * time.Second))
+	Expect(config.NseConnectionTimeout.Duration).To(Equal(5 * time.Second))
+	Expect(config.ConnectionLease.Duration).To(Equal(nsmd.DefaultConnectionLease))
+	Expect(config.Policy()).To(BeNil())
+
+	// Environment overrides file.
+	_ = os.Setenv(nsmd.ExcludedPrefixesEnv, "172.16.0.0/12")
+	_ = os.Setenv(nsmd.HealTimeoutEnv, "2m")
+	config, err = nsmd.LoadConfig(file)
+	Expect(err).To(BeNil())
+	Expect(config.ExcludedPrefixes).To(Equal([]string{"172.16.0.0/12"}))
+	Expect(config.HealTimeout.Duration).To(Equal(2 * time.Minute))
+	Expect(config.ManagerSettings().NseConnectionTimeout).To(Equal(5 * time.Second))
+
+	// Zero lease disables leases.
+	_ = os.Setenv(nsmd.ConnectionLeaseEnv, "0s")
+	config, err = nsmd.LoadConfig(file)
+	Expect(err).To(BeNil())
+	Expect(config.ManagerSettings().LeaseDuration).To(Equal(time.Duration(0)))
+}
+
+func TestInvalidConfig(t *testing.T) {
+	RegisterTestingT(t)
+	defer clearConfigEnv()()
+
+	for _, content := range []string{
+		`excludedPrefixes: ["abc"]`,
+		`apiAddress: "5001"`,
+		`healTimeout: 15`,
+		`nseConnectionTimeout: -1s`,
+		`connectionLease: -1m`,
+		`connectionLease: 3ns`,
+		`policyFile: /not/existing/policy.yaml`,
+		`excludedPrefixes: 10.96.0.0/12`,
+	} {
+		file := writeConfigFile("", content)
+		_, err := nsmd.LoadConfig(file)
+		Expect(err).NotTo(BeNil(), content)
+		_ = os.Remove(file)
+	}
+
+	_, err := nsmd.LoadConfig("/not/existing/nsmd.yaml")
+	Expect(err).NotTo(BeNil())
+
+	_ = os.Setenv(nsmd.ConnectionLeaseEnv, "forever")
+	_, err = nsmd.LoadConfig("")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestConfigReload(t *testing.T) {
+	RegisterTestingT(t)
+	defer clearConfigEnv()()
+	defer nsmd.SetConfig(nil)
+
+	policyFile := writeConfigFile("", `
+rules:
+- name: no-nsm-1
+  action: deny
+  workspaces: ["nsm-1"]
+`)
+	defer os.Remove(policyFile)
+	file := writeConfigFile("", `excludedPrefixes: ["10.96.0.0/12"]`)
+	defer os.Remove(file)
+
+	watcher, err := nsmd.NewConfigWatcher(file)
+	Expect(err).To(BeNil())
+	Expect(nsmd.GetExcludedPrefixes()).To(Equal([]string{"10.96.0.0/12"}))
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+	watcher.AddListener(func(config *nsmd.Config) {
+		srv.manager.UpdateSettings(config.ManagerSettings())
+		srv.policyEngine.SetPolicy(config.Policy())
+	})
+
+	writeConfigFile(file, `
+excludedPrefixes: ["10.244.0.0/16"]
+policyFile: `+policyFile)
+	Expect(watcher.Reload()).To(BeNil())
+	Expect(nsmd.GetExcludedPrefixes()).To(Equal([]string{"10.244.0.0/16"}))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	_, err = nsmClient.Request(context.Background(), createRequest(false))
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+	nsmClient2, conn2 := srv.requestNSMConnection("nsm-2")
+	defer conn2.Close()
+	_, err = nsmClient2.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	localNSE := srv.serviceRegistry.localTestNSE.(*localTestNSENetworkServiceClient)
+	Expect(localNSE.req.Connection.Context.ExcludedPrefixes).To(Equal([]string{"10.244.0.0/16"}))
+
+	// Invalid configuration is not applied.
+	writeConfigFile(file, `excludedPrefixes: ["abc"]`)
+	Expect(watcher.Reload()).NotTo(BeNil())
+	Expect(watcher.Config().ExcludedPrefixes).To(Equal([]string{"10.244.0.0/16"}))
+	Expect(nsmd.GetExcludedPrefixes()).To(Equal([]string{"10.244.0.0/16"}))
+}
+
+func TestConfigWatcherDetectsChanges(t *testing.T) {
+	RegisterTestingT(t)
+	defer clearConfigEnv()()
+	defer nsmd.SetConfig(nil)
+
+	file := writeConfigFile("", `healTimeout: 30s`)
+	defer os.Remove(file)
+
+	watcher, err := nsmd.NewConfigWatcher(file)
+	Expect(err).To(BeNil())
+	reloaded := make(chan *nsmd.Config, 1)
+	watcher.AddListener(func(config *nsmd.Config) {
+		reloaded <- config
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher.Start(ctx, 10*time.Millisecond)
+
+	writeConfigFile(file, `healTimeout: 45s`)
+	// Modification time could be the same if file is changed fast, so it is moved forward explicitly.
+	modTime := time.Now().Add(time.Second)
+	Expect(os.Chtimes(file, modTime, modTime)).To(BeNil())
+
+	select {
+	case config := <-reloaded:
+		Expect(config.HealTimeout.Duration).To(Equal(45 * time.Second))
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration is not reloaded")
+	}
+}
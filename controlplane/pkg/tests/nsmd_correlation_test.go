@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNSMDCorrelationId(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// Id passed by client is kept.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), tools.CorrelationIdKey, "client-id")
+	nsmResponse, err := nsmClient.Request(ctx, createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(srv.serviceRegistry.testDataplaneConnection.correlationIds).To(Equal([]string{"client-id"}))
+
+	// Id is created by NSM if client does not pass one.
+	_, err = nsmClient.Close(context.Background(), nsmResponse)
+	Expect(err).To(BeNil())
+	ids := srv.serviceRegistry.testDataplaneConnection.correlationIds
+	Expect(len(ids)).To(Equal(2))
+	Expect(ids[1]).NotTo(BeEmpty())
+	Expect(ids[1]).NotTo(Equal("client-id"))
+}
+
+func TestNSMDCorrelationIdRemoteNSMD(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+	nseReg := srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI())
+	srv2.testModel.AddEndpoint(nseReg)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	_, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	// Both dataplanes are programmed with the id created by first NSM.
+	ids := srv.serviceRegistry.testDataplaneConnection.correlationIds
+	Expect(len(ids)).To(Equal(1))
+	Expect(ids[0]).NotTo(BeEmpty())
+	Expect(srv2.serviceRegistry.testDataplaneConnection.correlationIds).To(Equal(ids))
+}
+
+func TestEnsureCorrelationId(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx, id := tools.EnsureCorrelationId(context.Background())
+	Expect(id).NotTo(BeEmpty())
+	Expect(tools.CorrelationId(ctx)).To(Equal(id))
+
+	// Id is not changed once assigned.
+	_, again := tools.EnsureCorrelationId(ctx)
+	Expect(again).To(Equal(id))
+
+	// Incoming id is used.
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tools.CorrelationIdKey, "incoming-id"))
+	ctx, id = tools.EnsureCorrelationId(incoming)
+	Expect(id).To(Equal("incoming-id"))
+	md, _ := metadata.FromOutgoingContext(ctx)
+	Expect(md.Get(tools.CorrelationIdKey)).To(Equal([]string{"incoming-id"}))
+}
+
+func TestCorrelationIdLog(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(tools.Log(context.Background()).Data).To(BeEmpty())
+
+	// Entries logged with context carry its correlation id.
+	ctx, id := tools.EnsureCorrelationId(context.Background())
+	Expect(tools.Log(ctx).Data[tools.CorrelationIdKey]).To(Equal(id))
+
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(tools.CorrelationIdKey, "incoming-id"))
+	Expect(tools.Log(incoming).Data[tools.CorrelationIdKey]).To(Equal("incoming-id"))
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	. "github.com/onsi/gomega"
+)
+
+func TestNSMDDrainEndpointMigratesConnections(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpointWithName("golden_network", "test", srv.serviceRegistry.GetPublicAPI(), "nse-1"))
+	srv.testModel.AddEndpoint(srv.registerFakeEndpointWithName("golden_network", "test", srv.serviceRegistry.GetPublicAPI(), "nse-2"))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	drainedConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	drainedEndpoint := drainedConnection.Endpoint.GetNetworkserviceEndpoint().GetEndpointName()
+	drainedDestination := drainedConnection.Xcon.GetLocalDestination().GetId()
+
+	registryServer := nsmd.NewRegistryServer(srv.testModel, nil, srv.manager, srv.serviceRegistry)
+	_, err = registryServer.DrainNSE(context.Background(), &registry.DrainNSERequest{EndpointName: drainedEndpoint})
+	Expect(err).To(BeNil())
+
+	// Endpoint is draining in both upstream registry and model.
+	Expect(srv.nseRegistry.endpoints[drainedEndpoint].GetState()).To(Equal(registry.EndpointStateDraining))
+	Expect(srv.testModel.GetEndpoint(drainedEndpoint).GetNetworkserviceEndpoint().GetState()).To(Equal(registry.EndpointStateDraining))
+
+	// Connection is moved to another endpoint.
+	migrated := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(migrated).ToNot(BeNil())
+	Expect(migrated.Endpoint.GetNetworkserviceEndpoint().GetEndpointName()).ToNot(Equal(drainedEndpoint))
+
+	// Cross connect is updated in place to a new destination, previous one is closed after that.
+	Expect(len(srv.serviceRegistry.testDataplaneConnection.updates)).To(Equal(1))
+	update := srv.serviceRegistry.testDataplaneConnection.updates[0]
+	Expect(update.GetPrevious().GetLocalDestination().GetId()).To(Equal(drainedDestination))
+	Expect(update.GetCurrent().GetLocalDestination().GetId()).To(Equal(migrated.Xcon.GetLocalDestination().GetId()))
+	Expect(srv.serviceRegistry.testDataplaneConnection.closed).To(BeEmpty())
+	Expect(srv.serviceRegistry.localTestNSE.(*localTestNSENetworkServiceClient).closed).To(Equal([]string{drainedDestination}))
+
+	// New connections avoid draining endpoint.
+	nsmResponse2, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(srv.testModel.GetClientConnection(nsmResponse2.GetId()).Endpoint.GetNetworkserviceEndpoint().GetEndpointName()).ToNot(Equal(drainedEndpoint))
+}
+
+func TestNSMDDrainLastEndpoint(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpointWithName("golden_network", "test", srv.serviceRegistry.GetPublicAPI(), "nse-1"))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	err = srv.manager.DrainEndpoint(context.Background(), "nse-1")
+	Expect(err).To(BeNil())
+
+	// There is no other endpoint to migrate connection to, so it is closed.
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId())).To(BeNil())
+
+	Expect(srv.manager.DrainEndpoint(context.Background(), "unknown")).NotTo(BeNil())
+}
+
+func TestNSMDDrainEndpointNotifiesRemoteSource(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+
+	srv2.testModel.AddEndpoint(srv.registerFakeEndpointWithName("golden_network", "test", srv2.serviceRegistry.GetPublicAPI(), "ep1"))
+	srv2.testModel.AddEndpoint(srv.registerFakeEndpointWithName("golden_network", "test", srv2.serviceRegistry.GetPublicAPI(), "ep2"))
+
+	l1 := newTestConnectionModelListener()
+	srv.testModel.AddListener(l1)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	l1.WaitAdd(1, time.Second*10, t)
+
+	remoteId := srv.testModel.GetClientConnection(nsmResponse.GetId()).Xcon.GetRemoteDestination().GetId()
+	drainedEndpoint := srv2.testModel.GetClientConnection(remoteId).Endpoint.GetNetworkserviceEndpoint().GetEndpointName()
+
+	// Remote NSM moves connection on its own, so draining waits for it.
+	Expect(srv2.manager.DrainEndpoint(context.Background(), drainedEndpoint)).To(BeNil())
+
+	migrated := srv2.testModel.GetClientConnection(remoteId)
+	Expect(migrated).ToNot(BeNil())
+	Expect(migrated.Endpoint.GetNetworkserviceEndpoint().GetEndpointName()).ToNot(Equal(drainedEndpoint))
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId()).Xcon.GetRemoteDestination().GetNetworkServiceEndpointName()).ToNot(Equal(drainedEndpoint))
+}
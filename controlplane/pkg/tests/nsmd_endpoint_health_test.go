@@ -0,0 +1,81 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestNSMDUnhealthyEndpointConnectionsHealed(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpointWithName("golden_network", "test", srv.serviceRegistry.GetPublicAPI(), "nse-1"))
+	srv.testModel.AddEndpoint(srv.registerFakeEndpointWithName("golden_network", "test", srv.serviceRegistry.GetPublicAPI(), "nse-2"))
+
+	monitor := nsmd.NewEndpointHealthMonitor(srv.testModel, srv.serviceRegistry,
+		services.NewClientConnectionManager(srv.testModel, srv.manager, srv.serviceRegistry))
+	srv.testModel.AddListener(monitor)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	unhealthyEndpoint := clientConnection.Endpoint.GetNetworkserviceEndpoint().GetEndpointName()
+	srv.serviceRegistry.endpointHealth[unhealthyEndpoint] = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+
+	// Endpoint is marked unhealthy only after several failed checks.
+	for i := 1; i < nsmd.EndpointProbeFailureThreshold; i++ {
+		monitor.ProbeEndpoints(context.Background())
+	}
+	Expect(srv.testModel.IsEndpointHealthy(unhealthyEndpoint)).To(BeTrue())
+
+	monitor.ProbeEndpoints(context.Background())
+	Expect(srv.testModel.IsEndpointHealthy(unhealthyEndpoint)).To(BeFalse())
+
+	// Connection is healed to another endpoint.
+	Eventually(func() string {
+		cc := srv.testModel.GetClientConnection(nsmResponse.GetId())
+		if cc == nil || cc.ConnectionState != model.ClientConnection_Ready {
+			return ""
+		}
+		return cc.Endpoint.GetNetworkserviceEndpoint().GetEndpointName()
+	}, 5*time.Second).ShouldNot(Or(Equal(""), Equal(unhealthyEndpoint)))
+
+	// Endpoint is back once it passes a health check.
+	delete(srv.serviceRegistry.endpointHealth, unhealthyEndpoint)
+	monitor.ProbeEndpoints(context.Background())
+	Expect(srv.testModel.IsEndpointHealthy(unhealthyEndpoint)).To(BeTrue())
+}
+
+func TestNSMDUnhealthyEndpointSkipped(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpointWithName("golden_network", "test", srv.serviceRegistry.GetPublicAPI(), "nse-1"))
+	srv.testModel.SetEndpointHealthy("nse-1", false)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	_, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).NotTo(BeNil())
+
+	srv.testModel.SetEndpointHealthy("nse-1", true)
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId()).Endpoint.GetNetworkserviceEndpoint().GetEndpointName()).To(Equal("nse-1"))
+}
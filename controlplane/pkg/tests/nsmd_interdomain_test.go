@@ -0,0 +1,233 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain/proxy"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+// startProxyNSMD - starts proxy relaying to NSMs of srv domain, proxy is authenticated by provider if it is not nil.
+func startProxyNSMD(srv *nsmdFullServerImpl, provider *security.Provider) (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	grpcServer := grpc.NewServer(provider.ServerOptions()...)
+	proxyRegistry := *srv.serviceRegistry
+	proxyRegistry.securityProvider = provider
+	proxyServer := proxy.NewProxyNetworkServiceServer(&proxyRegistry)
+	remote_networkservice.RegisterNetworkServiceServer(grpcServer, proxyServer)
+	remote_connection.RegisterMonitorConnectionServer(grpcServer, proxyServer)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	return listener.Addr().String(), grpcServer.Stop
+}
+
+func TestNSMDInterDomainRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	srvA := newNSMDFullServer()
+	srvB := newNSMDFullServer()
+	defer srvA.Stop()
+	defer srvB.Stop()
+
+	srvA.testModel.AddDataplane(testDataplane1)
+	srvB.testModel.AddDataplane(testDataplane2)
+
+	nseReg := srvB.registerFakeEndpoint("golden_network", "test", srvB.serviceRegistry.GetPublicAPI())
+	srvB.testModel.AddEndpoint(nseReg)
+
+	proxyAddress, stopProxy := startProxyNSMD(srvB, nil)
+	defer stopProxy()
+	srvA.serviceRegistry.domains = []*interdomain.Domain{
+		{Name: "cluster-b", RegistryUrl: "registry.cluster-b:5000", ProxyNsmdUrl: proxyAddress},
+	}
+	srvA.serviceRegistry.domainRegistries["cluster-b"] = srvB.nseRegistry
+
+	nsmClient, conn := srvA.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	request := createRequest(false)
+	request.Connection.NetworkService = "golden_network@cluster-b"
+	nsmResponse, err := nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetNetworkService()).To(Equal("golden_network@cluster-b"))
+
+	// Connection in domain A goes to NSM of domain B through proxy.
+	clientConnection := srvA.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(clientConnection.RemoteNsm.GetName()).To(Equal(srvB.serviceRegistry.GetPublicAPI() + "@cluster-b"))
+	Expect(clientConnection.RemoteNsm.GetUrl()).To(Equal(proxyAddress))
+	remoteDestination := clientConnection.Xcon.GetRemoteDestination()
+	Expect(remoteDestination.GetNetworkService()).To(Equal("golden_network@cluster-b"))
+	Expect(remoteDestination.GetDestinationNetworkServiceManagerName()).To(Equal(clientConnection.RemoteNsm.GetName()))
+
+	// NSM of domain B sees only names of its domain.
+	Expect(len(srvB.testModel.GetAllClientConnections())).To(Equal(1))
+	remoteSource := srvB.testModel.GetAllClientConnections()[0].Xcon.GetRemoteSource()
+	Expect(remoteSource.GetNetworkService()).To(Equal("golden_network"))
+	Expect(remoteSource.GetDestinationNetworkServiceManagerName()).To(Equal(srvB.serviceRegistry.GetPublicAPI()))
+
+	// Close is relayed to domain B as well.
+	_, err = nsmClient.Close(context.Background(), nsmResponse)
+	Expect(err).To(BeNil())
+	Expect(len(srvB.testModel.GetAllClientConnections())).To(Equal(0))
+}
+
+func TestNSMDInterDomainProxyRediscoversFailedNsm(t *testing.T) {
+	RegisterTestingT(t)
+
+	srvA := newNSMDFullServer()
+	srvB := newNSMDFullServer()
+	defer srvA.Stop()
+	defer srvB.Stop()
+
+	srvA.testModel.AddDataplane(testDataplane1)
+	srvB.testModel.AddDataplane(testDataplane2)
+
+	nseReg := srvB.registerFakeEndpoint("golden_network", "test", srvB.serviceRegistry.GetPublicAPI())
+	srvB.testModel.AddEndpoint(nseReg)
+	nsmName := nseReg.GetNetworkServiceManager().GetName()
+
+	proxyAddress, stopProxy := startProxyNSMD(srvB, nil)
+	defer stopProxy()
+	srvA.serviceRegistry.domains = []*interdomain.Domain{
+		{Name: "cluster-b", RegistryUrl: "registry.cluster-b:5000", ProxyNsmdUrl: proxyAddress},
+	}
+	srvA.serviceRegistry.domainRegistries["cluster-b"] = srvB.nseRegistry
+
+	nsmClient, conn := srvA.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// NSM of domain B is registered with URL of server not serving network services, so proxy fails to relay request.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	staleServer := grpc.NewServer()
+	go func() {
+		_ = staleServer.Serve(listener)
+	}()
+	defer staleServer.Stop()
+	srvB.nseRegistry.managers[nsmName] = &registry.NetworkServiceManager{Name: nsmName, Url: listener.Addr().String()}
+	request := createRequest(false)
+	request.Connection.NetworkService = "golden_network@cluster-b"
+	_, err = nsmClient.Request(context.Background(), request)
+	Expect(err).NotTo(BeNil())
+
+	// NSM is registered again with actual URL, proxy does not keep using the failed one.
+	srvB.nseRegistry.managers[nsmName] = &registry.NetworkServiceManager{Name: nsmName, Url: srvB.serviceRegistry.GetPublicAPI()}
+	request = createRequest(false)
+	request.Connection.NetworkService = "golden_network@cluster-b"
+	_, err = nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+	Expect(len(srvB.testModel.GetAllClientConnections())).To(Equal(1))
+}
+
+// newInterDomainNSMDs - creates NSMDs of domains A and B connected through proxy of domain B with mutual TLS.
+func newInterDomainNSMDs(ca *testCA, trustedProxy string) (*nsmdFullServerImpl, *nsmdFullServerImpl, func()) {
+	provider := func(nsmName string) *security.Provider {
+		p := ca.newProvider(nsmName)
+		if trustedProxy != "" {
+			p.AddTrustedProxy(trustedProxy)
+		}
+		return p
+	}
+	srvA := newSecureNSMDFullServer(provider)
+	srvB := newSecureNSMDFullServer(provider)
+	srvA.testModel.AddDataplane(testDataplane1)
+	srvB.testModel.AddDataplane(testDataplane2)
+	srvB.testModel.AddEndpoint(srvB.registerFakeEndpoint("golden_network", "test", srvB.serviceRegistry.GetPublicAPI()))
+
+	proxyAddress, stopProxy := startProxyNSMD(srvB, provider("proxy-b"))
+	srvA.serviceRegistry.domains = []*interdomain.Domain{
+		{Name: "cluster-b", RegistryUrl: "registry.cluster-b:5000", ProxyNsmdUrl: proxyAddress},
+	}
+	srvA.serviceRegistry.domainRegistries["cluster-b"] = srvB.nseRegistry
+	return srvA, srvB, func() {
+		stopProxy()
+		srvA.Stop()
+		srvB.Stop()
+	}
+}
+
+func TestNSMDInterDomainRequestMutualTLS(t *testing.T) {
+	RegisterTestingT(t)
+
+	srvA, srvB, stop := newInterDomainNSMDs(newTestCA(), "proxy-b")
+	defer stop()
+
+	nsmClient, conn := srvA.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	request := createRequest(false)
+	request.Connection.NetworkService = "golden_network@cluster-b"
+	nsmResponse, err := nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+	Expect(len(srvB.testModel.GetAllClientConnections())).To(Equal(1))
+
+	_, err = nsmClient.Close(context.Background(), nsmResponse)
+	Expect(err).To(BeNil())
+	Expect(len(srvB.testModel.GetAllClientConnections())).To(Equal(0))
+}
+
+func TestNSMDInterDomainRequestUntrustedProxy(t *testing.T) {
+	RegisterTestingT(t)
+
+	srvA, srvB, stop := newInterDomainNSMDs(newTestCA(), "")
+	defer stop()
+
+	nsmClient, conn := srvA.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// Proxy certificate is not issued for NSMs it relays connections for.
+	request := createRequest(false)
+	request.Connection.NetworkService = "golden_network@cluster-b"
+	_, err := nsmClient.Request(context.Background(), request)
+	Expect(err).NotTo(BeNil())
+	Expect(len(srvB.testModel.GetAllClientConnections())).To(Equal(0))
+}
+
+func TestNSMDInterDomainUnknownDomain(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.testModel.AddDataplane(testDataplane1)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	request := createRequest(false)
+	request.Connection.NetworkService = "golden_network@unknown"
+	_, err := nsmClient.Request(context.Background(), request)
+	Expect(err).NotTo(BeNil())
+	Expect(err.Error()).To(ContainSubstring("unknown domain unknown"))
+}
+
+func TestSplitDomain(t *testing.T) {
+	RegisterTestingT(t)
+
+	name, domain := interdomain.SplitDomain("golden_network@cluster-b")
+	Expect(name).To(Equal("golden_network"))
+	Expect(domain).To(Equal("cluster-b"))
+
+	name, domain = interdomain.SplitDomain("golden_network")
+	Expect(name).To(Equal("golden_network"))
+	Expect(domain).To(Equal(""))
+
+	Expect(interdomain.JoinDomain("golden_network", "")).To(Equal("golden_network"))
+
+	domains, err := interdomain.ParseDomains("cluster-b=10.0.0.1:5000,10.0.0.1:5005; cluster-c=10.0.0.2:5000,10.0.0.2:5005")
+	Expect(err).To(BeNil())
+	Expect(len(domains)).To(Equal(2))
+	Expect(domains[1]).To(Equal(&interdomain.Domain{Name: "cluster-c", RegistryUrl: "10.0.0.2:5000", ProxyNsmdUrl: "10.0.0.2:5005"}))
+
+	_, err = interdomain.ParseDomains("cluster-b=10.0.0.1:5000")
+	Expect(err).NotTo(BeNil())
+}
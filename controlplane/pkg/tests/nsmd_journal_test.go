@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	nsm2 "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connection_journal"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func journalEventTypes(events []*journal.JournalEvent) []string {
+	types := []string{}
+	for _, event := range events {
+		types = append(types, event.GetType())
+	}
+	return types
+}
+
+func TestNSMDConnectionJournal(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	_, err = nsmClient.Close(context.Background(), nsmResponse)
+	Expect(err).To(BeNil())
+
+	// Journal is served on local NSM socket only.
+	apiConn, err := grpc.Dial(srv.serviceRegistry.GetPublicAPI(), grpc.WithInsecure())
+	Expect(err).To(BeNil())
+	defer apiConn.Close()
+	_, err = journal.NewConnectionJournalClient(apiConn).GetEvents(context.Background(), &journal.JournalQuery{})
+	Expect(status.Code(err)).To(Equal(codes.Unimplemented))
+
+	_, localConn, err := srv.serviceRegistry.NSMDApiClient()
+	Expect(err).To(BeNil())
+	defer localConn.Close()
+	events, err := journal.NewConnectionJournalClient(localConn).GetEvents(context.Background(), &journal.JournalQuery{
+		ConnectionId: nsmResponse.GetId(),
+	})
+	Expect(err).To(BeNil())
+	Expect(journalEventTypes(events.GetEvents())).To(Equal([]string{
+		journal.EventRequestReceived,
+		journal.EventMechanismSelected,
+		journal.EventEndpointSelected,
+		journal.EventAdded,
+		journal.EventDataplaneProgrammed,
+		journal.EventClosed,
+		journal.EventDeleted,
+	}))
+	Expect(events.GetEvents()[5].GetReason()).To(Equal("closed by source"))
+	for _, event := range events.GetEvents() {
+		Expect(event.GetConnectionId()).To(Equal(nsmResponse.GetId()))
+		Expect(event.GetTime()).NotTo(BeNil())
+	}
+}
+
+func TestNSMDConnectionJournalHeal(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	srv.manager.Heal(srv.testModel.GetClientConnection(nsmResponse.GetId()), nsm2.HealState_DstDown)
+
+	events := srv.journal.Events(nsmResponse.GetId(), 0)
+	types := journalEventTypes(events)
+	Expect(types).To(ContainElement(journal.EventHealStarted))
+	last := events[len(events)-1]
+	Expect(last.GetType()).To(Equal(journal.EventHealFinished))
+	Expect(last.GetReason()).To(Equal("connection is ready"))
+
+	// Only last events are returned if limit is set.
+	Expect(srv.journal.Events(nsmResponse.GetId(), 1)).To(Equal([]*journal.JournalEvent{last}))
+}
+
+func TestConnectionJournalBounded(t *testing.T) {
+	RegisterTestingT(t)
+
+	output := &bytes.Buffer{}
+	j := connection_journal.NewJournal(3)
+	j.SetOutput(output)
+	for _, id := range []string{"1", "2", "1", "2", "1"} {
+		j.Record(id, journal.EventRequestReceived, "request of "+id)
+	}
+
+	// Oldest events are dropped.
+	events := j.Events("", 0)
+	Expect(len(events)).To(Equal(3))
+	Expect(events[0].GetReason()).To(Equal("request of 1"))
+	Expect(events[1].GetReason()).To(Equal("request of 2"))
+	Expect(events[2].GetReason()).To(Equal("request of 1"))
+	Expect(len(j.Events("1", 0))).To(Equal(2))
+
+	// All events are written to output once journal is closed.
+	j.Close()
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	Expect(len(lines)).To(Equal(5))
+	Expect(lines[4]).To(ContainSubstring(`"connectionId":"1"`))
+	Expect(lines[4]).To(ContainSubstring(`"type":"REQUEST_RECEIVED"`))
+
+	// Nil journal records nothing.
+	var nilJournal *connection_journal.Journal
+	nilJournal.Record("1", journal.EventClosed, "closed")
+	Expect(nilJournal.Events("1", 0)).To(BeNil())
+}
+
+func TestConnectionJournalFileRotated(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "journal_test")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+	file := path.Join(dir, "journal.log")
+
+	output, err := connection_journal.NewRotatingFile(file, 300)
+	Expect(err).To(BeNil())
+	j := connection_journal.NewJournal(10)
+	j.SetOutput(output)
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		j.Record(id, journal.EventRequestReceived, "request of "+id)
+	}
+	j.Close()
+	Expect(output.Close()).To(BeNil())
+
+	// Size of journal files is bounded, last events are kept in current file.
+	for _, name := range []string{file, file + ".1"} {
+		info, err := os.Stat(name)
+		Expect(err).To(BeNil())
+		Expect(info.Size()).To(BeNumerically("<=", 300))
+	}
+	data, err := ioutil.ReadFile(file)
+	Expect(err).To(BeNil())
+	Expect(string(data)).To(ContainSubstring("request of 5"))
+}
@@ -0,0 +1,204 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	. "github.com/onsi/gomega"
+)
+
+const testConnectionLease = 10 * time.Minute
+
+// setConnectionLease - replaces lease of connections used by NSMD, leases are disabled if it is zero.
+func setConnectionLease(srv *nsmdFullServerImpl, lease time.Duration) {
+	settings := nsmd.DefaultConfig().ManagerSettings()
+	settings.LeaseDuration = lease
+	srv.manager.UpdateSettings(settings)
+}
+
+func TestNSMDConnectionLeaseExpired(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	setConnectionLease(srv, testConnectionLease)
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetExpires()).ToNot(BeNil())
+	expires, err := ptypes.Timestamp(nsmResponse.GetExpires())
+	Expect(err).To(BeNil())
+	Expect(expires.After(time.Now())).To(BeTrue())
+	Expect(expires.Before(time.Now().Add(testConnectionLease + time.Second))).To(BeTrue())
+
+	// Lease is not expired yet.
+	srv.manager.ReapExpiredConnections(time.Now())
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId())).ToNot(BeNil())
+
+	srv.manager.ReapExpiredConnections(expires.Add(time.Second))
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId())).To(BeNil())
+	Expect(len(srv.serviceRegistry.testDataplaneConnection.closed)).To(Equal(1))
+}
+
+func TestNSMDConnectionLeaseRefresh(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	setConnectionLease(srv, testConnectionLease)
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	expires, err := ptypes.Timestamp(nsmResponse.GetExpires())
+	Expect(err).To(BeNil())
+
+	<-time.After(10 * time.Millisecond)
+	refreshed, err := nsmClient.Request(context.Background(), &networkservice.NetworkServiceRequest{
+		Connection:           nsmResponse,
+		MechanismPreferences: []*connection.Mechanism{nsmResponse.GetMechanism()},
+	})
+	Expect(err).To(BeNil())
+	Expect(refreshed.GetId()).To(Equal(nsmResponse.GetId()))
+	refreshedExpires, err := ptypes.Timestamp(refreshed.GetExpires())
+	Expect(err).To(BeNil())
+	Expect(refreshedExpires.After(expires)).To(BeTrue())
+
+	// Connection is alive, since it was refreshed.
+	srv.manager.ReapExpiredConnections(expires.Add(time.Millisecond))
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId())).ToNot(BeNil())
+}
+
+func TestNSMDRemoteConnectionLeaseExpired(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+	setConnectionLease(srv, testConnectionLease)
+	setConnectionLease(srv2, testConnectionLease)
+
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+
+	nseReg := srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI())
+	srv2.testModel.AddEndpoint(nseReg)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	remoteDestination := clientConnection.Xcon.GetRemoteDestination()
+	Expect(remoteDestination.GetExpires()).ToNot(BeNil())
+	remoteExpires, err := ptypes.Timestamp(remoteDestination.GetExpires())
+	Expect(err).To(BeNil())
+
+	// Remote NSM closes connection, since client NSM is not refreshing it anymore.
+	srv2.manager.ReapExpiredConnections(remoteExpires.Add(time.Second))
+	Expect(srv2.testModel.GetClientConnection(remoteDestination.GetId())).To(BeNil())
+}
+
+func TestNSMDConnectionLeaseDisabled(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	setConnectionLease(srv, 0)
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetExpires()).To(BeNil())
+
+	srv.manager.ReapExpiredConnections(time.Now().Add(time.Hour))
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId())).ToNot(BeNil())
+}
+
+func TestNSMDConnectionLeaseByDefault(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// Every connection carries an expiry, unless leases are disabled by configuration.
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	expires, err := ptypes.Timestamp(nsmResponse.GetExpires())
+	Expect(err).To(BeNil())
+	Expect(expires.After(time.Now().Add(nsmd.DefaultConnectionLease - time.Minute))).To(BeTrue())
+}
+
+func TestNSMDRemoteConnectionLeaseRefreshedByPeer(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+	// Local connections have no lease, but remote NSM still expects its connections to be refreshed.
+	setConnectionLease(srv, 0)
+	setConnectionLease(srv2, testConnectionLease)
+
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+
+	nseReg := srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI())
+	srv2.testModel.AddEndpoint(nseReg)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetExpires()).To(BeNil())
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	remoteDestination := clientConnection.Xcon.GetRemoteDestination()
+	remoteExpires, err := ptypes.Timestamp(remoteDestination.GetExpires())
+	Expect(err).To(BeNil())
+
+	// Lease is not expired soon, so it is not refreshed.
+	srv.manager.RefreshRemoteLeases(context.Background(), time.Now())
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId()).Xcon.GetRemoteDestination().GetExpires()).To(Equal(remoteDestination.GetExpires()))
+
+	<-time.After(10 * time.Millisecond)
+	srv.manager.RefreshRemoteLeases(context.Background(), remoteExpires.Add(time.Second))
+	refreshed := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(refreshed).ToNot(BeNil())
+	refreshedExpires, err := ptypes.Timestamp(refreshed.Xcon.GetRemoteDestination().GetExpires())
+	Expect(err).To(BeNil())
+	Expect(refreshedExpires.After(remoteExpires)).To(BeTrue())
+	// Source lease is not extended by refresh of remote lease.
+	Expect(refreshed.Xcon.GetLocalSource().GetExpires()).To(BeNil())
+
+	// Remote NSM keeps connection, since it was refreshed.
+	srv2.manager.ReapExpiredConnections(remoteExpires.Add(time.Millisecond))
+	Expect(srv2.testModel.GetClientConnection(refreshed.Xcon.GetRemoteDestination().GetId())).ToNot(BeNil())
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/sirupsen/logrus"
 	context2 "golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"os"
 	"strings"
 	"testing"
@@ -113,6 +115,65 @@ func TestNSMDRequestClientConnectionRequest(t *testing.T) {
 	logrus.Print("End of test")
 }
 
+func TestNSMDRequestUpdatesDataplaneInPlace(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	request := createRequest(false)
+	request.Connection.Id = nsmResponse.GetId()
+	request.MechanismPreferences[0].Parameters[connection.InterfaceNameKey] = "icmp-responder2"
+	nsmResponse, err = nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+
+	Expect(len(srv.serviceRegistry.testDataplaneConnection.connections)).To(Equal(1))
+	Expect(len(srv.serviceRegistry.testDataplaneConnection.updates)).To(Equal(1))
+	update := srv.serviceRegistry.testDataplaneConnection.updates[0]
+	Expect(update.GetPrevious().GetLocalSource().GetMechanism().GetParameters()[connection.InterfaceNameKey]).To(Equal("icmp-responder1"))
+	Expect(update.GetCurrent().GetLocalSource().GetMechanism().GetParameters()[connection.InterfaceNameKey]).To(Equal("icmp-responder2"))
+}
+
+func TestNSMDRequestClosesCrossConnectIfUpdateFails(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	srv.serviceRegistry.testDataplaneConnection.updateErrors = []error{status.Error(codes.Unimplemented, "update is not supported")}
+	request := createRequest(false)
+	request.Connection.Id = nsmResponse.GetId()
+	request.MechanismPreferences[0].Parameters[connection.InterfaceNameKey] = "icmp-responder2"
+	nsmResponse, err = nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+
+	// Previous cross connect is closed and new one is requested instead.
+	dataplaneConnection := srv.serviceRegistry.testDataplaneConnection
+	Expect(len(dataplaneConnection.updates)).To(Equal(0))
+	Expect(len(dataplaneConnection.closed)).To(Equal(1))
+	Expect(dataplaneConnection.closed[0].GetLocalSource().GetMechanism().GetParameters()[connection.InterfaceNameKey]).To(Equal("icmp-responder1"))
+	Expect(len(dataplaneConnection.connections)).To(Equal(2))
+	Expect(dataplaneConnection.connections[1].GetLocalSource().GetMechanism().GetParameters()[connection.InterfaceNameKey]).To(Equal("icmp-responder2"))
+}
+
 func TestNSENoSrc(t *testing.T) {
 	RegisterTestingT(t)
 
@@ -221,3 +282,33 @@ func TestNSEIPNeghtbours(t *testing.T) {
 	Expect(originl.connection.Context.IpNeighbors[0].Ip).To(Equal("127.0.0.1"))
 	Expect(originl.connection.Context.IpNeighbors[0].HardwareAddress).To(Equal("ff-ee-ff-ee-ff"))
 }
+
+func TestNSMDRequestRetriesDataplane(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	dataplane := srv.serviceRegistry.testDataplaneConnection
+	dataplane.requestErrors = []error{
+		status.Error(codes.Unavailable, "dataplane is not ready"),
+		status.Error(codes.Unavailable, "dataplane is not ready"),
+	}
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(nsmResponse.GetNetworkService()).To(Equal("golden_network"))
+	Expect(len(dataplane.connections)).To(Equal(1))
+
+	// Non transient errors are not retried.
+	dataplane.requestErrors = []error{
+		status.Error(codes.InvalidArgument, "wrong cross connect"),
+	}
+	_, err = nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).NotTo(BeNil())
+	Expect(len(dataplane.connections)).To(Equal(1))
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	nsm2 "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNSMDRequestCloseMetrics(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.testModel.AddListener(metrics.NewActiveConnectionsListener())
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	requests := testutil.ToFloat64(metrics.RequestsTotal.WithLabelValues("golden_network", metrics.ResultSuccess))
+	closes := testutil.ToFloat64(metrics.ClosesTotal.WithLabelValues("golden_network", metrics.ResultSuccess))
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(testutil.ToFloat64(metrics.RequestsTotal.WithLabelValues("golden_network", metrics.ResultSuccess))).To(Equal(requests + 1))
+	Expect(testutil.ToFloat64(metrics.ActiveConnections.WithLabelValues("test_data_plane", "golden_networkprovider"))).To(Equal(1.0))
+
+	_, err = nsmClient.Close(context.Background(), nsmResponse)
+	Expect(err).To(BeNil())
+	Expect(testutil.ToFloat64(metrics.ClosesTotal.WithLabelValues("golden_network", metrics.ResultSuccess))).To(Equal(closes + 1))
+	Expect(testutil.ToFloat64(metrics.ActiveConnections.WithLabelValues("test_data_plane", "golden_networkprovider"))).To(Equal(0.0))
+}
+
+func TestNSMDFailureMetrics(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	failures := testutil.ToFloat64(metrics.RequestsTotal.WithLabelValues("golden_network", metrics.ResultFailure))
+	dataplaneErrors := testutil.ToFloat64(metrics.DataplaneErrorsTotal.WithLabelValues("test_data_plane", metrics.OperationRequest))
+
+	srv.serviceRegistry.testDataplaneConnection.requestErrors = []error{
+		status.Error(codes.InvalidArgument, "wrong cross connect"),
+	}
+	_, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).NotTo(BeNil())
+	Expect(testutil.ToFloat64(metrics.RequestsTotal.WithLabelValues("golden_network", metrics.ResultFailure))).To(Equal(failures + 1))
+	Expect(testutil.ToFloat64(metrics.DataplaneErrorsTotal.WithLabelValues("test_data_plane", metrics.OperationRequest))).To(Equal(dataplaneErrors + 1))
+}
+
+func TestNSMDHealMetrics(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	recovered := testutil.ToFloat64(metrics.HealsTotal.WithLabelValues("DstDown", metrics.HealResultRecovered))
+	closed := testutil.ToFloat64(metrics.HealsTotal.WithLabelValues("SrcDown", metrics.HealResultClosed))
+
+	srv.manager.Heal(srv.testModel.GetClientConnection(nsmResponse.GetId()), nsm2.HealState_DstDown)
+	Expect(testutil.ToFloat64(metrics.HealsTotal.WithLabelValues("DstDown", metrics.HealResultRecovered))).To(Equal(recovered + 1))
+
+	srv.manager.Heal(srv.testModel.GetClientConnection(nsmResponse.GetId()), nsm2.HealState_SrcDown)
+	Expect(testutil.ToFloat64(metrics.HealsTotal.WithLabelValues("SrcDown", metrics.HealResultClosed))).To(Equal(closed + 1))
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/policy"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestNSMDPolicyDeniesWorkspace(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+	srv.policyEngine.SetPolicy(&policy.Policy{
+		Rules: []*policy.Rule{
+			{Name: "no-nsm-1", Action: policy.ActionDeny, NetworkService: "golden_*", Workspaces: []string{"nsm-1"}},
+		},
+	})
+
+	denials := testutil.ToFloat64(metrics.PolicyDenialsTotal.WithLabelValues("golden_network"))
+
+	deniedClient, deniedConn := srv.requestNSMConnection("nsm-1")
+	defer deniedConn.Close()
+	_, err := deniedClient.Request(context.Background(), createRequest(false))
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	Expect(err.Error()).To(ContainSubstring("no-nsm-1"))
+	Expect(len(srv.testModel.GetAllClientConnections())).To(Equal(0))
+	Expect(testutil.ToFloat64(metrics.PolicyDenialsTotal.WithLabelValues("golden_network"))).To(Equal(denials + 1))
+	events := srv.journal.Events("", 0)
+	Expect(events[len(events)-1].GetType()).To(Equal(journal.EventRequestDenied))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-2")
+	defer conn.Close()
+	_, err = nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+}
+
+func TestNSMDPolicyDeniesPod(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+	srv.policyEngine.SetPolicy(&policy.Policy{
+		Default: policy.ActionDeny,
+		Rules: []*policy.Rule{
+			{Name: "frontend", Action: policy.ActionAllow, Pods: []string{"frontend/*"}},
+		},
+	})
+
+	srv.serviceRegistry.workspacePods = map[string]string{
+		"nsm-1": "backend/db-1",
+		"nsm-2": "frontend/web-1",
+	}
+
+	// Pod is resolved from workspace, identity claimed by client is ignored.
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+	request := createRequest(false)
+	request.Connection.Labels["namespace"] = "frontend"
+	request.Connection.Labels["podName"] = "web-1"
+	_, err := nsmClient.Request(context.Background(), request)
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+	allowedClient, allowedConn := srv.requestNSMConnection("nsm-2")
+	defer allowedConn.Close()
+	_, err = allowedClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	// Pod of workspace is resolved once.
+	calls := srv.serviceRegistry.workspacePodCalls
+	_, err = allowedClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(srv.serviceRegistry.workspacePodCalls).To(Equal(calls))
+}
+
+func TestNSMDPolicyDeniesUnknownPod(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI()))
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// Pod is not resolved while policy has no pod rules.
+	srv.policyEngine.SetPolicy(&policy.Policy{
+		Rules: []*policy.Rule{
+			{Name: "no-nsm-2", Action: policy.ActionDeny, Workspaces: []string{"nsm-2"}},
+		},
+	})
+	_, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(srv.serviceRegistry.workspacePodCalls).To(Equal(0))
+
+	// Deny rule could not be checked for unknown pod, so request is denied.
+	srv.policyEngine.SetPolicy(&policy.Policy{
+		Rules: []*policy.Rule{
+			{Name: "no-backend", Action: policy.ActionDeny, Pods: []string{"backend/*"}},
+		},
+	})
+	_, err = nsmClient.Request(context.Background(), createRequest(false))
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	Expect(srv.serviceRegistry.workspacePodCalls).To(Equal(1))
+}
+
+func TestNSMDPolicyDeniesSourceNsm(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+	srv2.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI()))
+	srv2.policyEngine.SetPolicy(&policy.Policy{
+		Rules: []*policy.Rule{
+			{Name: "no-srv", Action: policy.ActionDeny, SourceNsms: []string{srv.serviceRegistry.GetPublicAPI()}},
+		},
+	})
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// Request is allowed by local NSM, but denied by remote one.
+	_, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).NotTo(BeNil())
+	Expect(len(srv2.testModel.GetAllClientConnections())).To(Equal(0))
+	events := srv2.journal.Events("", 0)
+	Expect(events[len(events)-1].GetType()).To(Equal(journal.EventRequestDenied))
+}
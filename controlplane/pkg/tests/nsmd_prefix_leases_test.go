@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testPrefixLeases - records owners prefixes are leased to and released by.
+type testPrefixLeases struct {
+	leased   []string
+	released []string
+}
+
+func (c *testPrefixLeases) LeasePrefixes(ctx context.Context, request *registry.PrefixLeaseRequest, opts ...grpc.CallOption) (*registry.PrefixLease, error) {
+	c.leased = append(c.leased, request.Owner)
+	return &registry.PrefixLease{
+		NetworkServiceName: request.NetworkServiceName,
+		Owner:              request.Owner,
+		Prefixes:           request.Prefixes,
+	}, nil
+}
+
+func (c *testPrefixLeases) ReleasePrefixes(ctx context.Context, lease *registry.PrefixLease, opts ...grpc.CallOption) (*empty.Empty, error) {
+	c.released = append(c.released, lease.Owner)
+	return &empty.Empty{}, nil
+}
+
+func TestNSMDPrefixLeaseBoundToWorkspaceEndpoint(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	leases := &testPrefixLeases{}
+	srv.serviceRegistry.prefixLeases = leases
+
+	_, conn := srv.requestNSMConnection("nse-1")
+	defer conn.Close()
+	_, err := registry.NewNetworkServiceRegistryClient(conn).RegisterNSE(context.Background(), &registry.NSERegistration{
+		NetworkService: &registry.NetworkService{
+			Name:    "golden_network",
+			Payload: "IP",
+		},
+		NetworkserviceEndpoint: &registry.NetworkServiceEndpoint{
+			NetworkServiceName: "golden_network",
+			Payload:            "IP",
+			EndpointName:       "golden_network-1",
+		},
+	})
+	Expect(err).To(BeNil())
+
+	// Owner claimed by endpoint is replaced by its registered name.
+	client := registry.NewPrefixLeaseRegistryClient(conn)
+	lease, err := client.LeasePrefixes(context.Background(), &registry.PrefixLeaseRequest{
+		NetworkServiceName: "golden_network",
+		Owner:              "golden_network-2",
+		Prefixes:           []string{"10.20.0.0/16"},
+	})
+	Expect(err).To(BeNil())
+	Expect(lease.Owner).To(Equal("golden_network-1"))
+	_, err = client.ReleasePrefixes(context.Background(), &registry.PrefixLease{
+		NetworkServiceName: "golden_network",
+		Owner:              "golden_network-2",
+	})
+	Expect(err).To(BeNil())
+	Expect(leases.leased).To(Equal([]string{"golden_network-1"}))
+	Expect(leases.released).To(Equal([]string{"golden_network-1"}))
+
+	// Workspace without endpoint of network service could not lease or release its prefixes.
+	_, otherConn := srv.requestNSMConnection("nse-2")
+	defer otherConn.Close()
+	otherClient := registry.NewPrefixLeaseRegistryClient(otherConn)
+	_, err = otherClient.LeasePrefixes(context.Background(), &registry.PrefixLeaseRequest{
+		NetworkServiceName: "golden_network",
+		Owner:              "golden_network-1",
+		Prefixes:           []string{"10.20.0.0/16"},
+	})
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	_, err = otherClient.ReleasePrefixes(context.Background(), lease)
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	Expect(leases.leased).To(Equal([]string{"golden_network-1"}))
+	Expect(leases.released).To(Equal([]string{"golden_network-1"}))
+}
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/crossconnect_monitor"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/monitor/remote_connection_monitor"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+type healRecordingManager struct {
+	sync.Mutex
+	heals map[string]nsm.HealState
+}
+
+func (m *healRecordingManager) Request(ctx context.Context, request nsm.NSMRequest) (nsm.NSMConnection, error) {
+	return nil, nil
+}
+
+func (m *healRecordingManager) Close(ctx context.Context, clientConnection nsm.NSMClientConnection) error {
+	return nil
+}
+
+func (m *healRecordingManager) Heal(connection nsm.NSMClientConnection, healState nsm.HealState) {
+	m.Lock()
+	defer m.Unlock()
+	m.heals[connection.GetId()] = healState
+}
+
+func (m *healRecordingManager) RestoreConnections(connections []nsm.NSMClientConnection) {
+}
+
+func (m *healRecordingManager) ReapExpiredConnections(now time.Time) {
+}
+
+func (m *healRecordingManager) RefreshRemoteLeases(ctx context.Context, deadline time.Time) {
+}
+
+func (m *healRecordingManager) DrainEndpoint(ctx context.Context, endpointName string) error {
+	return nil
+}
+
+func (m *healRecordingManager) UpdateSettings(settings nsm.Settings) {
+}
+
+func (m *healRecordingManager) UpdateClusterPrefixes(prefixes []string) {
+}
+
+func (m *healRecordingManager) getHeal(id string) (nsm.HealState, bool) {
+	m.Lock()
+	defer m.Unlock()
+	state, ok := m.heals[id]
+	return state, ok
+}
+
+func startRemotePeerMonitorClient(remoteNsmUrl string) (model.Model, *healRecordingManager) {
+	return startRemotePeerMonitorClientWithTimeouts(remoteNsmUrl, 50*time.Millisecond, 200*time.Millisecond, 500*time.Millisecond)
+}
+
+func startRemotePeerMonitorClientWithTimeouts(remoteNsmUrl string, minBackoff, maxBackoff, gracePeriod time.Duration) (model.Model, *healRecordingManager) {
+	myModel := model.NewModel()
+	manager := &healRecordingManager{heals: map[string]nsm.HealState{}}
+	monitorClient := nsmd.NewMonitorCrossConnectClient(crossconnect_monitor.NewCrossConnectMonitor(),
+		remote_connection_monitor.NewRemoteConnectionMonitor(),
+		services.NewClientConnectionManager(myModel, manager, nsmd.NewServiceRegistry()))
+	monitorClient.SetRemotePeerTimeouts(minBackoff, maxBackoff, gracePeriod)
+	myModel.AddListener(monitorClient)
+
+	myModel.AddClientConnection(&model.ClientConnection{
+		ConnectionId: "1",
+		Xcon: &crossconnect.CrossConnect{
+			Id: "1",
+			Destination: &crossconnect.CrossConnect_RemoteDestination{
+				RemoteDestination: &connection.Connection{
+					Id:    "remote-1",
+					State: connection.State_UP,
+				},
+			},
+		},
+		RemoteNsm: &registry.NetworkServiceManager{
+			Name: "remote_nsm",
+			Url:  remoteNsmUrl,
+		},
+		ConnectionState: model.ClientConnection_Ready,
+	})
+	return myModel, manager
+}
+
+func TestRemotePeerDiesConnectionsHealed(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Take a free port, so nobody is listening on it.
+	sock, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	remoteNsmUrl := sock.Addr().String()
+	sock.Close()
+
+	myModel, manager := startRemotePeerMonitorClient(remoteNsmUrl)
+	defer myModel.DeleteClientConnection("1")
+
+	_, healed := manager.getHeal("1")
+	Expect(healed).To(BeFalse())
+
+	Eventually(func() bool {
+		_, healed := manager.getHeal("1")
+		return healed
+	}, 5*time.Second, 50*time.Millisecond).Should(BeTrue())
+	state, _ := manager.getHeal("1")
+	Expect(state).To(Equal(nsm.HealState_DstDown))
+	Expect(myModel.GetClientConnection("1").Xcon.GetRemoteDestination().GetState()).To(Equal(connection.State_DOWN))
+}
+
+func TestRemotePeerNotRespondingHealedAfterGracePeriod(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Remote NSM accepts connections, but never responds, so dial could only time out.
+	sock, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	defer sock.Close()
+	go func() {
+		for {
+			conn, err := sock.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	start := time.Now()
+	myModel, manager := startRemotePeerMonitorClientWithTimeouts(sock.Addr().String(), 50*time.Millisecond, 10*time.Second, 300*time.Millisecond)
+	defer myModel.DeleteClientConnection("1")
+
+	// Healing is not delayed by dial timeout of not responding remote NSM.
+	Eventually(func() bool {
+		_, healed := manager.getHeal("1")
+		return healed
+	}, 5*time.Second, 50*time.Millisecond).Should(BeTrue())
+	Expect(time.Since(start)).To(BeNumerically("<", 2*time.Second))
+}
+
+func TestRemotePeerReconcileInitialState(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Remote NSM is alive, but does not know anything about our connection.
+	sock, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	grpcServer := grpc.NewServer()
+	connection.RegisterMonitorConnectionServer(grpcServer, remote_connection_monitor.NewRemoteConnectionMonitor())
+	go grpcServer.Serve(sock)
+	defer grpcServer.Stop()
+
+	myModel, manager := startRemotePeerMonitorClient(sock.Addr().String())
+	defer myModel.DeleteClientConnection("1")
+
+	Eventually(func() bool {
+		_, healed := manager.getHeal("1")
+		return healed
+	}, 5*time.Second, 50*time.Millisecond).Should(BeTrue())
+	state, _ := manager.getHeal("1")
+	Expect(state).To(Equal(nsm.HealState_DstDown))
+}
@@ -159,3 +159,63 @@ func TestNSMDCloseCrossConnection(t *testing.T) {
 	Expect(cross_connection2).To(BeNil())
 
 }
+
+func TestNSMDRequestClientRemoteNSMDUnsupportedMechanism(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	// GRE is preferred by dataplanes, but remote NSM is able to program VXLAN tunnels only.
+	srv.testModel.AddDataplane(&model.Dataplane{
+		RegisteredName: "test_data_plane",
+		SocketLocation: "tcp:some_addr",
+		LocalMechanisms: []*connection.Mechanism{
+			{
+				Type: connection.MechanismType_KERNEL_INTERFACE,
+			},
+		},
+		RemoteMechanisms: []*connection2.Mechanism{
+			{
+				Type:       connection2.MechanismType_GRE,
+				Parameters: map[string]string{connection2.VXLANSrcIP: "127.0.0.1"},
+			},
+			{
+				Type:       connection2.MechanismType_VXLAN,
+				Parameters: map[string]string{connection2.VXLANSrcIP: "127.0.0.1"},
+			},
+		},
+	})
+	srv2.testModel.AddDataplane(&model.Dataplane{
+		RegisteredName: "test_data_plane2",
+		SocketLocation: "tcp:some_addr",
+		RemoteMechanisms: []*connection2.Mechanism{
+			{
+				Type:       connection2.MechanismType_GRE,
+				Parameters: map[string]string{connection2.VXLANSrcIP: "127.0.0.2"},
+			},
+			{
+				Type:       connection2.MechanismType_VXLAN,
+				Parameters: map[string]string{connection2.VXLANSrcIP: "127.0.0.2"},
+			},
+		},
+	})
+
+	nseReg := srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI())
+	srv2.testModel.AddEndpoint(nseReg)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	mechanism := clientConnection.Xcon.GetRemoteDestination().GetMechanism()
+	Expect(mechanism.GetType()).To(Equal(connection2.MechanismType_VXLAN))
+	Expect(mechanism.GetParameters()[connection2.VXLANDstIP]).To(Equal("127.0.0.2"))
+	_, err = mechanism.VNI()
+	Expect(err).To(BeNil())
+}
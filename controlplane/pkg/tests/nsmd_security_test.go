@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	remote_connection "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+)
+
+var certificateGeneration int
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA() *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "nsm-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+	cert, err := x509.ParseCertificate(raw)
+	Expect(err).To(BeNil())
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}),
+	}
+}
+
+// writeCertificate - writes certificate issued for name, its key and CA to dir, files are rewritten on each call.
+func (ca *testCA) writeCertificate(dir string, name string) (string, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	Expect(err).To(BeNil())
+	keyRaw, err := x509.MarshalECPrivateKey(key)
+	Expect(err).To(BeNil())
+
+	certFile, keyFile, caFile := path.Join(dir, "tls.crt"), path.Join(dir, "tls.key"), path.Join(dir, "ca.crt")
+	Expect(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}), 0600)).To(BeNil())
+	Expect(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyRaw}), 0600)).To(BeNil())
+	Expect(ioutil.WriteFile(caFile, ca.pem, 0600)).To(BeNil())
+	// Make sure change is noticed even with coarse file system timestamps.
+	certificateGeneration++
+	modTime := time.Now().Add(time.Duration(certificateGeneration) * time.Second)
+	for _, file := range []string{certFile, keyFile, caFile} {
+		Expect(os.Chtimes(file, modTime, modTime)).To(BeNil())
+	}
+	return certFile, keyFile, caFile
+}
+
+func (ca *testCA) newProvider(name string) *security.Provider {
+	dir, err := ioutil.TempDir("", "nsmd_tls")
+	Expect(err).To(BeNil())
+	provider, err := security.NewProvider(ca.writeCertificate(dir, name))
+	Expect(err).To(BeNil())
+	return provider
+}
+
+func newSecureRemoteNSMDs(srvProvider, srv2Provider func(string) *security.Provider) (*nsmdFullServerImpl, *nsmdFullServerImpl) {
+	srv := newSecureNSMDFullServer(srvProvider)
+	srv2 := newSecureNSMDFullServer(srv2Provider)
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+	srv2.testModel.AddEndpoint(srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI()))
+	return srv, srv2
+}
+
+func TestNSMDRemoteRequestMutualTLS(t *testing.T) {
+	RegisterTestingT(t)
+
+	ca := newTestCA()
+	srv, srv2 := newSecureRemoteNSMDs(ca.newProvider, ca.newProvider)
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(len(srv2.serviceRegistry.testDataplaneConnection.connections)).To(Equal(1))
+
+	_, err = nsmClient.Close(context.Background(), nsmResponse)
+	Expect(err).To(BeNil())
+	Expect(len(srv2.testModel.GetAllClientConnections())).To(Equal(0))
+}
+
+func TestNSMDRemoteRequestWrongIdentity(t *testing.T) {
+	RegisterTestingT(t)
+
+	ca := newTestCA()
+	impostor := func(string) *security.Provider {
+		return ca.newProvider("impostor")
+	}
+	srv, srv2 := newSecureRemoteNSMDs(impostor, ca.newProvider)
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	// Certificate is valid, but not issued for NSM requesting connection.
+	_, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).NotTo(BeNil())
+	Expect(len(srv2.testModel.GetAllClientConnections())).To(Equal(0))
+}
+
+func TestNSMDRemoteConnectionOfOtherNSMRefused(t *testing.T) {
+	RegisterTestingT(t)
+
+	ca := newTestCA()
+	srv, srv2 := newSecureRemoteNSMDs(ca.newProvider, ca.newProvider)
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	nsmClient, nsmConn := srv.requestNSMConnection("nsm-1")
+	defer nsmConn.Close()
+	_, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+	Expect(len(srv2.testModel.GetAllClientConnections())).To(Equal(1))
+	remoteConnection := srv2.testModel.GetAllClientConnections()[0].Xcon.GetRemoteSource()
+
+	conn, err := grpc.Dial(srv2.serviceRegistry.GetPublicAPI(), ca.newProvider("nsm-other").DialOption(srv2.serviceRegistry.GetPublicAPI()))
+	Expect(err).To(BeNil())
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Connection id of another NSM is not accepted with name of the peer.
+	otherConnection := proto.Clone(remoteConnection).(*remote_connection.Connection)
+	otherConnection.SourceNetworkServiceManagerName = "nsm-other"
+	_, err = remote_networkservice.NewNetworkServiceClient(conn).Close(ctx, otherConnection)
+	Expect(err).NotTo(BeNil())
+	Expect(len(srv2.testModel.GetAllClientConnections())).To(Equal(1))
+
+	// Connections of another NSM are not monitored by the peer.
+	stream, err := remote_connection.NewMonitorConnectionClient(conn).MonitorConnections(ctx, &remote_connection.MonitorScopeSelector{
+		NetworkServiceManagerName: srv2.serviceRegistry.GetPublicAPI(),
+	})
+	Expect(err).To(BeNil())
+	event, err := stream.Recv()
+	Expect(err).To(BeNil())
+	Expect(event.GetType()).To(Equal(remote_connection.ConnectionEventType_INITIAL_STATE_TRANSFER))
+	Expect(len(event.GetConnections())).To(Equal(0))
+}
+
+func TestNSMDRemoteRequestPlaintextRefused(t *testing.T) {
+	RegisterTestingT(t)
+
+	ca := newTestCA()
+	srv2 := newSecureNSMDFullServer(ca.newProvider)
+	defer srv2.Stop()
+
+	conn, err := grpc.Dial(srv2.serviceRegistry.GetPublicAPI(), grpc.WithInsecure())
+	Expect(err).To(BeNil())
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = remote_networkservice.NewNetworkServiceClient(conn).Request(ctx, &remote_networkservice.NetworkServiceRequest{})
+	Expect(err).NotTo(BeNil())
+}
+
+func TestNSMDCertificateRotation(t *testing.T) {
+	RegisterTestingT(t)
+
+	oldCA, newCA := newTestCA(), newTestCA()
+	dir, err := ioutil.TempDir("", "nsmd_tls")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+	var name string
+	srv := newSecureNSMDFullServer(func(nsmName string) *security.Provider {
+		name = nsmName
+		provider, err := security.NewProvider(oldCA.writeCertificate(dir, nsmName))
+		Expect(err).To(BeNil())
+		return provider
+	})
+	defer srv.Stop()
+
+	query := func() error {
+		conn, err := grpc.Dial(srv.serviceRegistry.GetPublicAPI(), newCA.newProvider("nsmctl").DialOption(name))
+		Expect(err).To(BeNil())
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stream, err := crossconnect.NewMonitorCrossConnectClient(conn).MonitorCrossConnects(ctx, &empty.Empty{})
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+
+	// Peer trusts only new CA.
+	Expect(query()).NotTo(BeNil())
+
+	// Certificates are reloaded without restart.
+	newCA.writeCertificate(dir, name)
+	Expect(query()).To(BeNil())
+}
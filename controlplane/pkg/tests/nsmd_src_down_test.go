@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
+	. "github.com/onsi/gomega"
+)
+
+func TestNSMDLocalSrcDown(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.addFakeDataplane("test_data_plane", "tcp:some_addr")
+	srv.registerFakeEndpoint("golden_network", "test", srv.serviceRegistry.GetPublicAPI())
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(clientConnection).ToNot(BeNil())
+	nseConnectionId := clientConnection.Xcon.GetLocalDestination().GetId()
+	prefixPool := srv.serviceRegistry.localTestNSE.(*localTestNSENetworkServiceClient).prefixPool
+	_, _, err = prefixPool.GetConnectionInformation(nseConnectionId)
+	Expect(err).To(BeNil())
+
+	// Simulate client netns is gone.
+	xconManager := services.NewClientConnectionManager(srv.testModel, srv.manager, srv.serviceRegistry)
+	xconManager.UpdateClientConnectionSrcStateDown(clientConnection)
+
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId())).To(BeNil())
+	Expect(len(srv.serviceRegistry.testDataplaneConnection.closed)).To(Equal(1))
+	// IP addresses are released by NSE.
+	_, _, err = prefixPool.GetConnectionInformation(nseConnectionId)
+	Expect(err).NotTo(BeNil())
+}
+
+func TestNSMDRemoteSrcDown(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+
+	nseReg := srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI())
+	srv2.testModel.AddEndpoint(nseReg)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	Expect(clientConnection).ToNot(BeNil())
+	remoteConnectionId := clientConnection.Xcon.GetRemoteDestination().GetId()
+	Expect(srv2.testModel.GetClientConnection(remoteConnectionId)).ToNot(BeNil())
+
+	// Simulate client netns is gone.
+	xconManager := services.NewClientConnectionManager(srv.testModel, srv.manager, srv.serviceRegistry)
+	xconManager.UpdateClientConnectionSrcStateDown(clientConnection)
+
+	// Both local and remote NSM sides should be closed.
+	Expect(srv.testModel.GetClientConnection(nsmResponse.GetId())).To(BeNil())
+	Expect(srv2.testModel.GetClientConnection(remoteConnectionId)).To(BeNil())
+	Expect(len(srv.serviceRegistry.testDataplaneConnection.closed)).To(Equal(1))
+	Expect(len(srv2.serviceRegistry.testDataplaneConnection.closed)).To(Equal(1))
+}
+
+func TestNSMDRemoteSrcDownOnRemoteSide(t *testing.T) {
+	RegisterTestingT(t)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+
+	nseReg := srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI())
+	srv2.testModel.AddEndpoint(nseReg)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	nsmResponse, err := nsmClient.Request(context.Background(), createRequest(false))
+	Expect(err).To(BeNil())
+
+	clientConnection := srv.testModel.GetClientConnection(nsmResponse.GetId())
+	remoteConnectionId := clientConnection.Xcon.GetRemoteDestination().GetId()
+	remoteClientConnection := srv2.testModel.GetClientConnection(remoteConnectionId)
+	Expect(remoteClientConnection).ToNot(BeNil())
+
+	// Remote dataplane detects source side of tunnel is gone.
+	xconManager := services.NewClientConnectionManager(srv2.testModel, srv2.manager, srv2.serviceRegistry)
+	xconManager.UpdateClientConnectionSrcStateDown(remoteClientConnection)
+
+	Expect(srv2.testModel.GetClientConnection(remoteConnectionId)).To(BeNil())
+	Expect(len(srv2.serviceRegistry.testDataplaneConnection.closed)).To(Equal(1))
+}
@@ -20,15 +20,21 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	remote_networkservice "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/connection_journal"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/interdomain"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/policy"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
 	"github.com/networkservicemesh/networkservicemesh/dataplane/pkg/apis/dataplane"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	. "github.com/onsi/gomega"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 )
 
 type nsmdTestServiceDiscovery struct {
@@ -38,6 +44,7 @@ type nsmdTestServiceDiscovery struct {
 	managers   map[string]*registry.NetworkServiceManager
 	endpoints  map[string]*registry.NetworkServiceEndpoint
 	nsmCounter int
+	findCalls  int
 }
 
 func (impl *nsmdTestServiceDiscovery) RegisterNSE(ctx context.Context, in *registry.NSERegistration, opts ...grpc.CallOption) (*registry.NSERegistration, error) {
@@ -55,6 +62,13 @@ func (impl *nsmdTestServiceDiscovery) RegisterNSE(ctx context.Context, in *regis
 	return in, nil
 }
 
+func (impl *nsmdTestServiceDiscovery) DrainNSE(ctx context.Context, in *registry.DrainNSERequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	if endpoint := impl.endpoints[in.EndpointName]; endpoint != nil {
+		endpoint.State = registry.EndpointStateDraining
+	}
+	return &empty.Empty{}, nil
+}
+
 func (impl *nsmdTestServiceDiscovery) RemoveNSE(ctx context.Context, in *registry.RemoveNSERequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	delete(impl.endpoints, in.EndpointName)
 	return nil, nil
@@ -71,6 +85,7 @@ func newNSMDTestServiceDiscovery(testApi *testApiRegistry) *nsmdTestServiceDisco
 }
 
 func (impl *nsmdTestServiceDiscovery) FindNetworkService(ctx context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (*registry.FindNetworkServiceResponse, error) {
+	impl.findCalls++
 	endpoints := []*registry.NetworkServiceEndpoint{}
 
 	managers := map[string]*registry.NetworkServiceManager{}
@@ -92,6 +107,14 @@ func (impl *nsmdTestServiceDiscovery) FindNetworkService(ctx context.Context, in
 	}, nil
 }
 
+func (impl *nsmdTestServiceDiscovery) ListNetworkServices(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*registry.NetworkServiceList, error) {
+	services := &registry.NetworkServiceList{}
+	for _, service := range impl.services {
+		services.NetworkServices = append(services.NetworkServices, service)
+	}
+	return services, nil
+}
+
 type nsmdTestServiceRegistry struct {
 	nseRegistry             *nsmdTestServiceDiscovery
 	apiRegistry             *testApiRegistry
@@ -99,6 +122,22 @@ type nsmdTestServiceRegistry struct {
 	localTestNSE            networkservice.NetworkServiceClient
 	vniAllocator            vni.VniAllocator
 	rootDir                 string
+	// endpointHealth - serving status returned by endpoints health check, endpoints are serving by default.
+	endpointHealth map[string]grpc_health_v1.HealthCheckResponse_ServingStatus
+	// domains - other domains known by NSMD with their registries.
+	domains          []*interdomain.Domain
+	domainRegistries map[string]*nsmdTestServiceDiscovery
+	securityProvider *security.Provider
+	// clusterInfo - registry publishing prefixes used by cluster, registry is not available if it is not set.
+	clusterInfo registry.ClusterInfoClient
+	// prefixLeases - registry leasing sub-prefixes to endpoints, registry is not available if it is not set.
+	prefixLeases registry.PrefixLeaseRegistryClient
+	// waitForDataplane - wait for dataplane to be registered, instead of assuming it is available.
+	waitForDataplane bool
+	// workspacePods - pods workspaces are allocated to, pod of other workspaces is unknown.
+	workspacePods map[string]string
+	// workspacePodCalls - number of times pod of workspace is resolved.
+	workspacePodCalls int
 }
 
 func (impl *nsmdTestServiceRegistry) VniAllocator() vni.VniAllocator {
@@ -110,14 +149,30 @@ func (impl *nsmdTestServiceRegistry) NewWorkspaceProvider() serviceregistry.Work
 }
 
 func (impl *nsmdTestServiceRegistry) WaitForDataplaneAvailable(model model.Model, timeout time.Duration) error {
-	// Do Nothing.
-	return nil
+	if !impl.waitForDataplane {
+		return nil
+	}
+	st := time.Now()
+	for ; time.Since(st) < timeout; <-time.After(10 * time.Millisecond) {
+		if dp, _ := model.SelectDataplane(nil); dp != nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no dataplane is registered for %v", timeout)
 }
 
 func (impl *nsmdTestServiceRegistry) WorkspaceName(endpoint *registry.NSERegistration) string {
 	return ""
 }
 
+func (impl *nsmdTestServiceRegistry) WorkspacePod(workspace string) (string, error) {
+	impl.workspacePodCalls++
+	if pod, ok := impl.workspacePods[workspace]; ok {
+		return pod, nil
+	}
+	return "", fmt.Errorf("no pod is allocated workspace %s", workspace)
+}
+
 func (impl *nsmdTestServiceRegistry) RemoteNetworkServiceClient(nsm *registry.NetworkServiceManager) (remote_networkservice.NetworkServiceClient, *grpc.ClientConn, error) {
 	err := tools.WaitForPortAvailable(context.Background(), "tcp", nsm.Url, 1*time.Second)
 	if err != nil {
@@ -125,7 +180,7 @@ func (impl *nsmdTestServiceRegistry) RemoteNetworkServiceClient(nsm *registry.Ne
 	}
 
 	logrus.Println("Remote Network Service is available, attempting to connect...")
-	conn, err := grpc.Dial(nsm.Url, grpc.WithInsecure())
+	conn, err := grpc.Dial(nsm.Url, impl.securityProvider.DialOption(nsm.GetName()))
 	if err != nil {
 		logrus.Errorf("Failed to dial Network Service Registry at %s: %s", nsm.Url, err)
 		return nil, nil, err
@@ -137,6 +192,8 @@ func (impl *nsmdTestServiceRegistry) RemoteNetworkServiceClient(nsm *registry.Ne
 type localTestNSENetworkServiceClient struct {
 	req        *networkservice.NetworkServiceRequest
 	prefixPool prefix_pool.PrefixPool
+	// closed are ids of closed connections.
+	closed []string
 }
 
 func (impl *localTestNSENetworkServiceClient) Request(ctx context.Context, in *networkservice.NetworkServiceRequest, opts ...grpc.CallOption) (*connection.Connection, error) {
@@ -178,7 +235,8 @@ func (impl *localTestNSENetworkServiceClient) Request(ctx context.Context, in *n
 }
 
 func (impl *localTestNSENetworkServiceClient) Close(ctx context.Context, in *connection.Connection, opts ...grpc.CallOption) (*empty.Empty, error) {
-	//panic("implement me")
+	impl.closed = append(impl.closed, in.GetId())
+	_ = impl.prefixPool.Release(in.GetId())
 	return nil, nil
 }
 
@@ -186,23 +244,78 @@ func (impl *nsmdTestServiceRegistry) EndpointConnection(endpoint *registry.NSERe
 	return impl.localTestNSE, nil, nil
 }
 
+func (impl *nsmdTestServiceRegistry) EndpointHealthClient(endpoint *registry.NSERegistration) (grpc_health_v1.HealthClient, *grpc.ClientConn, error) {
+	servingStatus, ok := impl.endpointHealth[endpoint.GetNetworkserviceEndpoint().GetEndpointName()]
+	if !ok {
+		servingStatus = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return &testEndpointHealthClient{servingStatus: servingStatus}, nil, nil
+}
+
+type testEndpointHealthClient struct {
+	servingStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+}
+
+func (impl *testEndpointHealthClient) Check(ctx context.Context, in *grpc_health_v1.HealthCheckRequest, opts ...grpc.CallOption) (*grpc_health_v1.HealthCheckResponse, error) {
+	return &grpc_health_v1.HealthCheckResponse{Status: impl.servingStatus}, nil
+}
+
+func (impl *testEndpointHealthClient) Watch(ctx context.Context, in *grpc_health_v1.HealthCheckRequest, opts ...grpc.CallOption) (grpc_health_v1.Health_WatchClient, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
 type testDataplaneConnection struct {
 	connections []*crossconnect.CrossConnect
+	updates     []*dataplane.CrossConnectUpdate
+	closed      []*crossconnect.CrossConnect
+	// requestErrors are returned by subsequent requests before any request succeeds.
+	requestErrors []error
+	// updateErrors are returned by subsequent updates before any update succeeds.
+	updateErrors []error
+	// correlationIds are received with requests and closes.
+	correlationIds []string
 }
 
 func (impl *testDataplaneConnection) Request(ctx context.Context, in *crossconnect.CrossConnect, opts ...grpc.CallOption) (*crossconnect.CrossConnect, error) {
+	impl.correlationIds = append(impl.correlationIds, outgoingCorrelationId(ctx))
+	if len(impl.requestErrors) > 0 {
+		err := impl.requestErrors[0]
+		impl.requestErrors = impl.requestErrors[1:]
+		return nil, err
+	}
 	impl.connections = append(impl.connections, in)
 	return in, nil
 }
 
 func (impl *testDataplaneConnection) Close(ctx context.Context, in *crossconnect.CrossConnect, opts ...grpc.CallOption) (*empty.Empty, error) {
+	impl.correlationIds = append(impl.correlationIds, outgoingCorrelationId(ctx))
+	impl.closed = append(impl.closed, in)
 	return nil, nil
 }
 
+func (impl *testDataplaneConnection) Update(ctx context.Context, in *dataplane.CrossConnectUpdate, opts ...grpc.CallOption) (*crossconnect.CrossConnect, error) {
+	if len(impl.updateErrors) > 0 {
+		err := impl.updateErrors[0]
+		impl.updateErrors = impl.updateErrors[1:]
+		return nil, err
+	}
+	impl.updates = append(impl.updates, in)
+	return in.GetCurrent(), nil
+}
+
 func (impl *testDataplaneConnection) MonitorMechanisms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (dataplane.Dataplane_MonitorMechanismsClient, error) {
 	return nil, nil
 }
 
+func outgoingCorrelationId(ctx context.Context) string {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if values := md.Get(tools.CorrelationIdKey); len(values) > 0 {
+			return values[len(values)-1]
+		}
+	}
+	return ""
+}
+
 func (impl *nsmdTestServiceRegistry) DataplaneConnection(dataplane *model.Dataplane) (dataplane.DataplaneClient, *grpc.ClientConn, error) {
 	return impl.testDataplaneConnection, nil, nil
 }
@@ -234,6 +347,36 @@ func (impl *nsmdTestServiceRegistry) NetworkServiceDiscovery() (registry.Network
 	return impl.nseRegistry, nil
 }
 
+func (impl *nsmdTestServiceRegistry) SecurityProvider() *security.Provider {
+	return impl.securityProvider
+}
+
+func (impl *nsmdTestServiceRegistry) DomainResolver() interdomain.Resolver {
+	return interdomain.NewStaticResolver(impl.domains)
+}
+
+func (impl *nsmdTestServiceRegistry) DomainDiscovery(domain *interdomain.Domain) (registry.NetworkServiceDiscoveryClient, *grpc.ClientConn, error) {
+	discovery := impl.domainRegistries[domain.Name]
+	if discovery == nil {
+		return nil, nil, fmt.Errorf("no registry for domain %s", domain.Name)
+	}
+	return discovery, nil, nil
+}
+
+func (impl *nsmdTestServiceRegistry) ClusterInfoClient() (registry.ClusterInfoClient, error) {
+	if impl.clusterInfo == nil {
+		return nil, fmt.Errorf("cluster info is not available")
+	}
+	return impl.clusterInfo, nil
+}
+
+func (impl *nsmdTestServiceRegistry) PrefixLeaseClient() (registry.PrefixLeaseRegistryClient, error) {
+	if impl.prefixLeases == nil {
+		return nil, fmt.Errorf("prefix leases are not available")
+	}
+	return impl.prefixLeases, nil
+}
+
 func (impl *nsmdTestServiceRegistry) RegistryClient() (registry.NetworkServiceRegistryClient, error) {
 	return impl.nseRegistry, nil
 }
@@ -298,6 +441,8 @@ type nsmdFullServerImpl struct {
 	serviceRegistry *nsmdTestServiceRegistry
 	testModel       model.Model
 	manager         nsm2.NetworkServiceManager
+	journal         *connection_journal.Journal
+	policyEngine    *policy.Engine
 }
 
 func (srv *nsmdFullServerImpl) Stop() {
@@ -364,6 +509,11 @@ func (srv *nsmdFullServerImpl) requestNSMConnection(clientName string) (networks
 }
 
 func newNSMDFullServer() *nsmdFullServerImpl {
+	return newSecureNSMDFullServer(nil)
+}
+
+// newSecureNSMDFullServer - creates NSMD with mutual TLS, securityProvider is called with NSM name to get its credentials.
+func newSecureNSMDFullServer(securityProvider func(nsmName string) *security.Provider) *nsmdFullServerImpl {
 	srv := &nsmdFullServerImpl{}
 	srv.apiRegistry = newTestApiRegistry()
 	srv.nseRegistry = newNSMDTestServiceDiscovery(srv.apiRegistry)
@@ -383,17 +533,24 @@ func newNSMDFullServer() *nsmdFullServerImpl {
 		localTestNSE: &localTestNSENetworkServiceClient{
 			prefixPool: prefixPool,
 		},
-		vniAllocator: vni.NewVniAllocator(),
-		rootDir:      rootDir,
+		vniAllocator:     vni.NewVniAllocator(),
+		rootDir:          rootDir,
+		endpointHealth:   map[string]grpc_health_v1.HealthCheckResponse_ServingStatus{},
+		domainRegistries: map[string]*nsmdTestServiceDiscovery{},
+	}
+	if securityProvider != nil {
+		srv.serviceRegistry.securityProvider = securityProvider(srv.serviceRegistry.GetPublicAPI())
 	}
 
 	srv.testModel = model.NewModel()
-	srv.manager = nsm.NewNetworkServiceManager(srv.testModel, srv.serviceRegistry, nsmd.GetExcludedPrefixes())
+	srv.journal = nsmd.NewConnectionJournal(srv.testModel)
+	srv.policyEngine = policy.NewEngine(nil, srv.journal)
+	srv.manager = nsm.NewNetworkServiceManager(srv.testModel, srv.serviceRegistry, nsmd.GetExcludedPrefixes(), nsmd.GetConnectionLease(), srv.journal)
 
 	// Lets start NSMD NSE registry service
-	err = nsmd.StartNSMServer(srv.testModel, srv.manager, srv.serviceRegistry, srv.apiRegistry)
+	err = nsmd.StartNSMServer(srv.testModel, srv.manager, srv.serviceRegistry, srv.apiRegistry, srv.policyEngine, srv.journal)
 	Expect(err).To(BeNil())
-	err = nsmd.StartAPIServer(srv.testModel, srv.manager, srv.apiRegistry, srv.serviceRegistry)
+	err = nsmd.StartAPIServer(srv.testModel, srv.manager, srv.apiRegistry, srv.serviceRegistry, srv.policyEngine)
 	Expect(err).To(BeNil())
 
 	return srv
@@ -0,0 +1,223 @@
+package tests
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	nsm2 "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	. "github.com/onsi/gomega"
+)
+
+func TestPersistModelRestore(t *testing.T) {
+	RegisterTestingT(t)
+
+	baseDir, err := ioutil.TempDir("", "nsmd_persistence")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(baseDir)
+	persistence := model.NewFilePersistence(baseDir)
+
+	srv := newNSMDFullServer()
+	srv2 := newNSMDFullServer()
+	defer srv.Stop()
+	defer srv2.Stop()
+
+	srv.testModel.AddDataplane(testDataplane1)
+	srv2.testModel.AddDataplane(testDataplane2)
+	srv.testModel.AddListener(model.NewPersistenceListener(persistence))
+
+	nseReg := srv.registerFakeEndpoint("golden_network", "test", srv2.serviceRegistry.GetPublicAPI())
+	srv2.testModel.AddEndpoint(nseReg)
+
+	nsmClient, conn := srv.requestNSMConnection("nsm-1")
+	defer conn.Close()
+
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &connection.Connection{
+			NetworkService: "golden_network",
+			Context: &connectioncontext.ConnectionContext{
+				DstIpRequired: true,
+				SrcIpRequired: true,
+			},
+			Labels: make(map[string]string),
+		},
+		MechanismPreferences: []*connection.Mechanism{
+			{
+				Type: connection.MechanismType_KERNEL_INTERFACE,
+				Parameters: map[string]string{
+					connection.NetNsInodeKey:    "10",
+					connection.InterfaceNameKey: "icmp-responder1",
+				},
+			},
+		},
+	}
+
+	nsmResponse, err := nsmClient.Request(context.Background(), request)
+	Expect(err).To(BeNil())
+	original := srv.testModel.GetClientConnection(nsmResponse.GetId())
+
+	Eventually(func() int { return snapshotSize(persistence) }).Should(Equal(1))
+	restored, err := persistence.Load()
+	Expect(err).To(BeNil())
+	Expect(restored[0].GetId()).To(Equal(original.GetId()))
+	Expect(proto.Equal(restored[0].Xcon, original.Xcon)).To(Equal(true))
+	Expect(proto.Equal(restored[0].Endpoint, original.Endpoint)).To(Equal(true))
+	Expect(proto.Equal(restored[0].RemoteNsm, original.RemoteNsm)).To(Equal(true))
+	Expect(restored[0].Request.IsRemote()).To(Equal(false))
+	Expect(proto.Equal(restored[0].Request.(proto.Message), original.Request.(proto.Message))).To(Equal(true))
+	Expect(restored[0].Dataplane.RegisteredName).To(Equal(testDataplane1.RegisteredName))
+
+	// Restore connection into a fresh NSMD, as it will be done after restart.
+	srv3 := newNSMDFullServer()
+	defer srv3.Stop()
+	srv3.testModel.AddDataplane(testDataplane1)
+	l3 := newTestConnectionModelListener()
+	srv3.testModel.AddListener(l3)
+
+	srv3.manager.RestoreConnections([]nsm2.NSMClientConnection{restored[0]})
+	l3.WaitAdd(1, time.Second*10, t)
+
+	clientConnection := srv3.testModel.GetClientConnection(original.GetId())
+	Expect(clientConnection).ToNot(BeNil())
+	Eventually(func() *model.Dataplane {
+		return srv3.testModel.GetClientConnection(original.GetId()).Dataplane
+	}, time.Second*10).Should(Equal(testDataplane1))
+	// Newly created connections should not collide with restored ones.
+	Expect(srv3.testModel.ConnectionId()).ToNot(Equal(original.GetId()))
+}
+
+func TestPersistModelDelete(t *testing.T) {
+	RegisterTestingT(t)
+
+	baseDir, err := ioutil.TempDir("", "nsmd_persistence")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(baseDir)
+	persistence := model.NewFilePersistence(baseDir)
+
+	mdl := model.NewModel()
+	mdl.AddListener(model.NewPersistenceListener(persistence))
+
+	restored, err := persistence.Load()
+	Expect(err).To(BeNil())
+	Expect(len(restored)).To(Equal(0))
+
+	mdl.AddClientConnection(&model.ClientConnection{
+		ConnectionId: "1",
+		Xcon: &crossconnect.CrossConnect{
+			Id: "1",
+			Source: &crossconnect.CrossConnect_LocalSource{
+				LocalSource: &connection.Connection{Id: "1", NetworkService: "golden_network"},
+			},
+			Destination: &crossconnect.CrossConnect_LocalDestination{
+				LocalDestination: &connection.Connection{Id: "2", NetworkService: "golden_network"},
+			},
+		},
+		Dataplane: testDataplane1,
+	})
+	Eventually(func() int { return snapshotSize(persistence) }).Should(Equal(1))
+	restored, err = persistence.Load()
+	Expect(err).To(BeNil())
+	Expect(restored[0].Request).To(BeNil())
+
+	mdl.DeleteClientConnection("1")
+	Eventually(func() int { return snapshotSize(persistence) }).Should(Equal(0))
+}
+
+func TestPersistModelSnapshotIsCopied(t *testing.T) {
+	RegisterTestingT(t)
+
+	baseDir, err := ioutil.TempDir("", "nsmd_persistence")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(baseDir)
+	persistence := model.NewFilePersistence(baseDir)
+
+	mdl := model.NewModel()
+	mdl.AddListener(model.NewPersistenceListener(persistence))
+
+	clientConnection := &model.ClientConnection{
+		ConnectionId: "1",
+		Xcon: &crossconnect.CrossConnect{
+			Id: "1",
+			Source: &crossconnect.CrossConnect_LocalSource{
+				LocalSource: &connection.Connection{Id: "1", NetworkService: "golden_network"},
+			},
+		},
+	}
+	mdl.AddClientConnection(clientConnection)
+	// Connection modified after notification is not seen by persistence until model is updated.
+	clientConnection.Xcon.GetLocalSource().NetworkService = "modified"
+	Eventually(func() int { return snapshotSize(persistence) }).Should(Equal(1))
+	restored, err := persistence.Load()
+	Expect(err).To(BeNil())
+	Expect(restored[0].Xcon.GetLocalSource().NetworkService).To(Equal("golden_network"))
+
+	mdl.UpdateClientConnection(clientConnection)
+	Eventually(func() string {
+		restored, _ := persistence.Load()
+		return restored[0].Xcon.GetLocalSource().NetworkService
+	}).Should(Equal("modified"))
+}
+
+func snapshotSize(persistence model.Persistence) int {
+	snapshot, err := persistence.Load()
+	Expect(err).To(BeNil())
+	return len(snapshot)
+}
+
+func TestRestoreBeforeDataplaneRegistered(t *testing.T) {
+	RegisterTestingT(t)
+
+	baseDir, err := ioutil.TempDir("", "nsmd_persistence")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(baseDir)
+	persistence := model.NewFilePersistence(baseDir)
+
+	restored := &model.ClientConnection{
+		ConnectionId: "1",
+		Xcon: &crossconnect.CrossConnect{
+			Id: "1",
+			Source: &crossconnect.CrossConnect_LocalSource{
+				LocalSource: &connection.Connection{Id: "1", NetworkService: "golden_network"},
+			},
+			Destination: &crossconnect.CrossConnect_LocalDestination{
+				LocalDestination: &connection.Connection{Id: "2", NetworkService: "golden_network"},
+			},
+		},
+		Dataplane: &model.Dataplane{RegisteredName: testDataplane1.RegisteredName},
+	}
+	Expect(persistence.Save([]*model.ClientConnection{restored})).To(BeNil())
+
+	// NSMD is restarted, there is no dataplane registered yet.
+	srv := newNSMDFullServer()
+	defer srv.Stop()
+	srv.serviceRegistry.waitForDataplane = true
+	srv.testModel.AddListener(model.NewPersistenceListener(persistence, restored))
+
+	// Snapshot is not overwritten by changes done before restored connections are added to model.
+	srv.testModel.AddDataplane(testDataplane2)
+	srv.testModel.DeleteDataplane(testDataplane2.RegisteredName)
+	srv.testModel.AddClientConnection(&model.ClientConnection{ConnectionId: "2", Xcon: &crossconnect.CrossConnect{Id: "2"}})
+	srv.testModel.DeleteClientConnection("2")
+	Consistently(func() int { return snapshotSize(persistence) }, 3*model.PersistenceDelay).Should(Equal(1))
+
+	srv.manager.RestoreConnections([]nsm2.NSMClientConnection{restored})
+	clientConnection := srv.testModel.GetClientConnection("1")
+	Expect(clientConnection).ToNot(BeNil())
+	Expect(clientConnection.ConnectionState).To(Equal(model.ClientConnectionState(model.ClientConnection_Healing)))
+	Consistently(func() int { return snapshotSize(persistence) }, 3*model.PersistenceDelay).Should(Equal(1))
+
+	// Connection is healed once dataplane is registered.
+	srv.testModel.AddDataplane(testDataplane1)
+	Eventually(func() *model.Dataplane {
+		return srv.testModel.GetClientConnection("1").Dataplane
+	}, time.Second*10).Should(Equal(testDataplane1))
+	Expect(srv.testModel.GetClientConnection("1").ConnectionState).To(Equal(model.ClientConnectionState(model.ClientConnection_Ready)))
+}
@@ -15,39 +15,249 @@
 package vni
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxVni - VNI is a 24-bit value
+	MaxVni = 1<<24 - 1
+	// DefaultTenant - a name of range used by tenants without own range
+	DefaultTenant = "default"
 )
 
+// VniAllocator allocates VNIs for tunnels between a pair of dataplanes.
 type VniAllocator interface {
-	Vni(local_ip string, remote_ip string) uint64
+	// Allocate returns a VNI for connection, connection keeps its VNI until it is released.
+	Allocate(connectionId string, tenant string, localIp string, remoteIp string) (uint32, error)
+	// Release frees VNI allocated for connection.
+	Release(connectionId string)
+}
+
+// VniRange is an inclusive range of VNIs.
+type VniRange struct {
+	Min uint32 `json:"min"`
+	Max uint32 `json:"max"`
+}
+
+// VniUsage checks if VNI is already used by tunnel between localIp and remoteIp.
+type VniUsage func(localIp string, remoteIp string, vni uint32) bool
+
+// VniConfig is a configuration of VNI allocator.
+type VniConfig struct {
+	// Ranges of VNIs per tenant, DefaultTenant range is used for other tenants.
+	Ranges map[string]VniRange
+	// StateFile is a file to persist allocations in, allocations are not persisted if empty.
+	StateFile string
+	// Usage detects VNIs used by existing connections, but not allocated by this allocator.
+	Usage VniUsage
+}
+
+type vniAllocation struct {
+	Tenant   string `json:"tenant"`
+	LocalIp  string `json:"local_ip"`
+	RemoteIp string `json:"remote_ip"`
+	Vni      uint32 `json:"vni"`
 }
 
 type vniAllocator struct {
-	lastVni map[string]uint64
 	sync.Mutex
+	config      VniConfig
+	allocations map[string]*vniAllocation
+	lastVni     map[string]uint32
 }
 
+// NewVniAllocator creates an allocator using whole VNI space without persistence.
 func NewVniAllocator() VniAllocator {
-	return &vniAllocator{
-		lastVni: make(map[string]uint64),
+	allocator, _ := NewVniAllocatorWithConfig(&VniConfig{})
+	return allocator
+}
+
+// NewVniAllocatorWithConfig creates an allocator and restores its allocations from config.StateFile.
+func NewVniAllocatorWithConfig(config *VniConfig) (VniAllocator, error) {
+	rv := &vniAllocator{
+		config:      *config,
+		allocations: make(map[string]*vniAllocation),
+		lastVni:     make(map[string]uint32),
+	}
+	rv.config.Ranges = map[string]VniRange{}
+	for tenant, r := range config.Ranges {
+		rv.config.Ranges[tenant] = r
+	}
+	if _, ok := rv.config.Ranges[DefaultTenant]; !ok {
+		rv.config.Ranges[DefaultTenant] = VniRange{Min: 1, Max: MaxVni}
+	}
+	for tenant, r := range rv.config.Ranges {
+		if r.Min == 0 || r.Min > r.Max || r.Max > MaxVni {
+			return nil, fmt.Errorf("invalid VNI range %d-%d for tenant %s", r.Min, r.Max, tenant)
+		}
 	}
+	if err := rv.load(); err != nil {
+		return nil, err
+	}
+	return rv, nil
+}
+
+// ParseVniRanges parses ranges in form "tenant=min-max,tenant2=min-max".
+func ParseVniRanges(value string) (map[string]VniRange, error) {
+	ranges := map[string]VniRange{}
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid VNI range %s, expected tenant=min-max", item)
+		}
+		bounds := strings.SplitN(parts[1], "-", 2)
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("invalid VNI range %s, expected tenant=min-max", item)
+		}
+		min, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 10, 24)
+		if err != nil {
+			return nil, fmt.Errorf("invalid VNI range %s: %v", item, err)
+		}
+		max, err := strconv.ParseUint(strings.TrimSpace(bounds[1]), 10, 24)
+		if err != nil {
+			return nil, fmt.Errorf("invalid VNI range %s: %v", item, err)
+		}
+		ranges[strings.TrimSpace(parts[0])] = VniRange{Min: uint32(min), Max: uint32(max)}
+	}
+	return ranges, nil
 }
 
-// Vni - Allocate a new VNI, odd if local_ip < remote_ip, even otherwise
-func (a *vniAllocator) Vni(local_ip string, remote_ip string) uint64 {
+// Allocate - Allocate a new VNI, odd if local_ip < remote_ip, even otherwise, so both sides
+// of tunnel will never allocate the same VNI.
+func (a *vniAllocator) Allocate(connectionId string, tenant string, localIp string, remoteIp string) (uint32, error) {
 	a.Lock()
 	defer a.Unlock()
-	lip := net.ParseIP(local_ip)
-	rip := net.ParseIP(remote_ip)
-	lastVni := a.lastVni[remote_ip]
-	if lastVni == 0 {
-		if compareIps(lip, rip) < 0 {
-			lastVni = 1
+
+	if allocation, ok := a.allocations[connectionId]; ok {
+		if allocation.LocalIp == localIp && allocation.RemoteIp == remoteIp && allocation.Tenant == tenant {
+			return allocation.Vni, nil
 		}
+		// Tunnel is changed, so VNI should be allocated again.
+		delete(a.allocations, connectionId)
+	}
+
+	r, ok := a.config.Ranges[tenant]
+	if !ok {
+		r = a.config.Ranges[DefaultTenant]
+	}
+
+	parity := uint32(0)
+	if compareIps(net.ParseIP(localIp), net.ParseIP(remoteIp)) < 0 {
+		parity = 1
+	}
+	first := r.Min
+	if first%2 != parity {
+		first++
+	}
+	if first > r.Max {
+		return 0, fmt.Errorf("VNI range %d-%d of tenant %s is too small", r.Min, r.Max, tenant)
 	}
-	a.lastVni[remote_ip] = lastVni + 2
-	return a.lastVni[remote_ip]
+	count := (r.Max-first)/2 + 1
+
+	key := pairKey(localIp, remoteIp)
+	used := a.usedVnis(localIp, remoteIp)
+	vni := a.lastVni[key]
+	for i := uint32(0); i < count; i++ {
+		if vni < first || vni+2 > r.Max {
+			vni = first
+		} else {
+			vni += 2
+		}
+		if used[vni] || (a.config.Usage != nil && a.config.Usage(localIp, remoteIp, vni)) {
+			continue
+		}
+		a.lastVni[key] = vni
+		a.allocations[connectionId] = &vniAllocation{
+			Tenant:   tenant,
+			LocalIp:  localIp,
+			RemoteIp: remoteIp,
+			Vni:      vni,
+		}
+		a.save()
+		return vni, nil
+	}
+	return 0, fmt.Errorf("no free VNI in range %d-%d of tenant %s for tunnel %s - %s", r.Min, r.Max, tenant, localIp, remoteIp)
+}
+
+func (a *vniAllocator) Release(connectionId string) {
+	a.Lock()
+	defer a.Unlock()
+
+	if _, ok := a.allocations[connectionId]; !ok {
+		return
+	}
+	delete(a.allocations, connectionId)
+	a.save()
+}
+
+func (a *vniAllocator) usedVnis(localIp string, remoteIp string) map[uint32]bool {
+	used := map[uint32]bool{}
+	for _, allocation := range a.allocations {
+		if allocation.LocalIp == localIp && allocation.RemoteIp == remoteIp {
+			used[allocation.Vni] = true
+		}
+	}
+	return used
+}
+
+func (a *vniAllocator) save() {
+	if a.config.StateFile == "" {
+		return
+	}
+	data, err := json.Marshal(a.allocations)
+	if err != nil {
+		logrus.Errorf("Failed to persist VNI allocations: %v", err)
+		return
+	}
+	// Write into temporary file and rename, so partially written state will never be loaded.
+	tmpFile := a.config.StateFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, data, 0600); err != nil {
+		logrus.Errorf("Failed to persist VNI allocations: %v", err)
+		return
+	}
+	if err := os.Rename(tmpFile, a.config.StateFile); err != nil {
+		logrus.Errorf("Failed to persist VNI allocations: %v", err)
+	}
+}
+
+func (a *vniAllocator) load() error {
+	if a.config.StateFile == "" {
+		return nil
+	}
+	data, err := ioutil.ReadFile(a.config.StateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := json.Unmarshal(data, &a.allocations); err != nil {
+		return fmt.Errorf("failed to parse VNI allocations %s: %v", a.config.StateFile, err)
+	}
+	for _, allocation := range a.allocations {
+		key := pairKey(allocation.LocalIp, allocation.RemoteIp)
+		if allocation.Vni > a.lastVni[key] {
+			a.lastVni[key] = allocation.Vni
+		}
+	}
+	return nil
+}
+
+func pairKey(localIp string, remoteIp string) string {
+	return localIp + "/" + remoteIp
 }
 
 func compareIps(ip1 net.IP, ip2 net.IP) int {
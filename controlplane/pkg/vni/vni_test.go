@@ -0,0 +1,112 @@
+package vni
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestVniAllocateRelease(t *testing.T) {
+	RegisterTestingT(t)
+
+	allocator := NewVniAllocator()
+
+	vni1, err := allocator.Allocate("1", "", "10.0.0.1", "10.0.0.2")
+	Expect(err).To(BeNil())
+	Expect(vni1).To(Equal(uint32(1)))
+
+	// Same connection keeps its VNI
+	vni, err := allocator.Allocate("1", "", "10.0.0.1", "10.0.0.2")
+	Expect(err).To(BeNil())
+	Expect(vni).To(Equal(vni1))
+
+	vni2, err := allocator.Allocate("2", "", "10.0.0.1", "10.0.0.2")
+	Expect(err).To(BeNil())
+	Expect(vni2).To(Equal(uint32(3)))
+
+	// Other side of tunnel allocates even VNIs
+	vni, err = allocator.Allocate("3", "", "10.0.0.2", "10.0.0.1")
+	Expect(err).To(BeNil())
+	Expect(vni).To(Equal(uint32(2)))
+
+	allocator.Release("1")
+	allocator.Release("unknown")
+	vni, err = allocator.Allocate("4", "", "10.0.0.1", "10.0.0.2")
+	Expect(err).To(BeNil())
+	Expect(vni).To(Equal(uint32(5)))
+}
+
+func TestVniTenantRangeWraps(t *testing.T) {
+	RegisterTestingT(t)
+
+	ranges, err := ParseVniRanges("tenant-a=100-104, default=1-10")
+	Expect(err).To(BeNil())
+	allocator, err := NewVniAllocatorWithConfig(&VniConfig{Ranges: ranges})
+	Expect(err).To(BeNil())
+
+	vnis := []uint32{}
+	for _, id := range []string{"1", "2", "3"} {
+		vni, err := allocator.Allocate(id, "tenant-a", "10.0.0.2", "10.0.0.1")
+		Expect(err).To(BeNil())
+		vnis = append(vnis, vni)
+	}
+	Expect(vnis).To(Equal([]uint32{100, 102, 104}))
+
+	_, err = allocator.Allocate("4", "tenant-a", "10.0.0.2", "10.0.0.1")
+	Expect(err).NotTo(BeNil())
+
+	// Released VNI is reused after range is wrapped
+	allocator.Release("2")
+	vni, err := allocator.Allocate("4", "tenant-a", "10.0.0.2", "10.0.0.1")
+	Expect(err).To(BeNil())
+	Expect(vni).To(Equal(uint32(102)))
+
+	// Unknown tenant uses default range
+	vni, err = allocator.Allocate("5", "tenant-b", "10.0.0.2", "10.0.0.1")
+	Expect(err).To(BeNil())
+	Expect(vni).To(Equal(uint32(2)))
+
+	_, err = ParseVniRanges("tenant-a=100")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestVniUsageCollision(t *testing.T) {
+	RegisterTestingT(t)
+
+	allocator, err := NewVniAllocatorWithConfig(&VniConfig{
+		Usage: func(localIp string, remoteIp string, vni uint32) bool {
+			return vni == 1 || vni == 3
+		},
+	})
+	Expect(err).To(BeNil())
+
+	vni, err := allocator.Allocate("1", "", "10.0.0.1", "10.0.0.2")
+	Expect(err).To(BeNil())
+	Expect(vni).To(Equal(uint32(5)))
+}
+
+func TestVniPersistence(t *testing.T) {
+	RegisterTestingT(t)
+
+	dir, err := ioutil.TempDir("", "vni_test")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+	config := &VniConfig{StateFile: path.Join(dir, "vni.json")}
+
+	allocator, err := NewVniAllocatorWithConfig(config)
+	Expect(err).To(BeNil())
+	vni1, err := allocator.Allocate("1", "", "10.0.0.1", "10.0.0.2")
+	Expect(err).To(BeNil())
+
+	restored, err := NewVniAllocatorWithConfig(config)
+	Expect(err).To(BeNil())
+	vni, err := restored.Allocate("1", "", "10.0.0.1", "10.0.0.2")
+	Expect(err).To(BeNil())
+	Expect(vni).To(Equal(vni1))
+	vni, err = restored.Allocate("2", "", "10.0.0.1", "10.0.0.2")
+	Expect(err).To(BeNil())
+	Expect(vni).NotTo(Equal(vni1))
+}
@@ -75,35 +75,88 @@ func (m *MechanismUpdate) GetLocalMechanisms() []*connection1.Mechanism {
 	return nil
 }
 
+// Message sent by NSM to update existing cross connect in place, dataplane applies
+// only a difference between previous and current cross connect configuration.
+type CrossConnectUpdate struct {
+	Previous             *crossconnect.CrossConnect `protobuf:"bytes,1,opt,name=previous,proto3" json:"previous,omitempty"`
+	Current              *crossconnect.CrossConnect `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *CrossConnectUpdate) Reset()         { *m = CrossConnectUpdate{} }
+func (m *CrossConnectUpdate) String() string { return proto.CompactTextString(m) }
+func (*CrossConnectUpdate) ProtoMessage()    {}
+func (*CrossConnectUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_617387e490a04ffa, []int{1}
+}
+
+func (m *CrossConnectUpdate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossConnectUpdate.Unmarshal(m, b)
+}
+func (m *CrossConnectUpdate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossConnectUpdate.Marshal(b, m, deterministic)
+}
+func (m *CrossConnectUpdate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossConnectUpdate.Merge(m, src)
+}
+func (m *CrossConnectUpdate) XXX_Size() int {
+	return xxx_messageInfo_CrossConnectUpdate.Size(m)
+}
+func (m *CrossConnectUpdate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossConnectUpdate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossConnectUpdate proto.InternalMessageInfo
+
+func (m *CrossConnectUpdate) GetPrevious() *crossconnect.CrossConnect {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
+func (m *CrossConnectUpdate) GetCurrent() *crossconnect.CrossConnect {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*MechanismUpdate)(nil), "dataplane.MechanismUpdate")
+	proto.RegisterType((*CrossConnectUpdate)(nil), "dataplane.CrossConnectUpdate")
 }
 
 func init() { proto.RegisterFile("dataplane.proto", fileDescriptor_617387e490a04ffa) }
 
 var fileDescriptor_617387e490a04ffa = []byte{
-	// 326 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0x3f, 0x4f, 0xf3, 0x30,
-	0x10, 0xc6, 0x95, 0xf7, 0x15, 0xa0, 0x9a, 0xa1, 0x25, 0x03, 0x42, 0x81, 0x01, 0x31, 0x31, 0xd9,
-	0xa8, 0x1d, 0x99, 0x50, 0x01, 0x89, 0xa1, 0x4b, 0x25, 0x66, 0xe4, 0xb8, 0xd7, 0xc4, 0xaa, 0xe3,
-	0x33, 0xb6, 0x03, 0xea, 0xf7, 0xe1, 0x33, 0xf1, 0x79, 0x50, 0xec, 0xfe, 0x49, 0x51, 0xc9, 0xd4,
-	0x25, 0xca, 0xdd, 0xef, 0xee, 0x79, 0x74, 0xe7, 0x23, 0xfd, 0x19, 0xf7, 0xdc, 0x28, 0xae, 0x81,
-	0x1a, 0x8b, 0x1e, 0xd3, 0xde, 0x26, 0x91, 0x95, 0x85, 0xf4, 0x65, 0x9d, 0x53, 0x81, 0x15, 0xd3,
-	0xe0, 0x3f, 0xd1, 0x2e, 0x1c, 0xd8, 0x0f, 0x29, 0xa0, 0x02, 0x57, 0xee, 0x4b, 0x09, 0xd4, 0xde,
-	0xa2, 0x0a, 0xed, 0xcc, 0x2c, 0x0a, 0xc6, 0x8d, 0x74, 0x4c, 0xa1, 0xe0, 0xaa, 0x61, 0x1a, 0x84,
-	0x97, 0xa8, 0x5b, 0xbf, 0xd1, 0x34, 0x93, 0x07, 0x72, 0xb2, 0x50, 0xa1, 0x87, 0x4e, 0xab, 0xf9,
-	0x81, 0xac, 0x84, 0x45, 0xe7, 0x56, 0xea, 0x3b, 0xc1, 0xca, 0x67, 0xd4, 0xf2, 0x29, 0x50, 0x71,
-	0x5d, 0xb0, 0x00, 0xf2, 0x7a, 0xce, 0x8c, 0x5f, 0x1a, 0x70, 0x0c, 0x2a, 0xe3, 0x97, 0xf1, 0x1b,
-	0x9b, 0x6e, 0xbe, 0x12, 0xd2, 0x9f, 0x80, 0x28, 0xb9, 0x96, 0xae, 0x7a, 0x35, 0x33, 0xee, 0x21,
-	0x7d, 0x21, 0x67, 0x71, 0xac, 0xb7, 0x6a, 0x4d, 0xdc, 0x45, 0x72, 0xfd, 0xff, 0xf6, 0x74, 0x78,
-	0x45, 0x23, 0xa1, 0xad, 0x29, 0x37, 0xed, 0xd3, 0x41, 0x84, 0x9b, 0x84, 0x4b, 0x9f, 0xc9, 0x20,
-	0xbc, 0x45, 0x5b, 0xe9, 0x5f, 0x50, 0xba, 0xa4, 0x01, 0xec, 0x17, 0xea, 0x07, 0xb6, 0xd5, 0x19,
-	0x7e, 0x27, 0xa4, 0xf7, 0xb8, 0x3e, 0x93, 0xf4, 0x81, 0x9c, 0x4c, 0xe1, 0xbd, 0x06, 0xe7, 0xd3,
-	0x8c, 0xee, 0x6c, 0x62, 0xdc, 0x04, 0xe3, 0x18, 0x64, 0x1d, 0x2c, 0xbd, 0x27, 0x47, 0x63, 0x85,
-	0x0e, 0x3a, 0x05, 0xce, 0x69, 0x81, 0x58, 0xa8, 0xd5, 0xa1, 0xe6, 0xf5, 0x9c, 0x3e, 0x35, 0xab,
-	0x6b, 0x16, 0x34, 0x41, 0x2d, 0x3d, 0xda, 0xd6, 0xa8, 0x7f, 0x14, 0x67, 0x19, 0xdd, 0x1e, 0xfc,
-	0xaf, 0x4d, 0xdf, 0x25, 0xf9, 0x71, 0xa8, 0x1e, 0xfd, 0x04, 0x00, 0x00, 0xff, 0xff, 0xaf, 0xc8,
-	0x4f, 0xeb, 0x16, 0x03, 0x00, 0x00,
+	// 381 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0x3d, 0xaf, 0xda, 0x30,
+	0x14, 0x55, 0xa8, 0x0a, 0xc5, 0x0c, 0x80, 0x87, 0x0a, 0xa5, 0xad, 0x54, 0x31, 0x31, 0x39, 0x15,
+	0x54, 0x5d, 0x3a, 0x55, 0x69, 0x2b, 0x31, 0xb0, 0x20, 0x75, 0xae, 0x1c, 0x73, 0x49, 0x2c, 0x12,
+	0x5f, 0xd7, 0x76, 0xa8, 0x58, 0xfb, 0x37, 0xba, 0xf6, 0x87, 0x56, 0xf9, 0x20, 0x84, 0xf7, 0x78,
+	0x79, 0x0b, 0x4b, 0x14, 0xdf, 0x73, 0xcf, 0x39, 0xf7, 0xc3, 0x26, 0xe3, 0x1d, 0x77, 0x5c, 0xa7,
+	0x5c, 0x01, 0xd3, 0x06, 0x1d, 0xd2, 0x61, 0x13, 0xf0, 0x93, 0x58, 0xba, 0x24, 0x8f, 0x98, 0xc0,
+	0x2c, 0x50, 0xe0, 0x7e, 0xa3, 0x39, 0x58, 0x30, 0x47, 0x29, 0x20, 0x03, 0x9b, 0xdc, 0x0a, 0x09,
+	0x54, 0xce, 0x60, 0x5a, 0xd2, 0x03, 0x7d, 0x88, 0x03, 0xae, 0xa5, 0x0d, 0x52, 0x14, 0x3c, 0x2d,
+	0x30, 0x05, 0xc2, 0x49, 0x54, 0xad, 0xdf, 0xca, 0xd4, 0x97, 0x77, 0x72, 0x32, 0x90, 0xa1, 0x83,
+	0x4e, 0xab, 0xfd, 0x9d, 0xac, 0x84, 0x41, 0x6b, 0x6b, 0xf5, 0xab, 0x43, 0xed, 0xb3, 0x6a, 0xf9,
+	0xc4, 0x98, 0x72, 0x15, 0x07, 0x25, 0x10, 0xe5, 0xfb, 0x40, 0xbb, 0x93, 0x06, 0x1b, 0x40, 0xa6,
+	0xdd, 0xa9, 0xfa, 0x56, 0xa4, 0xf9, 0x3f, 0x8f, 0x8c, 0x37, 0x20, 0x12, 0xae, 0xa4, 0xcd, 0x7e,
+	0xe8, 0x1d, 0x77, 0x40, 0xd7, 0x64, 0x5a, 0xb5, 0xf5, 0x33, 0x3b, 0x23, 0x76, 0xe6, 0xbd, 0x7f,
+	0xb1, 0x18, 0x2d, 0xdf, 0xb2, 0x0a, 0x61, 0xad, 0x2e, 0x1b, 0xfa, 0x76, 0x52, 0x81, 0x4d, 0xc0,
+	0xd2, 0xef, 0x64, 0x52, 0xee, 0xa2, 0xad, 0xd4, 0x2b, 0x95, 0xde, 0xb0, 0x12, 0xb8, 0x2d, 0x34,
+	0x2e, 0xb1, 0x8b, 0xce, 0xfc, 0x8f, 0x47, 0x68, 0x58, 0xb4, 0x1c, 0x56, 0xe9, 0x75, 0xa5, 0x9f,
+	0xc8, 0x2b, 0x6d, 0xe0, 0x28, 0x31, 0x2f, 0x0a, 0xf4, 0x16, 0xa3, 0xa5, 0xcf, 0xae, 0x26, 0xd3,
+	0xe6, 0x6c, 0x9b, 0x5c, 0xfa, 0x91, 0x0c, 0x44, 0x6e, 0x0c, 0x28, 0x37, 0xeb, 0x3d, 0x4b, 0x3b,
+	0xa7, 0x2e, 0xff, 0xf6, 0xc8, 0xf0, 0xeb, 0xf9, 0xae, 0xd2, 0x2f, 0x64, 0xb0, 0x85, 0x5f, 0x39,
+	0x58, 0x47, 0x3b, 0xd8, 0x7e, 0x07, 0x46, 0x3f, 0x93, 0x97, 0x61, 0x8a, 0x16, 0x3a, 0x05, 0x5e,
+	0xb3, 0x18, 0x31, 0x4e, 0xeb, 0xd7, 0x12, 0xe5, 0x7b, 0xf6, 0xad, 0xd8, 0x1f, 0x0d, 0x49, 0xbf,
+	0x9e, 0xc2, 0x3b, 0x76, 0x79, 0x52, 0x8f, 0x87, 0xd4, 0x59, 0xc1, 0x9a, 0x4c, 0x37, 0xa8, 0xa4,
+	0x43, 0xd3, 0x5a, 0xda, 0x13, 0x8e, 0xbe, 0xdf, 0xf2, 0x79, 0x70, 0x67, 0x3e, 0x78, 0x51, 0xbf,
+	0xcc, 0x5e, 0xfd, 0x1f, 0x00, 0x5f, 0xff, 0xd4, 0x45, 0xe0, 0x03, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -120,6 +173,7 @@ const _ = grpc.SupportPackageIsVersion4
 type DataplaneClient interface {
 	Request(ctx context.Context, in *crossconnect.CrossConnect, opts ...grpc.CallOption) (*crossconnect.CrossConnect, error)
 	Close(ctx context.Context, in *crossconnect.CrossConnect, opts ...grpc.CallOption) (*empty.Empty, error)
+	Update(ctx context.Context, in *CrossConnectUpdate, opts ...grpc.CallOption) (*crossconnect.CrossConnect, error)
 	MonitorMechanisms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (Dataplane_MonitorMechanismsClient, error)
 }
 
@@ -149,6 +203,15 @@ func (c *dataplaneClient) Close(ctx context.Context, in *crossconnect.CrossConne
 	return out, nil
 }
 
+func (c *dataplaneClient) Update(ctx context.Context, in *CrossConnectUpdate, opts ...grpc.CallOption) (*crossconnect.CrossConnect, error) {
+	out := new(crossconnect.CrossConnect)
+	err := c.cc.Invoke(ctx, "/dataplane.Dataplane/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dataplaneClient) MonitorMechanisms(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (Dataplane_MonitorMechanismsClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Dataplane_serviceDesc.Streams[0], "/dataplane.Dataplane/MonitorMechanisms", opts...)
 	if err != nil {
@@ -185,6 +248,7 @@ func (x *dataplaneMonitorMechanismsClient) Recv() (*MechanismUpdate, error) {
 type DataplaneServer interface {
 	Request(context.Context, *crossconnect.CrossConnect) (*crossconnect.CrossConnect, error)
 	Close(context.Context, *crossconnect.CrossConnect) (*empty.Empty, error)
+	Update(context.Context, *CrossConnectUpdate) (*crossconnect.CrossConnect, error)
 	MonitorMechanisms(*empty.Empty, Dataplane_MonitorMechanismsServer) error
 }
 
@@ -228,6 +292,24 @@ func _Dataplane_Close_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dataplane_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrossConnectUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataplaneServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dataplane.Dataplane/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataplaneServer).Update(ctx, req.(*CrossConnectUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Dataplane_MonitorMechanisms_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(empty.Empty)
 	if err := stream.RecvMsg(m); err != nil {
@@ -261,6 +343,10 @@ var _Dataplane_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Close",
 			Handler:    _Dataplane_Close_Handler,
 		},
+		{
+			MethodName: "Update",
+			Handler:    _Dataplane_Update_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1,6 +1,9 @@
 package converter
 
-import "github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
+import (
+	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
+	"github.com/sirupsen/logrus"
+)
 
 type Converter interface {
 	ToDataRequest(rv *rpc.DataRequest, connect bool) (*rpc.DataRequest, error)
@@ -8,6 +11,8 @@ type Converter interface {
 
 type CrossConnectConversionParameters struct {
 	BaseDir string
+	// Log - logger of request converted, standard logger is used if it is not set.
+	Log *logrus.Entry
 }
 
 type ConnectionContextSide int
@@ -23,4 +28,13 @@ type ConnectionConversionParameters struct {
 	Side      ConnectionContextSide
 	Name      string
 	BaseDir   string
+	// Log - logger of request converted, standard logger is used if it is not set.
+	Log *logrus.Entry
+}
+
+func logger(log *logrus.Entry) *logrus.Entry {
+	if log == nil {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+	return log
 }
@@ -32,6 +32,33 @@ func (c *CrossConnectConverter) ToDataRequest(rv *rpc.DataRequest, connect bool)
 	if rv == nil {
 		rv = &rpc.DataRequest{}
 	}
+	for _, converter := range []Converter{c.sourceConverter(), c.destinationConverter()} {
+		if converter == nil {
+			continue
+		}
+		if _, err := converter.ToDataRequest(rv, connect); err != nil {
+			return rv, fmt.Errorf("Error Converting CrossConnect %v: %s", c, err)
+		}
+	}
+
+	if len(rv.Interfaces) < 2 {
+		return nil, fmt.Errorf("Did not create enough interfaces to cross connect, expected at least 2, got %d", len(rv.Interfaces))
+	}
+	ifaces := rv.Interfaces[len(rv.Interfaces)-2:]
+	rv.XCons = append(rv.XCons, &l2.XConnectPairs_XConnectPair{
+		ReceiveInterface:  ifaces[0].Name,
+		TransmitInterface: ifaces[1].Name,
+	})
+	rv.XCons = append(rv.XCons, &l2.XConnectPairs_XConnectPair{
+		ReceiveInterface:  ifaces[1].Name,
+		TransmitInterface: ifaces[0].Name,
+	})
+
+	return rv, nil
+}
+
+// sourceConverter returns a converter for a source side of cross connect, or nil if it is not set.
+func (c *CrossConnectConverter) sourceConverter() Converter {
 	if c.GetLocalSource() != nil {
 		baseDir := path.Join(c.conversionParameters.BaseDir, c.GetLocalSource().GetMechanism().GetWorkspace())
 		conversionParameters := &ConnectionConversionParameters{
@@ -39,20 +66,18 @@ func (c *CrossConnectConverter) ToDataRequest(rv *rpc.DataRequest, connect bool)
 			Terminate: false,
 			Side:      SOURCE,
 			BaseDir:   baseDir,
+			Log:       c.conversionParameters.Log,
 		}
-		rv, err := NewLocalConnectionConverter(c.GetLocalSource(), conversionParameters).ToDataRequest(rv, connect)
-		if err != nil {
-			return rv, fmt.Errorf("Error Converting CrossConnect %v: %s", c, err)
-		}
+		return NewLocalConnectionConverter(c.GetLocalSource(), conversionParameters)
 	}
-
 	if c.GetRemoteSource() != nil {
-		rv, err := NewRemoteConnectionConverter(c.GetRemoteSource(), "SRC-"+c.GetId(), SOURCE).ToDataRequest(rv, connect)
-		if err != nil {
-			return rv, fmt.Errorf("Error Converting CrossConnect %v: %s", c, err)
-		}
+		return NewRemoteConnectionConverter(c.GetRemoteSource(), "SRC-"+c.GetId(), SOURCE, c.conversionParameters.Log)
 	}
+	return nil
+}
 
+// destinationConverter returns a converter for a destination side of cross connect, or nil if it is not set.
+func (c *CrossConnectConverter) destinationConverter() Converter {
 	if c.GetLocalDestination() != nil {
 		baseDir := path.Join(c.conversionParameters.BaseDir, c.GetLocalDestination().GetMechanism().GetWorkspace())
 		conversionParameters := &ConnectionConversionParameters{
@@ -60,32 +85,12 @@ func (c *CrossConnectConverter) ToDataRequest(rv *rpc.DataRequest, connect bool)
 			Terminate: false,
 			Side:      DESTINATION,
 			BaseDir:   baseDir,
+			Log:       c.conversionParameters.Log,
 		}
-		rv, err := NewLocalConnectionConverter(c.GetLocalDestination(), conversionParameters).ToDataRequest(rv, connect)
-		if err != nil {
-			return rv, fmt.Errorf("Error Converting CrossConnect %v: %s", c, err)
-		}
+		return NewLocalConnectionConverter(c.GetLocalDestination(), conversionParameters)
 	}
-
 	if c.GetRemoteDestination() != nil {
-		rv, err := NewRemoteConnectionConverter(c.GetRemoteDestination(), "DST-"+c.GetId(), DESTINATION).ToDataRequest(rv, connect)
-		if err != nil {
-			return rv, fmt.Errorf("Error Converting CrossConnect %v: %s", c, err)
-		}
-	}
-
-	if len(rv.Interfaces) < 2 {
-		return nil, fmt.Errorf("Did not create enough interfaces to cross connect, expected at least 2, got %d", len(rv.Interfaces))
+		return NewRemoteConnectionConverter(c.GetRemoteDestination(), "DST-"+c.GetId(), DESTINATION, c.conversionParameters.Log)
 	}
-	ifaces := rv.Interfaces[len(rv.Interfaces)-2:]
-	rv.XCons = append(rv.XCons, &l2.XConnectPairs_XConnectPair{
-		ReceiveInterface:  ifaces[0].Name,
-		TransmitInterface: ifaces[1].Name,
-	})
-	rv.XCons = append(rv.XCons, &l2.XConnectPairs_XConnectPair{
-		ReceiveInterface:  ifaces[1].Name,
-		TransmitInterface: ifaces[0].Name,
-	})
-
-	return rv, nil
+	return nil
 }
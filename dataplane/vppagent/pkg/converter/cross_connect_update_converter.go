@@ -0,0 +1,184 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+)
+
+// CrossConnectUpdateConverter converts a change of cross connect into configuration
+// which should be removed from and added to vppagent.
+type CrossConnectUpdateConverter struct {
+	previous             *CrossConnectConverter
+	current              *CrossConnectConverter
+	conversionParameters *CrossConnectConversionParameters
+}
+
+func NewCrossConnectUpdateConverter(previous, current *crossconnect.CrossConnect, conversionParameters *CrossConnectConversionParameters) *CrossConnectUpdateConverter {
+	return &CrossConnectUpdateConverter{
+		previous:             NewCrossConnectConverter(previous, conversionParameters),
+		current:              NewCrossConnectConverter(current, conversionParameters),
+		conversionParameters: conversionParameters,
+	}
+}
+
+// ToDataRequests returns a configuration to be deleted and a configuration to be put into vppagent to
+// update previous cross connect to current one. Interfaces are recreated only for a side with a changed mechanism
+// or ip address, routes and ARP entries are updated separately.
+func (c *CrossConnectUpdateConverter) ToDataRequests() (*rpc.DataRequest, *rpc.DataRequest, error) {
+	if c.previous.CrossConnect == nil || c.current.CrossConnect == nil {
+		return nil, nil, fmt.Errorf("CrossConnectUpdateConverter requires both previous and current CrossConnect")
+	}
+	if err := c.current.IsComplete(); err != nil {
+		return nil, nil, err
+	}
+	if c.previous.GetId() != c.current.GetId() {
+		// Names of all interfaces are changed, so whole configuration should be replaced
+		del, err := c.previous.ToDataRequest(nil, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		put, err := c.current.ToDataRequest(nil, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		return del, put, nil
+	}
+
+	del := &rpc.DataRequest{}
+	put := &rpc.DataRequest{}
+	interfacesChanged := false
+	sides := []struct {
+		side     ConnectionContextSide
+		previous Converter
+		current  Converter
+	}{
+		{SOURCE, c.previous.sourceConverter(), c.current.sourceConverter()},
+		{DESTINATION, c.previous.destinationConverter(), c.current.destinationConverter()},
+	}
+	for _, s := range sides {
+		if s.previous == nil || s.current == nil {
+			return nil, nil, fmt.Errorf("Error Converting CrossConnect update %v: unsupported mechanism", c.current)
+		}
+		previous, err := toPreviousDataRequest(s.previous)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error Converting CrossConnect %v: %s", c.previous, err)
+		}
+		current, err := s.current.ToDataRequest(nil, true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error Converting CrossConnect %v: %s", c.current, err)
+		}
+		if isSideInterfaceChanged(c.previous.CrossConnect, c.current.CrossConnect, s.side) {
+			interfacesChanged = true
+			appendDataRequest(del, previous)
+			appendDataRequest(put, current)
+			continue
+		}
+		diffDataRequestRoutes(del, put, previous, current)
+	}
+
+	if interfacesChanged {
+		previous, err := c.previous.ToDataRequest(nil, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		current, err := c.current.ToDataRequest(nil, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		del.XCons = append(del.XCons, previous.XCons...)
+		put.XCons = append(put.XCons, current.XCons...)
+	}
+	return del, put, nil
+}
+
+// toPreviousDataRequest converts previous configuration as it was applied, if it is not possible anymore
+// (e.g. network namespace is gone) it is converted for deletion only.
+func toPreviousDataRequest(converter Converter) (*rpc.DataRequest, error) {
+	if rv, err := converter.ToDataRequest(nil, true); err == nil {
+		return rv, nil
+	}
+	return converter.ToDataRequest(nil, false)
+}
+
+// IsEmptyDataRequest checks if DataRequest does not contain any configuration.
+func IsEmptyDataRequest(dataRequest *rpc.DataRequest) bool {
+	return dataRequest == nil || proto.Equal(dataRequest, &rpc.DataRequest{})
+}
+
+func isSideInterfaceChanged(previous, current *crossconnect.CrossConnect, side ConnectionContextSide) bool {
+	if side == SOURCE {
+		if (previous.GetLocalSource() == nil) != (current.GetLocalSource() == nil) {
+			return true
+		}
+		if current.GetLocalSource() != nil {
+			return !proto.Equal(previous.GetLocalSource().GetMechanism(), current.GetLocalSource().GetMechanism()) ||
+				!reflect.DeepEqual(previous.GetLocalSource().GetContext().SrcIpAddrs(), current.GetLocalSource().GetContext().SrcIpAddrs())
+		}
+		return !proto.Equal(previous.GetRemoteSource().GetMechanism(), current.GetRemoteSource().GetMechanism())
+	}
+	if (previous.GetLocalDestination() == nil) != (current.GetLocalDestination() == nil) {
+		return true
+	}
+	if current.GetLocalDestination() != nil {
+		return !proto.Equal(previous.GetLocalDestination().GetMechanism(), current.GetLocalDestination().GetMechanism()) ||
+			!reflect.DeepEqual(previous.GetLocalDestination().GetContext().DstIpAddrs(), current.GetLocalDestination().GetContext().DstIpAddrs())
+	}
+	return !proto.Equal(previous.GetRemoteDestination().GetMechanism(), current.GetRemoteDestination().GetMechanism())
+}
+
+func appendDataRequest(rv, dataRequest *rpc.DataRequest) {
+	rv.Interfaces = append(rv.Interfaces, dataRequest.Interfaces...)
+	rv.LinuxInterfaces = append(rv.LinuxInterfaces, dataRequest.LinuxInterfaces...)
+	rv.LinuxRoutes = append(rv.LinuxRoutes, dataRequest.LinuxRoutes...)
+	rv.LinuxArpEntries = append(rv.LinuxArpEntries, dataRequest.LinuxArpEntries...)
+	rv.StaticRoutes = append(rv.StaticRoutes, dataRequest.StaticRoutes...)
+}
+
+// diffDataRequestRoutes appends routes and ARP entries removed from previous into del and
+// routes and ARP entries added in current into put.
+func diffDataRequestRoutes(del, put, previous, current *rpc.DataRequest) {
+	for _, route := range previous.LinuxRoutes {
+		if !containsMessage(len(current.LinuxRoutes), func(i int) proto.Message { return current.LinuxRoutes[i] }, route) {
+			del.LinuxRoutes = append(del.LinuxRoutes, route)
+		}
+	}
+	for _, route := range current.LinuxRoutes {
+		if !containsMessage(len(previous.LinuxRoutes), func(i int) proto.Message { return previous.LinuxRoutes[i] }, route) {
+			put.LinuxRoutes = append(put.LinuxRoutes, route)
+		}
+	}
+	for _, entry := range previous.LinuxArpEntries {
+		if !containsMessage(len(current.LinuxArpEntries), func(i int) proto.Message { return current.LinuxArpEntries[i] }, entry) {
+			del.LinuxArpEntries = append(del.LinuxArpEntries, entry)
+		}
+	}
+	for _, entry := range current.LinuxArpEntries {
+		if !containsMessage(len(previous.LinuxArpEntries), func(i int) proto.Message { return previous.LinuxArpEntries[i] }, entry) {
+			put.LinuxArpEntries = append(put.LinuxArpEntries, entry)
+		}
+	}
+	for _, route := range previous.StaticRoutes {
+		if !containsMessage(len(current.StaticRoutes), func(i int) proto.Message { return current.StaticRoutes[i] }, route) {
+			del.StaticRoutes = append(del.StaticRoutes, route)
+		}
+	}
+	for _, route := range current.StaticRoutes {
+		if !containsMessage(len(previous.StaticRoutes), func(i int) proto.Message { return previous.StaticRoutes[i] }, route) {
+			put.StaticRoutes = append(put.StaticRoutes, route)
+		}
+	}
+}
+
+// containsMessage checks if any of count messages returned by get is equal to passed one.
+func containsMessage(count int, get func(i int) proto.Message, message proto.Message) bool {
+	for i := 0; i < count; i++ {
+		if proto.Equal(get(i), message) {
+			return true
+		}
+	}
+	return false
+}
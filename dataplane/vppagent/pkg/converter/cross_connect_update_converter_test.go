@@ -0,0 +1,88 @@
+package converter_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	. "github.com/networkservicemesh/networkservicemesh/dataplane/vppagent/pkg/converter"
+	. "github.com/onsi/gomega"
+)
+
+func createTestCrossConnect() *crossconnect.CrossConnect {
+	source := createTestConnection()
+	source.Context.Routes = []*connectioncontext.Route{
+		{Prefix: "10.20.0.0/16"},
+	}
+	return &crossconnect.CrossConnect{
+		Id:      connectionId,
+		Payload: "IP",
+		Source: &crossconnect.CrossConnect_LocalSource{
+			LocalSource: source,
+		},
+		Destination: &crossconnect.CrossConnect_LocalDestination{
+			LocalDestination: createTestConnection(),
+		},
+	}
+}
+
+func TestUpdateConverterUnchanged(t *testing.T) {
+	RegisterTestingT(t)
+	defer os.RemoveAll(baseDir)
+
+	previous := createTestCrossConnect()
+	current := createTestCrossConnect()
+	del, put, err := NewCrossConnectUpdateConverter(previous, current, &CrossConnectConversionParameters{BaseDir: baseDir}).ToDataRequests()
+	Expect(err).To(BeNil())
+	Expect(IsEmptyDataRequest(del)).To(BeTrue())
+	Expect(IsEmptyDataRequest(put)).To(BeTrue())
+}
+
+func TestUpdateConverterRoutesChanged(t *testing.T) {
+	RegisterTestingT(t)
+	defer os.RemoveAll(baseDir)
+
+	previous := createTestCrossConnect()
+	current := createTestCrossConnect()
+	current.GetLocalSource().Context.Routes = []*connectioncontext.Route{
+		{Prefix: "10.20.0.0/16"},
+		{Prefix: "10.40.0.0/16"},
+	}
+	del, put, err := NewCrossConnectUpdateConverter(previous, current, &CrossConnectConversionParameters{BaseDir: baseDir}).ToDataRequests()
+	Expect(err).To(BeNil())
+	Expect(IsEmptyDataRequest(del)).To(BeTrue())
+	Expect(put.Interfaces).To(BeEmpty())
+	Expect(put.XCons).To(BeEmpty())
+	Expect(len(put.StaticRoutes)).To(Equal(1))
+	Expect(put.StaticRoutes[0].DstIpAddr).To(Equal("10.40.0.0/16"))
+
+	del, put, err = NewCrossConnectUpdateConverter(current, previous, &CrossConnectConversionParameters{BaseDir: baseDir}).ToDataRequests()
+	Expect(err).To(BeNil())
+	Expect(IsEmptyDataRequest(put)).To(BeTrue())
+	Expect(del.Interfaces).To(BeEmpty())
+	Expect(len(del.StaticRoutes)).To(Equal(1))
+	Expect(del.StaticRoutes[0].DstIpAddr).To(Equal("10.40.0.0/16"))
+}
+
+func TestUpdateConverterMechanismChanged(t *testing.T) {
+	RegisterTestingT(t)
+	defer os.RemoveAll(baseDir)
+
+	previous := createTestCrossConnect()
+	current := proto.Clone(previous).(*crossconnect.CrossConnect)
+	current.GetLocalDestination().GetMechanism().GetParameters()[connection.SocketFilename] = "other.sock"
+	del, put, err := NewCrossConnectUpdateConverter(previous, current, &CrossConnectConversionParameters{BaseDir: baseDir}).ToDataRequests()
+	Expect(err).To(BeNil())
+
+	Expect(len(del.Interfaces)).To(Equal(1))
+	Expect(del.Interfaces[0].Name).To(Equal("DST-" + connectionId))
+	Expect(len(put.Interfaces)).To(Equal(1))
+	Expect(put.Interfaces[0].Name).To(Equal("DST-" + connectionId))
+	Expect(put.Interfaces[0].Memif.SocketFilename).To(HaveSuffix("other.sock"))
+	Expect(len(put.XCons)).To(Equal(2))
+	Expect(del.StaticRoutes).To(BeEmpty())
+	Expect(put.StaticRoutes).To(BeEmpty())
+}
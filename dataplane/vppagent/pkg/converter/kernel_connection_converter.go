@@ -9,7 +9,6 @@ import (
 	"github.com/ligato/vpp-agent/plugins/vpp/model/interfaces"
 	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
-	"github.com/sirupsen/logrus"
 )
 
 type KernelConnectionConverter struct {
@@ -43,17 +42,19 @@ func (c *KernelConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 	if err != nil && connect {
 		return nil, err
 	}
+	log := logger(c.conversionParameters.Log)
 	tmpIface := TempIfName()
+	log.Infof("Generated unique TempIfName: %s len(TempIfName) %d", tmpIface, len(tmpIface))
 
 	var ipAddresses []string
 	if c.conversionParameters.Side == DESTINATION {
-		ipAddresses = []string{c.Connection.GetContext().DstIpAddr}
+		ipAddresses = c.Connection.GetContext().DstIpAddrs()
 	}
 	if c.conversionParameters.Side == SOURCE {
-		ipAddresses = []string{c.Connection.GetContext().SrcIpAddr}
+		ipAddresses = c.Connection.GetContext().SrcIpAddrs()
 	}
 
-	logrus.Infof("m.GetParameters()[%s]: %s", connection.InterfaceNameKey, m.GetParameters()[connection.InterfaceNameKey])
+	log.Infof("m.GetParameters()[%s]: %s", connection.InterfaceNameKey, m.GetParameters()[connection.InterfaceNameKey])
 
 	// If we have access to /dev/vhost-net, we can use tapv2.  Otherwise fall back to
 	// veth pairs
@@ -79,7 +80,7 @@ func (c *KernelConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 				HostIfName: tmpIface,
 			},
 		})
-		logrus.Info("Found /dev/vhost-net - using tapv2")
+		log.Info("Found /dev/vhost-net - using tapv2")
 		// We apply configuration to LinuxInterfaces
 		// Important details:
 		//    - If you have created a TAP, LinuxInterfaces.Tap.TempIfName must match
@@ -101,7 +102,7 @@ func (c *KernelConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 			},
 		})
 	} else {
-		logrus.Info("Did Not Find /dev/vhost-net - using veth pairs")
+		log.Info("Did Not Find /dev/vhost-net - using veth pairs")
 		rv.LinuxInterfaces = append(rv.LinuxInterfaces, &linux_interfaces.LinuxInterfaces_Interface{
 			Name:        tmpIface,
 			Type:        linux_interfaces.LinuxInterfaces_VETH,
@@ -151,7 +152,7 @@ func (c *KernelConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 					Type:     l3.LinuxStaticRoutes_Route_Namespace_FILE_REF_NS,
 					Filepath: filepath,
 				},
-				GwAddr: extractCleanIPAddress(c.Connection.GetContext().DstIpAddr),
+				GwAddr: extractCleanIPAddress(c.Connection.GetContext().RouteGateway(route.Prefix)),
 			})
 		}
 	}
@@ -0,0 +1,76 @@
+package converter_test
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	. "github.com/networkservicemesh/networkservicemesh/dataplane/vppagent/pkg/converter"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	srcIpv6 = "fd00:30:1::1/126"
+	dstIpv6 = "fd00:30:1::2/126"
+)
+
+func createDualStackKernelConnection() *connection.Connection {
+	return &connection.Connection{
+		Id:             connectionId,
+		NetworkService: networkService,
+		Mechanism: &connection.Mechanism{
+			Type: connection.MechanismType_KERNEL_INTERFACE,
+			Parameters: map[string]string{
+				connection.InterfaceNameKey:        mechanismName,
+				connection.InterfaceDescriptionKey: mechanismDescription,
+				connection.NetNsInodeKey:           "1",
+			},
+		},
+		Context: &connectioncontext.ConnectionContext{
+			SrcIpAddr:   srcIp,
+			DstIpAddr:   dstIp,
+			SrcIpv6Addr: srcIpv6,
+			DstIpv6Addr: dstIpv6,
+			Routes: []*connectioncontext.Route{
+				{Prefix: "8.8.8.8/30"},
+				{Prefix: "fd00:8::/64"},
+			},
+		},
+	}
+}
+
+func TestKernelConverterDualStackSource(t *testing.T) {
+	RegisterTestingT(t)
+
+	converter := NewKernelConnectionConverter(createDualStackKernelConnection(), &ConnectionConversionParameters{
+		Side: SOURCE,
+		Name: interfaceName,
+	})
+	dataRequest, err := converter.ToDataRequest(nil, false)
+	Expect(err).To(BeNil())
+
+	Expect(dataRequest.LinuxInterfaces).ToNot(BeEmpty())
+	for _, linuxInterface := range dataRequest.LinuxInterfaces {
+		Expect(linuxInterface.IpAddresses).To(Equal([]string{srcIp, srcIpv6}))
+	}
+	Expect(len(dataRequest.LinuxRoutes)).To(Equal(2))
+	Expect(dataRequest.LinuxRoutes[0].GwAddr).To(Equal("10.30.1.2"))
+	Expect(dataRequest.LinuxRoutes[1].GwAddr).To(Equal("fd00:30:1::2"))
+}
+
+func TestKernelConverterDualStackDestination(t *testing.T) {
+	RegisterTestingT(t)
+
+	converter := NewKernelConnectionConverter(createDualStackKernelConnection(), &ConnectionConversionParameters{
+		Side: DESTINATION,
+		Name: interfaceName,
+	})
+	dataRequest, err := converter.ToDataRequest(nil, false)
+	Expect(err).To(BeNil())
+
+	Expect(dataRequest.LinuxInterfaces).ToNot(BeEmpty())
+	for _, linuxInterface := range dataRequest.LinuxInterfaces {
+		Expect(linuxInterface.IpAddresses).To(Equal([]string{dstIp, dstIpv6}))
+	}
+	Expect(dataRequest.LinuxRoutes).To(BeEmpty())
+}
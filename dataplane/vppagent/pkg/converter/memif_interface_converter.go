@@ -60,10 +60,10 @@ func (c *MemifInterfaceConverter) ToDataRequest(rv *rpc.DataRequest, connect boo
 
 	var ipAddresses []string
 	if c.conversionParameters.Terminate && c.conversionParameters.Side == DESTINATION {
-		ipAddresses = []string{c.Connection.GetContext().DstIpAddr}
+		ipAddresses = c.Connection.GetContext().DstIpAddrs()
 	}
 	if c.conversionParameters.Terminate && c.conversionParameters.Side == SOURCE {
-		ipAddresses = []string{c.Connection.GetContext().SrcIpAddr}
+		ipAddresses = c.Connection.GetContext().SrcIpAddrs()
 	}
 
 	if c.conversionParameters.Name == "" {
@@ -87,7 +87,7 @@ func (c *MemifInterfaceConverter) ToDataRequest(rv *rpc.DataRequest, connect boo
 			route := &l3.StaticRoutes_Route{
 				DstIpAddr:         route.Prefix,
 				Description:       "Route to " + route.Prefix,
-				NextHopAddr:       extractCleanIPAddress(c.Connection.GetContext().DstIpAddr),
+				NextHopAddr:       extractCleanIPAddress(c.Connection.GetContext().RouteGateway(route.Prefix)),
 				OutgoingInterface: c.conversionParameters.Name,
 			}
 			rv.StaticRoutes = append(rv.StaticRoutes, route)
@@ -28,14 +28,16 @@ type RemoteConnectionConverter struct {
 	*connection.Connection
 	name string
 	side ConnectionContextSide
+	log  *logrus.Entry
 }
 
-// NewRemoteConnectionConverter creates a new remote connection coverter
-func NewRemoteConnectionConverter(c *connection.Connection, name string, side ConnectionContextSide) *RemoteConnectionConverter {
+// NewRemoteConnectionConverter creates a new remote connection coverter, conversion is logged with log if it is set
+func NewRemoteConnectionConverter(c *connection.Connection, name string, side ConnectionContextSide, log *logrus.Entry) *RemoteConnectionConverter {
 	return &RemoteConnectionConverter{
 		Connection: c,
 		name:       name,
 		side:       side,
+		log:        logger(log),
 	}
 }
 
@@ -47,13 +49,19 @@ func (c *RemoteConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 	if err := c.IsComplete(); err != nil {
 		return rv, err
 	}
-	if c.GetMechanism().GetType() != connection.MechanismType_VXLAN {
-		return rv, fmt.Errorf("RemoteConnectionConverter supports only VXLAN. Attempt to use Connection.Mechanism.Type %s", c.GetMechanism().GetType())
-	}
 	if rv == nil {
 		rv = &rpc.DataRequest{}
 	}
 
+	if c.GetMechanism().GetType() != connection.MechanismType_VXLAN {
+		// vppagent interfaces model provides neither GRE tunnels, SRv6 policies nor MPLS in DataRequest, so dataplane
+		// advertises VXLAN only and NSM never requests other mechanisms from it.
+		return rv, fmt.Errorf("RemoteConnectionConverter supports only VXLAN. Attempt to use Connection.Mechanism.Type %s", c.GetMechanism().GetType())
+	}
+	return c.toVxlanDataRequest(rv)
+}
+
+func (c *RemoteConnectionConverter) toVxlanDataRequest(rv *rpc.DataRequest) (*rpc.DataRequest, error) {
 	m := c.GetMechanism()
 
 	// If the remote Connection is DESTINATION Side then srcip/dstip match the Connection
@@ -66,9 +74,9 @@ func (c *RemoteConnectionConverter) ToDataRequest(rv *rpc.DataRequest, connect b
 	}
 	vni, _ := m.VNI()
 
-	logrus.Infof("m.GetParameters()[%s]: %s", connection.VXLANSrcIP, srcip)
-	logrus.Infof("m.GetParameters()[%s]: %s", connection.VXLANDstIP, dstip)
-	logrus.Infof("m.GetParameters()[%s]: %d", connection.VXLANVNI, vni)
+	c.log.Infof("m.GetParameters()[%s]: %s", connection.VXLANSrcIP, srcip)
+	c.log.Infof("m.GetParameters()[%s]: %s", connection.VXLANDstIP, dstip)
+	c.log.Infof("m.GetParameters()[%s]: %d", connection.VXLANVNI, vni)
 
 	rv.Interfaces = append(rv.Interfaces, &interfaces.Interfaces_Interface{
 		Name:    c.name,
@@ -2,7 +2,6 @@ package converter
 
 import (
 	"github.com/rs/xid"
-	"github.com/sirupsen/logrus"
 	"net"
 )
 
@@ -24,7 +23,6 @@ func TempIfName() string {
 
 	rv := guid.String()
 	rv = rv[:7] + rv[16:]
-	logrus.Infof("Generated unique TempIfName: %s len(TempIfName) %d", rv, len(rv))
 	return rv
 }
 
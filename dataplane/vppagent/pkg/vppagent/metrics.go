@@ -0,0 +1,21 @@
+package vppagent
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	operationRequest = "request"
+	operationUpdate  = "update"
+	operationClose   = "close"
+)
+
+// programmingErrorsTotal - number of cross connects failed to be programmed into vppagent per operation.
+var programmingErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "vppagent_dataplane_programming_errors_total",
+	Help: "Number of cross connects failed to be programmed into vppagent per operation.",
+}, []string{"operation"})
+
+func init() {
+	prometheus.MustRegister(programmingErrorsTotal)
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -68,8 +69,9 @@ func liveness(w http.ResponseWriter, r *http.Request) {
 }
 
 func BeginHealthCheck() {
-	logrus.Debug("Starting VPP Agent liveness/readiness healthcheck")
+	logrus.Debug("Starting VPP Agent liveness/readiness healthcheck and metrics")
 	http.HandleFunc("/liveness", liveness)
 	http.HandleFunc("/readiness", readiness)
+	http.Handle("/metrics", promhttp.Handler())
 	http.ListenAndServe(healthcheckProbesPort, nil)
 }
@@ -34,6 +34,7 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
@@ -71,6 +72,7 @@ func NewVPPAgent(vppAgentEndpoint string, monitor *crossconnect_monitor.CrossCon
 					Type: local.MechanismType_MEM_INTERFACE,
 				},
 			},
+			// Only VXLAN is advertised, since converter could not program other remote mechanisms into vppagent.
 			remoteMechanisms: []*remote.Mechanism{
 				{
 					Type: remote.MechanismType_VXLAN,
@@ -123,17 +125,23 @@ func (v *VPPAgent) MonitorMechanisms(empty *empty.Empty, updateSrv dataplane.Dat
 }
 
 func (v *VPPAgent) Request(ctx context.Context, crossConnect *crossconnect.CrossConnect) (*crossconnect.CrossConnect, error) {
-	logrus.Infof("Request(ConnectRequest) called with %v", crossConnect)
+	ctx, requestId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("Request(ConnectRequest:%v) called with %v", requestId, crossConnect)
 	xcon, err := v.ConnectOrDisConnect(ctx, crossConnect, true)
+	if err != nil {
+		programmingErrorsTotal.WithLabelValues(operationRequest).Inc()
+	}
 	v.monitor.Update(xcon)
-	logrus.Infof("Request(ConnectRequest) called with %v returning: %v", crossConnect, xcon)
+	log.Infof("Request(ConnectRequest:%v) called with %v returning: %v", requestId, crossConnect, xcon)
 	return xcon, err
 }
 
 func (v *VPPAgent) ConnectOrDisConnect(ctx context.Context, crossConnect *crossconnect.CrossConnect, connect bool) (*crossconnect.CrossConnect, error) {
-	if crossConnect.GetLocalSource().GetMechanism().GetType() == local.MechanismType_MEM_INTERFACE &&
-		crossConnect.GetLocalDestination().GetMechanism().GetType() == local.MechanismType_MEM_INTERFACE {
-		return v.directMemifConnector.ConnectOrDisConnect(crossConnect, connect)
+	log := tools.Log(ctx)
+	if isDirectMemif(crossConnect) {
+		xcon, err := v.directMemifConnector.ConnectOrDisConnect(crossConnect, connect)
+		return xcon, dataplaneError(err, codes.Internal)
 	}
 
 	// TODO look at whether keepin a single conn might be better
@@ -144,34 +152,121 @@ func (v *VPPAgent) ConnectOrDisConnect(ctx context.Context, crossConnect *crossc
 		grpc.WithStreamInterceptor(
 			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
 	if err != nil {
-		logrus.Errorf("can't dial grpc server: %v", err)
-		return nil, err
+		log.Errorf("can't dial grpc server: %v", err)
+		return nil, dataplaneError(err, codes.Unavailable)
 	}
 	defer conn.Close()
 	client := rpc.NewDataChangeServiceClient(conn)
 	conversionParameters := &converter.CrossConnectConversionParameters{
 		BaseDir: v.baseDir,
+		Log:     log,
 	}
 	dataChange, err := converter.NewCrossConnectConverter(crossConnect, conversionParameters).ToDataRequest(nil, connect)
 	if err != nil {
-		logrus.Error(err)
-		return nil, err
+		log.Error(err)
+		return nil, dataplaneError(err, codes.InvalidArgument)
 	}
-	logrus.Infof("Sending DataChange to vppagent: %v", dataChange)
+	log.Infof("Sending DataChange to vppagent: %v", dataChange)
 	if connect {
 		_, err = client.Put(ctx, dataChange)
 	} else {
 		_, err = client.Del(ctx, dataChange)
 	}
 	if err != nil {
-		logrus.Error(err)
+		log.Error(err)
 		// TODO handle connection tracking
 		// TODO handle teardown of any partial config that happened
-		return crossConnect, err
+		return crossConnect, dataplaneError(err, codes.Unavailable)
 	}
 	return crossConnect, nil
 }
 
+// Update updates existing cross connect in place, only a difference between previous and current
+// cross connect is applied to vppagent.
+func (v *VPPAgent) Update(ctx context.Context, update *dataplane.CrossConnectUpdate) (*crossconnect.CrossConnect, error) {
+	ctx, requestId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("Update(CrossConnectUpdate:%v) called with %v", requestId, update)
+	xcon, err := v.updateCrossConnect(ctx, update.GetPrevious(), update.GetCurrent())
+	if err != nil {
+		log.Errorf("Update(CrossConnectUpdate:%v) %v", requestId, err)
+		programmingErrorsTotal.WithLabelValues(operationUpdate).Inc()
+		return xcon, err
+	}
+	v.monitor.Update(xcon)
+	log.Infof("Update(CrossConnectUpdate:%v) called with %v returning: %v", requestId, update, xcon)
+	return xcon, nil
+}
+
+func (v *VPPAgent) updateCrossConnect(ctx context.Context, previous, current *crossconnect.CrossConnect) (*crossconnect.CrossConnect, error) {
+	log := tools.Log(ctx)
+	if isDirectMemif(previous) || isDirectMemif(current) {
+		// Direct memif connections are not programmed into vppagent, so just reconnect them
+		if _, err := v.ConnectOrDisConnect(ctx, previous, false); err != nil {
+			log.Warn(err)
+		}
+		return v.ConnectOrDisConnect(ctx, current, true)
+	}
+
+	conversionParameters := &converter.CrossConnectConversionParameters{
+		BaseDir: v.baseDir,
+		Log:     log,
+	}
+	dataDel, dataPut, err := converter.NewCrossConnectUpdateConverter(previous, current, conversionParameters).ToDataRequests()
+	if err != nil {
+		return nil, dataplaneError(err, codes.InvalidArgument)
+	}
+
+	tracer := opentracing.GlobalTracer()
+	conn, err := grpc.Dial(v.vppAgentEndpoint, grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(
+			otgrpc.OpenTracingClientInterceptor(tracer, otgrpc.LogPayloads())),
+		grpc.WithStreamInterceptor(
+			otgrpc.OpenTracingStreamClientInterceptor(tracer)))
+	if err != nil {
+		log.Errorf("can't dial grpc server: %v", err)
+		return nil, dataplaneError(err, codes.Unavailable)
+	}
+	defer conn.Close()
+	client := rpc.NewDataChangeServiceClient(conn)
+
+	if !converter.IsEmptyDataRequest(dataDel) {
+		log.Infof("Sending DataChange delete to vppagent: %v", dataDel)
+		if _, err := client.Del(ctx, dataDel); err != nil {
+			return current, dataplaneError(err, codes.Unavailable)
+		}
+	}
+	if !converter.IsEmptyDataRequest(dataPut) {
+		log.Infof("Sending DataChange put to vppagent: %v", dataPut)
+		if _, err := client.Put(ctx, dataPut); err != nil {
+			return current, dataplaneError(err, codes.Unavailable)
+		}
+	}
+	return current, nil
+}
+
+// dataplaneError - returns error with gRPC status, so NSM could tell transient failures from permanent ones and retry
+// only the former. Errors returned by vppagent keep their status, other errors get code passed. vppagent returns plain
+// errors when it fails to configure vpp, they come with codes.Unknown and so get code passed too.
+func dataplaneError(err error, code codes.Code) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return status.Error(code, err.Error())
+	}
+	if st.Code() == codes.Unknown {
+		return status.Error(code, st.Message())
+	}
+	return err
+}
+
+func isDirectMemif(crossConnect *crossconnect.CrossConnect) bool {
+	return crossConnect.GetLocalSource().GetMechanism().GetType() == local.MechanismType_MEM_INTERFACE &&
+		crossConnect.GetLocalDestination().GetMechanism().GetType() == local.MechanismType_MEM_INTERFACE
+}
+
 func (v *VPPAgent) reset() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 	defer cancel()
@@ -236,10 +331,13 @@ func (v *VPPAgent) programMgmtInterface() error {
 }
 
 func (v *VPPAgent) Close(ctx context.Context, crossConnect *crossconnect.CrossConnect) (*empty.Empty, error) {
-	logrus.Infof("vppagent.DisconnectRequest called with %#v", crossConnect)
+	ctx, requestId := tools.EnsureCorrelationId(ctx)
+	log := tools.Log(ctx)
+	log.Infof("vppagent.DisconnectRequest(%v) called with %#v", requestId, crossConnect)
 	xcon, err := v.ConnectOrDisConnect(ctx, crossConnect, false)
 	if err != nil {
-		logrus.Warn(err)
+		log.Warnf("vppagent.DisconnectRequest(%v) %v", requestId, err)
+		programmingErrorsTotal.WithLabelValues(operationClose).Inc()
 	}
 	v.monitor.Delete(xcon)
 	return &empty.Empty{}, nil
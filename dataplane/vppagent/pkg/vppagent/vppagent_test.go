@@ -0,0 +1,119 @@
+package vppagent
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/ligato/vpp-agent/plugins/vpp/model/rpc"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/crossconnect"
+	local "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
+	remote "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/remote/connection"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingDataChangeService - vppagent failing every change with a plain error, like it does when vpp rejects it
+type failingDataChangeService struct{}
+
+func (failingDataChangeService) Put(context.Context, *rpc.DataRequest) (*rpc.PutResponse, error) {
+	return nil, fmt.Errorf("failed to configure vpp")
+}
+
+func (failingDataChangeService) Del(context.Context, *rpc.DataRequest) (*rpc.DelResponse, error) {
+	return nil, fmt.Errorf("failed to configure vpp")
+}
+
+func startFailingVppAgent() (string, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	server := grpc.NewServer()
+	rpc.RegisterDataChangeServiceServer(server, failingDataChangeService{})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	return listener.Addr().String(), server.Stop
+}
+
+func createTestCrossConnect() *crossconnect.CrossConnect {
+	ctx := &connectioncontext.ConnectionContext{
+		SrcIpAddr: "10.20.1.1/30",
+		DstIpAddr: "10.20.1.2/30",
+	}
+	return &crossconnect.CrossConnect{
+		Id:      "1",
+		Payload: "IP",
+		Source: &crossconnect.CrossConnect_LocalSource{
+			LocalSource: &local.Connection{
+				Id:             "1",
+				NetworkService: "network-service",
+				Context:        ctx,
+				Mechanism: &local.Mechanism{
+					Type: local.MechanismType_MEM_INTERFACE,
+					Parameters: map[string]string{
+						local.InterfaceNameKey: "src",
+						local.Workspace:        "workspace",
+					},
+				},
+			},
+		},
+		Destination: &crossconnect.CrossConnect_RemoteDestination{
+			RemoteDestination: &remote.Connection{
+				Id:             "2",
+				NetworkService: "network-service",
+				Context:        ctx,
+				Mechanism: &remote.Mechanism{
+					Type: remote.MechanismType_VXLAN,
+					Parameters: map[string]string{
+						remote.VXLANSrcIP: "10.0.0.1",
+						remote.VXLANDstIP: "10.0.0.2",
+						remote.VXLANVNI:   "1",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInvalidCrossConnectIsNotRetryable(t *testing.T) {
+	RegisterTestingT(t)
+
+	v := &VPPAgent{vppAgentEndpoint: "127.0.0.1:0"}
+	_, err := v.ConnectOrDisConnect(context.Background(), &crossconnect.CrossConnect{}, true)
+	Expect(err).NotTo(BeNil())
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+}
+
+func TestVppAgentFailureIsRetryable(t *testing.T) {
+	RegisterTestingT(t)
+
+	endpoint, stop := startFailingVppAgent()
+	defer stop()
+	baseDir, err := ioutil.TempDir("", "vppagent")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(baseDir)
+
+	v := &VPPAgent{vppAgentEndpoint: endpoint, baseDir: baseDir}
+	_, err = v.ConnectOrDisConnect(context.Background(), createTestCrossConnect(), true)
+	Expect(err).NotTo(BeNil())
+	Expect(status.Code(err)).To(Equal(codes.Unavailable))
+
+	_, err = v.ConnectOrDisConnect(context.Background(), createTestCrossConnect(), false)
+	Expect(err).NotTo(BeNil())
+	Expect(status.Code(err)).To(Equal(codes.Unavailable))
+}
+
+func TestDataplaneErrorKeepsStatus(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(dataplaneError(nil, codes.Unavailable)).To(BeNil())
+	Expect(status.Code(dataplaneError(fmt.Errorf("failed"), codes.Unavailable))).To(Equal(codes.Unavailable))
+	Expect(status.Code(dataplaneError(status.Error(codes.Unknown, "failed"), codes.Unavailable))).To(Equal(codes.Unavailable))
+	Expect(status.Code(dataplaneError(status.Error(codes.Aborted, "failed"), codes.Unavailable))).To(Equal(codes.Aborted))
+}
@@ -28,8 +28,12 @@ import (
 
 func main() {
 
+	ipamEndpoint, err := composite.NewIpamCompositeEndpoint(nil)
+	if err != nil {
+		logrus.Fatalf("%v", err)
+	}
 	composite := composite.NewMonitorCompositeEndpoint(nil).SetNext(
-		composite.NewIpamCompositeEndpoint(nil).SetNext(
+		ipamEndpoint.SetNext(
 			composite.NewConnectionCompositeEndpoint(nil)))
 
 	nsmEndpoint, err := endpoint.NewNSMEndpoint(nil, nil, composite)
@@ -10,10 +10,15 @@ import (
 	"syscall"
 
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/clientset/versioned"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/prefix_collector"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/prefix_lease"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/registryserver"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/util/homedir"
@@ -32,6 +37,8 @@ func main() {
 	opentracing.SetGlobalTracer(tracer)
 	defer closer.Close()
 
+	go registryserver.BeginHealthCheck()
+
 	address := os.Getenv("NSMD_K8S_ADDRESS")
 	if strings.TrimSpace(address) == "" {
 		address = "127.0.0.1:5000"
@@ -67,7 +74,26 @@ func main() {
 		logrus.Fatalln(err)
 	}
 
-	server := registryserver.New(nsmClientSet, nsmName)
+	// NSMDs should present certificates signed by CA, if TLS is configured.
+	securityProvider, err := security.NewProviderFromEnv()
+	if err != nil {
+		logrus.Fatalln("Invalid TLS configuration", err)
+	}
+
+	// Pod and service CIDRs are published to NSMDs, so endpoints do not allocate addresses colliding with them.
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		logrus.Fatalln("Unable to create Kubernetes clientset", err)
+	}
+	prefixCollector := prefix_collector.NewPrefixCollector(clientset, strings.TrimSpace(os.Getenv(prefix_collector.ServiceCidrEnv)))
+	if err := prefixCollector.Start(make(chan struct{})); err != nil {
+		logrus.Errorf("Failed to start cluster prefixes discovery: %v", err)
+	}
+
+	// Replicas of endpoints lease disjoint sub-prefixes of their network service, so their addresses do not overlap.
+	prefixLeases := prefix_lease.NewPrefixLeases(clientset, metav1.NamespaceDefault, prefix_lease.DefaultLeaseDuration)
+
+	server := registryserver.New(nsmClientSet, nsmName, securityProvider, prefixCollector, prefixLeases)
 
 	logrus.Print("nsmd-k8s intialized and waiting for connection")
 	err = server.Serve(listener)
@@ -38,7 +38,7 @@ import (
 
 const (
 	// SocketBaseDir defines the location of NSM client socket
-	resourceName = "networkservicemesh.io/socket"
+	resourceName = nsmd.NsmResourceName
 	// ServerSock defines the name of NSM client socket
 	ServerSock = "networkservicemesh.io.sock"
 )
@@ -7,10 +7,11 @@ import (
 type State string
 
 const (
-	OFFLINE = "OFFLINE"
-	RUNNING = "RUNNING"
-	PAUSED  = "PAUSED"
-	ERROR   = "ERROR"
+	OFFLINE  = "OFFLINE"
+	RUNNING  = "RUNNING"
+	PAUSED   = "PAUSED"
+	ERROR    = "ERROR"
+	DRAINING = "DRAINING"
 )
 
 // +genclient
@@ -25,8 +26,19 @@ type NetworkService struct {
 }
 
 type NetworkServiceSpec struct {
-	Payload string   `json:"payload"`
-	Matches []*Match `json:"matches"`
+	Payload           string       `json:"payload"`
+	Matches           []*Match     `json:"matches"`
+	SelectionStrategy string       `json:"selectionStrategy,omitempty"`
+	RetryPolicy       *RetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type RetryPolicy struct {
+	MaxAttempts       uint32   `json:"maxAttempts,omitempty"`
+	InitialBackoffMs  uint32   `json:"initialBackoffMs,omitempty"`
+	MaxBackoffMs      uint32   `json:"maxBackoffMs,omitempty"`
+	BackoffMultiplier float64  `json:"backoffMultiplier,omitempty"`
+	Jitter            float64  `json:"jitter,omitempty"`
+	RetryableCodes    []string `json:"retryableCodes,omitempty"`
 }
 
 type Match struct {
@@ -101,4 +113,6 @@ type NetworkServiceManagerStatus struct {
 	LastSeen metaV1.Time `json:"lastseen"`
 	URL      string      `json:"url"`
 	State    State       `json:"state"`
+	// RemoteMechanisms - remote mechanism types dataplanes of NSM support, they are unknown if empty.
+	RemoteMechanisms []string `json:"remoteMechanisms,omitempty"`
 }
@@ -315,6 +315,11 @@ func (in *NetworkServiceManagerSpec) DeepCopy() *NetworkServiceManagerSpec {
 func (in *NetworkServiceManagerStatus) DeepCopyInto(out *NetworkServiceManagerStatus) {
 	*out = *in
 	in.LastSeen.DeepCopyInto(&out.LastSeen)
+	if in.RemoteMechanisms != nil {
+		in, out := &in.RemoteMechanisms, &out.RemoteMechanisms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -342,6 +347,11 @@ func (in *NetworkServiceSpec) DeepCopyInto(out *NetworkServiceSpec) {
 			}
 		}
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -370,3 +380,24 @@ func (in *NetworkServiceStatus) DeepCopy() *NetworkServiceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.RetryableCodes != nil {
+		in, out := &in.RetryableCodes, &out.RetryableCodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
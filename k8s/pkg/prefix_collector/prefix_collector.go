@@ -0,0 +1,218 @@
+package prefix_collector
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// KubeadmConfigMap - config map kubeadm stores cluster configuration to.
+	KubeadmConfigMap = "kubeadm-config"
+	// ServiceCidrEnv - service CIDR of cluster, it is read from kubeadm configuration if not set.
+	ServiceCidrEnv = "NSM_SERVICE_CIDR"
+	// CacheSyncTimeout - time to wait for nodes to be listed on start, nodes are tracked in background after it.
+	CacheSyncTimeout = 30 * time.Second
+
+	resyncPeriod = 10 * time.Minute
+)
+
+// PrefixCollector - discovers prefixes used by Kubernetes cluster: pod CIDRs of nodes and service CIDR, so
+// endpoints do not allocate addresses colliding with them. Pod CIDRs are tracked while nodes are added or removed.
+type PrefixCollector struct {
+	sync.Mutex
+	clientset     kubernetes.Interface
+	syncTimeout   time.Duration
+	nodePrefixes  map[string]string
+	servicePrefix string
+	prefixes      []string
+	subscribers   []chan []string
+}
+
+// NewPrefixCollector - creates collector of prefixes using clientset, servicePrefix is a configured service CIDR,
+// it is read from kubeadm configuration if empty.
+func NewPrefixCollector(clientset kubernetes.Interface, servicePrefix string) *PrefixCollector {
+	return &PrefixCollector{
+		clientset:     clientset,
+		syncTimeout:   CacheSyncTimeout,
+		nodePrefixes:  map[string]string{},
+		servicePrefix: servicePrefix,
+		prefixes:      []string{},
+	}
+}
+
+// Start - discovers service CIDR and starts tracking pod CIDRs of nodes until stopCh is closed. Pod CIDRs are known
+// once Start returns without error, if nodes are not listed in CacheSyncTimeout they are tracked in background.
+func (c *PrefixCollector) Start(stopCh <-chan struct{}) error {
+	servicePrefix, err := c.discoverServicePrefix()
+	if err != nil {
+		logrus.Warnf("Prefix collector: service CIDR is not discovered, it is not excluded, set %s to configure it: %v", ServiceCidrEnv, err)
+	} else {
+		logrus.Infof("Prefix collector: service CIDR is %s", servicePrefix)
+	}
+	c.Lock()
+	c.servicePrefix = servicePrefix
+	c.update()
+	c.Unlock()
+
+	factory := informers.NewSharedInformerFactory(c.clientset, resyncPeriod)
+	informer := factory.Core().V1().Nodes().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.setNode(obj.(*v1.Node))
+		},
+		UpdateFunc: func(old interface{}, new interface{}) {
+			c.setNode(new.(*v1.Node))
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if node, ok := obj.(*v1.Node); ok {
+				c.deleteNode(node.Name)
+			}
+		},
+	})
+	factory.Start(stopCh)
+
+	timeoutCh := make(chan struct{})
+	timer := time.AfterFunc(c.syncTimeout, func() {
+		close(timeoutCh)
+	})
+	defer timer.Stop()
+	syncStopCh := make(chan struct{})
+	go func() {
+		defer close(syncStopCh)
+		select {
+		case <-stopCh:
+		case <-timeoutCh:
+		}
+	}()
+	if !cache.WaitForCacheSync(syncStopCh, informer.HasSynced) {
+		return fmt.Errorf("nodes are not synced in %v, pod CIDRs are tracked in background", c.syncTimeout)
+	}
+	return nil
+}
+
+// Prefixes - returns sorted prefixes currently used by cluster.
+func (c *PrefixCollector) Prefixes() []string {
+	c.Lock()
+	defer c.Unlock()
+	return append([]string{}, c.prefixes...)
+}
+
+// Subscribe - returns channel receiving prefixes every time they are changed, starting with current ones. Only the
+// latest prefixes are kept for slow subscriber. Returned function stops subscription.
+func (c *PrefixCollector) Subscribe() (<-chan []string, func()) {
+	c.Lock()
+	defer c.Unlock()
+	ch := make(chan []string, 1)
+	ch <- append([]string{}, c.prefixes...)
+	c.subscribers = append(c.subscribers, ch)
+	return ch, func() {
+		c.Lock()
+		defer c.Unlock()
+		for i, subscriber := range c.subscribers {
+			if subscriber == ch {
+				c.subscribers = append(c.subscribers[:i], c.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (c *PrefixCollector) setNode(node *v1.Node) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.nodePrefixes, node.Name)
+	if node.Spec.PodCIDR != "" {
+		if prefix, err := validPrefix(node.Spec.PodCIDR); err != nil {
+			logrus.Errorf("Prefix collector: pod CIDR of node %s is ignored: %v", node.Name, err)
+		} else {
+			c.nodePrefixes[node.Name] = prefix
+		}
+	}
+	c.update()
+}
+
+func (c *PrefixCollector) deleteNode(name string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.nodePrefixes, name)
+	c.update()
+}
+
+// update - recalculates prefixes and notifies subscribers if they are changed, should be called with lock held.
+func (c *PrefixCollector) update() {
+	unique := map[string]bool{}
+	if c.servicePrefix != "" {
+		unique[c.servicePrefix] = true
+	}
+	for _, prefix := range c.nodePrefixes {
+		unique[prefix] = true
+	}
+	prefixes := make([]string, 0, len(unique))
+	for prefix := range unique {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	if reflect.DeepEqual(prefixes, c.prefixes) {
+		return
+	}
+	logrus.Infof("Prefix collector: cluster prefixes are changed: %v", prefixes)
+	c.prefixes = prefixes
+	for _, subscriber := range c.subscribers {
+		// Drop prefixes subscriber has not received yet, they are outdated.
+		select {
+		case <-subscriber:
+		default:
+		}
+		subscriber <- append([]string{}, prefixes...)
+	}
+}
+
+// discoverServicePrefix - returns configured service CIDR, or reads it from kubeadm configuration.
+func (c *PrefixCollector) discoverServicePrefix() (string, error) {
+	c.Lock()
+	servicePrefix := c.servicePrefix
+	c.Unlock()
+	if servicePrefix != "" {
+		return validPrefix(servicePrefix)
+	}
+	return c.kubeadmServicePrefix()
+}
+
+func (c *PrefixCollector) kubeadmServicePrefix() (string, error) {
+	configMap, err := c.clientset.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(KubeadmConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	clusterConfiguration := &struct {
+		Networking struct {
+			ServiceSubnet string `json:"serviceSubnet"`
+		} `json:"networking"`
+	}{}
+	if err := yaml.Unmarshal([]byte(configMap.Data["ClusterConfiguration"]), clusterConfiguration); err != nil {
+		return "", err
+	}
+	return validPrefix(clusterConfiguration.Networking.ServiceSubnet)
+}
+
+func validPrefix(prefix string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return "", fmt.Errorf("invalid prefix %q: %v", prefix, err)
+	}
+	return ipNet.String(), nil
+}
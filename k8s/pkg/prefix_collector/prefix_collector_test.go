@@ -0,0 +1,101 @@
+package prefix_collector
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func node(name, podCidr string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1.NodeSpec{PodCIDR: podCidr},
+	}
+}
+
+func kubeadmConfig(serviceSubnet string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: KubeadmConfigMap, Namespace: metav1.NamespaceSystem},
+		Data: map[string]string{
+			"ClusterConfiguration": fmt.Sprintf("networking:\n  podSubnet: 10.244.0.0/16\n  serviceSubnet: %s\n", serviceSubnet),
+		},
+	}
+}
+
+func receive(updates <-chan []string) []string {
+	select {
+	case prefixes := <-updates:
+		return prefixes
+	case <-time.After(5 * time.Second):
+		return nil
+	}
+}
+
+func TestCollectPrefixes(t *testing.T) {
+	RegisterTestingT(t)
+
+	clientset := fake.NewSimpleClientset(kubeadmConfig("10.96.0.0/12"), node("node-1", "10.244.0.0/24"), node("node-2", ""))
+	collector := NewPrefixCollector(clientset, "")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	Expect(collector.Start(stopCh)).To(BeNil())
+	Expect(collector.Prefixes()).To(Equal([]string{"10.244.0.0/24", "10.96.0.0/12"}))
+
+	updates, unsubscribe := collector.Subscribe()
+	defer unsubscribe()
+	Expect(receive(updates)).To(Equal([]string{"10.244.0.0/24", "10.96.0.0/12"}))
+
+	_, err := clientset.CoreV1().Nodes().Update(node("node-2", "10.244.1.0/24"))
+	Expect(err).To(BeNil())
+	Expect(receive(updates)).To(Equal([]string{"10.244.0.0/24", "10.244.1.0/24", "10.96.0.0/12"}))
+
+	Expect(clientset.CoreV1().Nodes().Delete("node-1", &metav1.DeleteOptions{})).To(BeNil())
+	Expect(receive(updates)).To(Equal([]string{"10.244.1.0/24", "10.96.0.0/12"}))
+	Expect(collector.Prefixes()).To(Equal([]string{"10.244.1.0/24", "10.96.0.0/12"}))
+}
+
+func TestConfiguredServicePrefix(t *testing.T) {
+	RegisterTestingT(t)
+
+	// Configured service CIDR is used instead of kubeadm configuration.
+	clientset := fake.NewSimpleClientset(kubeadmConfig("10.96.0.0/12"), node("node-1", "10.244.0.0/24"))
+	collector := NewPrefixCollector(clientset, "10.100.0.0/16")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	Expect(collector.Start(stopCh)).To(BeNil())
+	Expect(collector.Prefixes()).To(Equal([]string{"10.100.0.0/16", "10.244.0.0/24"}))
+}
+
+func TestServicePrefixIsNotDiscovered(t *testing.T) {
+	RegisterTestingT(t)
+
+	clientset := fake.NewSimpleClientset(kubeadmConfig("invalid"), node("node-1", "10.244.0.0/24"))
+	collector := NewPrefixCollector(clientset, "")
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	Expect(collector.Start(stopCh)).To(BeNil())
+	Expect(collector.Prefixes()).To(Equal([]string{"10.244.0.0/24"}))
+}
+
+func TestStartDoesNotBlockWithoutNodes(t *testing.T) {
+	RegisterTestingT(t)
+
+	clientset := fake.NewSimpleClientset(kubeadmConfig("10.96.0.0/12"))
+	clientset.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.NewServiceUnavailable("API server is not available")
+	})
+	collector := NewPrefixCollector(clientset, "")
+	collector.syncTimeout = 100 * time.Millisecond
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	Expect(collector.Start(stopCh)).NotTo(BeNil())
+	Expect(collector.Prefixes()).To(Equal([]string{"10.96.0.0/12"}))
+}
@@ -0,0 +1,212 @@
+package prefix_lease
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/prefix_pool"
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	// LeasesConfigMap - config map leases are stored to, every network service has its own key.
+	LeasesConfigMap = "nsm-prefix-leases"
+	// DefaultLeaseDuration - duration lease is valid for if it is not renewed.
+	DefaultLeaseDuration = 10 * time.Minute
+)
+
+// Lease - sub-prefixes leased to owner till expiration time.
+type Lease struct {
+	Prefixes []string  `json:"prefixes"`
+	Expires  time.Time `json:"expires"`
+}
+
+// PrefixLeases - leases disjoint sub-prefixes of network service address space to its endpoints, so replicas of
+// endpoint allocate addresses not colliding with each other. Leases are stored in config map, concurrent updates of
+// registries running on different nodes are resolved by optimistic locking.
+type PrefixLeases struct {
+	clientset kubernetes.Interface
+	namespace string
+	duration  time.Duration
+}
+
+// NewPrefixLeases - creates leases stored in namespace using clientset, leases not renewed for duration expire.
+func NewPrefixLeases(clientset kubernetes.Interface, namespace string, duration time.Duration) *PrefixLeases {
+	return &PrefixLeases{
+		clientset: clientset,
+		namespace: namespace,
+		duration:  duration,
+	}
+}
+
+// Lease - leases owner a sub-prefix of prefixLen for every family of prefixes, sub-prefixes leased to other owners
+// are never returned. Lease of owner is renewed if it is still valid, expired one is renewed if its sub-prefixes are
+// not leased to others meanwhile.
+func (p *PrefixLeases) Lease(networkService string, owner string, prefixes []string, prefixLen map[connectioncontext.IpFamily_Family]uint32) (*Lease, error) {
+	if err := validateNetworkService(networkService); err != nil {
+		return nil, err
+	}
+	if owner == "" {
+		return nil, fmt.Errorf("owner of lease should be set")
+	}
+	families := []connectioncontext.IpFamily_Family{}
+	for _, prefix := range prefixes {
+		ip, _, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prefix %q: %v", prefix, err)
+		}
+		family := connectioncontext.IpFamily_IPV6
+		if ip.To4() != nil {
+			family = connectioncontext.IpFamily_IPV4
+		}
+		if !hasFamily(families, family) {
+			families = append(families, family)
+		}
+	}
+	if len(families) == 0 {
+		return nil, fmt.Errorf("prefixes to lease from should be set")
+	}
+
+	var result *Lease
+	err := p.update(networkService, func(leases map[string]*Lease) error {
+		now := time.Now()
+		free := prefixes
+		for leaseOwner, lease := range leases {
+			if leaseOwner == owner {
+				continue
+			}
+			if lease.Expires.Before(now) {
+				delete(leases, leaseOwner)
+				continue
+			}
+			var err error
+			if free, err = prefix_pool.ExcludePrefixes(free, lease.Prefixes...); err != nil {
+				return err
+			}
+		}
+		lease := leases[owner]
+		if lease == nil || !isLeasable(lease.Prefixes, free, len(families)) {
+			lease = &Lease{}
+			for _, family := range families {
+				leased, remaining, err := prefix_pool.ExtractPrefixes(free, &connectioncontext.ExtraPrefixRequest{
+					AddrFamily:      &connectioncontext.IpFamily{Family: family},
+					PrefixLen:       prefixLen[family],
+					RequiredNumber:  1,
+					RequestedNumber: 1,
+				})
+				if err != nil {
+					return fmt.Errorf("no free %v prefix of length %d is left in %v: %v", family, prefixLen[family], prefixes, err)
+				}
+				lease.Prefixes = append(lease.Prefixes, leased...)
+				free = remaining
+			}
+			logrus.Infof("Prefixes %v of network service %s are leased to %s", lease.Prefixes, networkService, owner)
+		}
+		lease.Expires = now.Add(p.duration)
+		leases[owner] = lease
+		result = &Lease{
+			Prefixes: append([]string{}, lease.Prefixes...),
+			Expires:  lease.Expires,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Release - releases sub-prefixes leased to owner, so they could be leased to others.
+func (p *PrefixLeases) Release(networkService string, owner string) error {
+	if err := validateNetworkService(networkService); err != nil {
+		return err
+	}
+	return p.update(networkService, func(leases map[string]*Lease) error {
+		if lease, ok := leases[owner]; ok {
+			logrus.Infof("Prefixes %v of network service %s are released by %s", lease.Prefixes, networkService, owner)
+			delete(leases, owner)
+		}
+		return nil
+	})
+}
+
+// update - reads leases of network service, modifies and writes them back, retrying if config map is updated by
+// other registry meanwhile.
+func (p *PrefixLeases) update(networkService string, modify func(leases map[string]*Lease) error) error {
+	configMaps := p.clientset.CoreV1().ConfigMaps(p.namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := configMaps.Get(LeasesConfigMap, metav1.GetOptions{})
+		exists := err == nil
+		if errors.IsNotFound(err) {
+			configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: LeasesConfigMap, Namespace: p.namespace},
+			}
+		} else if err != nil {
+			return err
+		}
+
+		leases := map[string]*Lease{}
+		if data, ok := configMap.Data[networkService]; ok {
+			if err := json.Unmarshal([]byte(data), &leases); err != nil {
+				return fmt.Errorf("failed to parse leases of network service %s: %v", networkService, err)
+			}
+		}
+		if err := modify(leases); err != nil {
+			return err
+		}
+		data, err := json.Marshal(leases)
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[networkService] = string(data)
+
+		if exists {
+			_, err = configMaps.Update(configMap)
+			return err
+		}
+		_, err = configMaps.Create(configMap)
+		if errors.IsAlreadyExists(err) {
+			// Config map is created by other registry meanwhile, leases should be read again.
+			return errors.NewConflict(v1.Resource("configmaps"), LeasesConfigMap, err)
+		}
+		return err
+	})
+}
+
+// isLeasable - checks leased prefixes cover every family and are not leased to others, i.e. lie within free prefixes.
+func isLeasable(leased []string, free []string, families int) bool {
+	if len(leased) != families {
+		return false
+	}
+	outside, err := prefix_pool.ExcludePrefixes(leased, free...)
+	return err == nil && len(outside) == 0
+}
+
+func hasFamily(families []connectioncontext.IpFamily_Family, family connectioncontext.IpFamily_Family) bool {
+	for _, f := range families {
+		if f == family {
+			return true
+		}
+	}
+	return false
+}
+
+func validateNetworkService(networkService string) error {
+	if errs := validation.IsConfigMapKey(networkService); len(errs) > 0 {
+		return fmt.Errorf("invalid network service name %q: %s", networkService, strings.Join(errs, ", "))
+	}
+	return nil
+}
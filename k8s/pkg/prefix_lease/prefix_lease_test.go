@@ -0,0 +1,87 @@
+package prefix_lease
+
+import (
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var prefixLen = map[connectioncontext.IpFamily_Family]uint32{
+	connectioncontext.IpFamily_IPV4: 24,
+	connectioncontext.IpFamily_IPV6: 112,
+}
+
+func TestLeaseDisjointPrefixes(t *testing.T) {
+	RegisterTestingT(t)
+
+	leases := NewPrefixLeases(fake.NewSimpleClientset(), metav1.NamespaceDefault, DefaultLeaseDuration)
+	prefixes := []string{"10.20.0.0/23", "fd00:20::/64"}
+
+	lease1, err := leases.Lease("icmp-responder", "nse-1", prefixes, prefixLen)
+	Expect(err).To(BeNil())
+	Expect(lease1.Prefixes).To(Equal([]string{"10.20.0.0/24", "fd00:20::/112"}))
+	lease2, err := leases.Lease("icmp-responder", "nse-2", prefixes, prefixLen)
+	Expect(err).To(BeNil())
+	Expect(lease2.Prefixes).To(Equal([]string{"10.20.1.0/24", "fd00:20::1:0/112"}))
+
+	// Lease is renewed with the same prefixes.
+	renewed, err := leases.Lease("icmp-responder", "nse-1", prefixes, prefixLen)
+	Expect(err).To(BeNil())
+	Expect(renewed.Prefixes).To(Equal(lease1.Prefixes))
+	Expect(renewed.Expires.After(lease1.Expires)).To(BeTrue())
+
+	// There is no IPv4 prefix left for third replica.
+	_, err = leases.Lease("icmp-responder", "nse-3", prefixes, prefixLen)
+	Expect(err).NotTo(BeNil())
+
+	// Other network services have own address space.
+	other, err := leases.Lease("vpn-gateway", "nse-3", prefixes, prefixLen)
+	Expect(err).To(BeNil())
+	Expect(other.Prefixes).To(Equal(lease1.Prefixes))
+
+	Expect(leases.Release("icmp-responder", "nse-1")).To(BeNil())
+	lease3, err := leases.Lease("icmp-responder", "nse-3", prefixes, prefixLen)
+	Expect(err).To(BeNil())
+	Expect(lease3.Prefixes).To(Equal(lease1.Prefixes))
+}
+
+func TestExpiredLease(t *testing.T) {
+	RegisterTestingT(t)
+
+	leases := NewPrefixLeases(fake.NewSimpleClientset(), metav1.NamespaceDefault, 100*time.Millisecond)
+	prefixes := []string{"10.20.0.0/23"}
+
+	lease1, err := leases.Lease("icmp-responder", "nse-1", prefixes, prefixLen)
+	Expect(err).To(BeNil())
+	time.Sleep(200 * time.Millisecond)
+
+	// Expired lease is renewed if its prefixes are not leased to others.
+	renewed, err := leases.Lease("icmp-responder", "nse-1", prefixes, prefixLen)
+	Expect(err).To(BeNil())
+	Expect(renewed.Prefixes).To(Equal(lease1.Prefixes))
+	time.Sleep(200 * time.Millisecond)
+
+	// Prefixes of expired lease are leased to others, so owner gets other ones.
+	lease2, err := leases.Lease("icmp-responder", "nse-2", []string{"10.20.0.0/24"}, prefixLen)
+	Expect(err).To(BeNil())
+	Expect(lease2.Prefixes).To(Equal(lease1.Prefixes))
+	renewed, err = leases.Lease("icmp-responder", "nse-1", prefixes, prefixLen)
+	Expect(err).To(BeNil())
+	Expect(renewed.Prefixes).To(Equal([]string{"10.20.1.0/24"}))
+}
+
+func TestInvalidLeaseRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	leases := NewPrefixLeases(fake.NewSimpleClientset(), metav1.NamespaceDefault, DefaultLeaseDuration)
+	_, err := leases.Lease("icmp-responder", "nse-1", []string{"abc"}, prefixLen)
+	Expect(err).NotTo(BeNil())
+	_, err = leases.Lease("icmp/responder", "nse-1", []string{"10.20.0.0/23"}, prefixLen)
+	Expect(err).NotTo(BeNil())
+	_, err = leases.Lease("icmp-responder", "", []string{"10.20.0.0/23"}, prefixLen)
+	Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,36 @@
+package registryserver
+
+import (
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/prefix_collector"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// clusterInfoService - publishes prefixes used by cluster, NSMDs exclude them from addresses allocated by endpoints.
+type clusterInfoService struct {
+	prefixCollector *prefix_collector.PrefixCollector
+}
+
+func (s *clusterInfoService) GetExcludedPrefixes(ctx context.Context, _ *empty.Empty) (*registry.ExcludedPrefixes, error) {
+	return &registry.ExcludedPrefixes{
+		Prefixes: s.prefixCollector.Prefixes(),
+	}, nil
+}
+
+func (s *clusterInfoService) MonitorExcludedPrefixes(_ *empty.Empty, stream registry.ClusterInfo_MonitorExcludedPrefixesServer) error {
+	updates, unsubscribe := s.prefixCollector.Subscribe()
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case prefixes := <-updates:
+			if err := stream.Send(&registry.ExcludedPrefixes{Prefixes: prefixes}); err != nil {
+				logrus.Errorf("Failed to send excluded prefixes: %v", err)
+				return err
+			}
+		}
+	}
+}
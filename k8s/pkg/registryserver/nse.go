@@ -1,6 +1,7 @@
 package registryserver
 
 import (
+	"reflect"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
@@ -10,8 +11,11 @@ import (
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,14 +26,28 @@ const (
 )
 
 type registryService struct {
-	nsmName string
-	cache   RegistryCache
+	nsmName          string
+	cache            RegistryCache
+	securityProvider *security.Provider
+}
+
+// verifyPeer - checks request is received from NSM it is done on behalf of.
+func verifyPeer(ctx context.Context, securityProvider *security.Provider, nsmName string) error {
+	if err := securityProvider.VerifyPeer(ctx, nsmName); err != nil {
+		logrus.Errorf("Request on behalf of NSM %s is refused: %v", nsmName, err)
+		return status.Errorf(codes.PermissionDenied, "request on behalf of NSM %s is refused: %v", nsmName, err)
+	}
+	return nil
 }
 
 func (rs registryService) RegisterNSE(ctx context.Context, request *registry.NSERegistration) (*registry.NSERegistration, error) {
 	st := time.Now()
 
 	logrus.Infof("Received RegisterNSE(%v)", request)
+	// Endpoints are registered on behalf of NSM registry is running for.
+	if err := verifyPeer(ctx, rs.securityProvider, rs.nsmName); err != nil {
+		return nil, err
+	}
 	// get network service
 	if request.GetNetworkServiceManager().GetUrl() == "" {
 		return nil, errors.New("NSERegistration.NetworkServiceManager.Url must be defined")
@@ -41,14 +59,19 @@ func (rs registryService) RegisterNSE(ctx context.Context, request *registry.NSE
 		},
 		Spec: v1.NetworkServiceManagerSpec{},
 		Status: v1.NetworkServiceManagerStatus{
-			LastSeen: metav1.Time{Time: time.Now()},
-			URL:      request.GetNetworkServiceManager().GetUrl(),
-			State:    v1.RUNNING,
+			LastSeen:         metav1.Time{Time: time.Now()},
+			URL:              request.GetNetworkServiceManager().GetUrl(),
+			State:            v1.RUNNING,
+			RemoteMechanisms: request.GetNetworkServiceManager().GetRemoteMechanisms(),
 		},
 	}
 
 	_, err := rs.cache.AddNetworkServiceManager(nsm)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
+	if apierrors.IsAlreadyExists(err) {
+		// Remote mechanisms could be changed since NSM is registered, since dataplanes are changed.
+		err = rs.updateNetworkServiceManager(nsm)
+	}
+	if err != nil {
 		logrus.Errorf("Failed to register nsm: %s", err)
 		return nil, err
 	}
@@ -57,10 +80,11 @@ func (rs registryService) RegisterNSE(ctx context.Context, request *registry.NSE
 		logrus.Errorf("Failed time conversion of %v", nsm.Status.LastSeen)
 	}
 	request.NetworkServiceManager = &registry.NetworkServiceManager{
-		Name:     nsm.GetName(),
-		Url:      nsm.Status.URL,
-		State:    string(nsm.Status.State),
-		LastSeen: lastSeen,
+		Name:             nsm.GetName(),
+		Url:              nsm.Status.URL,
+		State:            string(nsm.Status.State),
+		LastSeen:         lastSeen,
+		RemoteMechanisms: nsm.Status.RemoteMechanisms,
 	}
 
 	labels := request.GetNetworkserviceEndpoint().GetLabels()
@@ -74,7 +98,9 @@ func (rs registryService) RegisterNSE(ctx context.Context, request *registry.NSE
 				Name: request.NetworkService.GetName(),
 			},
 			Spec: v1.NetworkServiceSpec{
-				Payload: request.NetworkService.GetPayload(),
+				Payload:           request.NetworkService.GetPayload(),
+				SelectionStrategy: request.NetworkService.GetSelectionStrategy(),
+				RetryPolicy:       retryPolicyToCRD(request.NetworkService.GetRetryPolicy()),
 			},
 			Status: v1.NetworkServiceStatus{},
 		})
@@ -113,11 +139,32 @@ func (rs registryService) RegisterNSE(ctx context.Context, request *registry.NSE
 
 }
 
+// updateNetworkServiceManager - updates url and remote mechanisms of already registered NSM, if they are changed.
+func (rs registryService) updateNetworkServiceManager(nsm *v1.NetworkServiceManager) error {
+	existing, err := rs.cache.GetNetworkServiceManager(nsm.Name)
+	if err != nil {
+		// NSM is not in cache yet, it will be updated on next registration.
+		logrus.Warnf("Failed to update nsm: %s", err)
+		return nil
+	}
+	if existing.Status.URL == nsm.Status.URL && reflect.DeepEqual(existing.Status.RemoteMechanisms, nsm.Status.RemoteMechanisms) {
+		return nil
+	}
+	updated := existing.DeepCopy()
+	updated.Status.URL = nsm.Status.URL
+	updated.Status.RemoteMechanisms = nsm.Status.RemoteMechanisms
+	_, err = rs.cache.UpdateNetworkServiceManager(updated)
+	return err
+}
+
 func (rs registryService) RemoveNSE(ctx context.Context, request *registry.RemoveNSERequest) (*empty.Empty, error) {
 	st := time.Now()
 
 	logrus.Infof("Received RemoveNSE(%v)", request)
 
+	if err := rs.verifyEndpointNsm(ctx, request.EndpointName); err != nil {
+		return nil, err
+	}
 	if err := rs.cache.DeleteNetworkServiceEndpoint(request.EndpointName); err != nil {
 		return nil, err
 	}
@@ -125,6 +172,36 @@ func (rs registryService) RemoveNSE(ctx context.Context, request *registry.Remov
 	return &empty.Empty{}, nil
 }
 
+func (rs registryService) DrainNSE(ctx context.Context, request *registry.DrainNSERequest) (*empty.Empty, error) {
+	st := time.Now()
+
+	logrus.Infof("Received DrainNSE(%v)", request)
+
+	existing, err := rs.cache.GetNetworkServiceEndpoint(request.EndpointName)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyPeer(ctx, rs.securityProvider, existing.Spec.NsmName); err != nil {
+		return nil, err
+	}
+	nse := existing.DeepCopy()
+	nse.Status.State = v1.DRAINING
+	if _, err := rs.cache.UpdateNetworkServiceEndpoint(nse); err != nil {
+		return nil, err
+	}
+	logrus.Infof("DrainNSE done: time %v", time.Since(st))
+	return &empty.Empty{}, nil
+}
+
+// verifyEndpointNsm - checks request to change endpoint is received from NSM endpoint is registered by.
+func (rs registryService) verifyEndpointNsm(ctx context.Context, endpointName string) error {
+	nse, err := rs.cache.GetNetworkServiceEndpoint(endpointName)
+	if err != nil {
+		return err
+	}
+	return verifyPeer(ctx, rs.securityProvider, nse.Spec.NsmName)
+}
+
 func (rs registryService) FindNetworkService(ctx context.Context, request *registry.FindNetworkServiceRequest) (*registry.FindNetworkServiceResponse, error) {
 	st := time.Now()
 	service, err := rs.cache.GetNetworkService(request.NetworkServiceName)
@@ -147,6 +224,7 @@ func (rs registryService) FindNetworkService(ctx context.Context, request *regis
 			NetworkServiceManagerName: endpoint.Spec.NsmName,
 			Payload:                   payload,
 			Labels:                    endpoint.ObjectMeta.Labels,
+			State:                     string(endpoint.Status.State),
 		}
 		manager := NSMsREG[endpoint.Spec.NsmName]
 		if manager == nil {
@@ -163,9 +241,34 @@ func (rs registryService) FindNetworkService(ctx context.Context, request *regis
 				Seconds: manager.Status.LastSeen.ProtoTime().Seconds,
 				Nanos:   manager.Status.LastSeen.ProtoTime().Nanos,
 			},
+			RemoteMechanisms: manager.Status.RemoteMechanisms,
 		}
 	}
 
+	response := &registry.FindNetworkServiceResponse{
+		Payload:                 payload,
+		NetworkService:          networkServiceFromCRD(service),
+		NetworkServiceManagers:  NSMs,
+		NetworkServiceEndpoints: NSEs,
+	}
+	logrus.Infof("FindNetworkService done: time %v", time.Since(st))
+	return response, nil
+}
+
+func (rs registryService) ListNetworkServices(ctx context.Context, _ *empty.Empty) (*registry.NetworkServiceList, error) {
+	services, err := rs.cache.GetNetworkServices()
+	if err != nil {
+		logrus.Errorf("Failed to list NetworkServices: %v", err)
+		return nil, err
+	}
+	response := &registry.NetworkServiceList{}
+	for _, service := range services {
+		response.NetworkServices = append(response.NetworkServices, networkServiceFromCRD(service))
+	}
+	return response, nil
+}
+
+func networkServiceFromCRD(service *v1.NetworkService) *registry.NetworkService {
 	var matches []*registry.Match
 
 	for _, m := range service.Spec.Matches {
@@ -186,16 +289,39 @@ func (rs registryService) FindNetworkService(ctx context.Context, request *regis
 		matches = append(matches, match)
 	}
 
-	response := &registry.FindNetworkServiceResponse{
-		Payload: payload,
-		NetworkService: &registry.NetworkService{
-			Name:    service.ObjectMeta.Name,
-			Payload: service.Spec.Payload,
-			Matches: matches,
-		},
-		NetworkServiceManagers:  NSMs,
-		NetworkServiceEndpoints: NSEs,
+	return &registry.NetworkService{
+		Name:              service.ObjectMeta.Name,
+		Payload:           service.Spec.Payload,
+		Matches:           matches,
+		SelectionStrategy: service.Spec.SelectionStrategy,
+		RetryPolicy:       retryPolicyFromCRD(service.Spec.RetryPolicy),
+	}
+}
+
+func retryPolicyToCRD(policy *registry.RetryPolicy) *v1.RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &v1.RetryPolicy{
+		MaxAttempts:       policy.MaxAttempts,
+		InitialBackoffMs:  policy.InitialBackoffMs,
+		MaxBackoffMs:      policy.MaxBackoffMs,
+		BackoffMultiplier: policy.BackoffMultiplier,
+		Jitter:            policy.Jitter,
+		RetryableCodes:    policy.RetryableCodes,
+	}
+}
+
+func retryPolicyFromCRD(policy *v1.RetryPolicy) *registry.RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &registry.RetryPolicy{
+		MaxAttempts:       policy.MaxAttempts,
+		InitialBackoffMs:  policy.InitialBackoffMs,
+		MaxBackoffMs:      policy.MaxBackoffMs,
+		BackoffMultiplier: policy.BackoffMultiplier,
+		Jitter:            policy.Jitter,
+		RetryableCodes:    policy.RetryableCodes,
 	}
-	logrus.Infof("FindNetworkService done: time %v", time.Since(st))
-	return response, nil
 }
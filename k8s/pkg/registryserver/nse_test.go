@@ -0,0 +1,195 @@
+package registryserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/apis/networkservice/v1"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeRegistryCache struct {
+	RegistryCache
+	services  map[string]*v1.NetworkService
+	managers  map[string]*v1.NetworkServiceManager
+	endpoints map[string]*v1.NetworkServiceEndpoint
+}
+
+func newFakeRegistryCache() *fakeRegistryCache {
+	return &fakeRegistryCache{
+		services:  map[string]*v1.NetworkService{},
+		managers:  map[string]*v1.NetworkServiceManager{},
+		endpoints: map[string]*v1.NetworkServiceEndpoint{},
+	}
+}
+
+func (c *fakeRegistryCache) GetNetworkService(name string) (*v1.NetworkService, error) {
+	if ns, ok := c.services[name]; ok {
+		return ns, nil
+	}
+	return nil, fmt.Errorf("no network service %s", name)
+}
+
+func (c *fakeRegistryCache) GetNetworkServiceManager(name string) (*v1.NetworkServiceManager, error) {
+	if nsm, ok := c.managers[name]; ok {
+		return nsm, nil
+	}
+	return nil, fmt.Errorf("no nsm %s", name)
+}
+
+func (c *fakeRegistryCache) GetNetworkServiceEndpoint(endpointName string) (*v1.NetworkServiceEndpoint, error) {
+	if nse, ok := c.endpoints[endpointName]; ok {
+		return nse, nil
+	}
+	return nil, fmt.Errorf("no nse %s", endpointName)
+}
+
+func (c *fakeRegistryCache) UpdateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
+	c.endpoints[nse.Name] = nse
+	return nse, nil
+}
+
+func (c *fakeRegistryCache) GetNetworkServiceEndpoints(networkServiceName string) []*v1.NetworkServiceEndpoint {
+	var result []*v1.NetworkServiceEndpoint
+	for _, nse := range c.endpoints {
+		if nse.Spec.NetworkServiceName == networkServiceName {
+			result = append(result, nse)
+		}
+	}
+	return result
+}
+
+// newCertificate - creates self signed certificate issued for name.
+func newCertificate(name string) ([]byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+	return raw, key
+}
+
+func newSecurityProvider(name string) *security.Provider {
+	dir, err := ioutil.TempDir("", "registry_tls")
+	Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+	raw, key := newCertificate(name)
+	keyRaw, err := x509.MarshalECPrivateKey(key)
+	Expect(err).To(BeNil())
+	certFile, keyFile := path.Join(dir, "tls.crt"), path.Join(dir, "tls.key")
+	Expect(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: raw}), 0600)).To(BeNil())
+	Expect(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyRaw}), 0600)).To(BeNil())
+	provider, err := security.NewProvider(certFile, keyFile, certFile)
+	Expect(err).To(BeNil())
+	return provider
+}
+
+// peerContext - returns context of request received from peer authenticated as name.
+func peerContext(name string) context.Context {
+	raw, _ := newCertificate(name)
+	cert, err := x509.ParseCertificate(raw)
+	Expect(err).To(BeNil())
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+}
+
+func newDrainTestCache() *fakeRegistryCache {
+	cache := newFakeRegistryCache()
+	cache.services["golden-network"] = &v1.NetworkService{ObjectMeta: metav1.ObjectMeta{Name: "golden-network"}}
+	cache.managers["nsm-1"] = &v1.NetworkServiceManager{ObjectMeta: metav1.ObjectMeta{Name: "nsm-1"}}
+	cache.endpoints["nse-1"] = &v1.NetworkServiceEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "nse-1"},
+		Spec: v1.NetworkServiceEndpointSpec{
+			NetworkServiceName: "golden-network",
+			NsmName:            "nsm-1",
+		},
+		Status: v1.NetworkServiceEndpointStatus{State: v1.RUNNING},
+	}
+	return cache
+}
+
+func TestDrainNSE(t *testing.T) {
+	RegisterTestingT(t)
+
+	cache := newDrainTestCache()
+	running := cache.endpoints["nse-1"]
+	rs := registryService{nsmName: "nsm-1", cache: cache}
+
+	_, err := rs.DrainNSE(context.Background(), &registry.DrainNSERequest{EndpointName: "nse-1"})
+	Expect(err).To(BeNil())
+
+	// Cached endpoint is replaced, not modified.
+	Expect(running.Status.State).To(Equal(v1.State(v1.RUNNING)))
+	Expect(cache.endpoints["nse-1"].Status.State).To(Equal(v1.State(v1.DRAINING)))
+
+	response, err := rs.FindNetworkService(context.Background(), &registry.FindNetworkServiceRequest{NetworkServiceName: "golden-network"})
+	Expect(err).To(BeNil())
+	Expect(len(response.GetNetworkServiceEndpoints())).To(Equal(1))
+	Expect(response.GetNetworkServiceEndpoints()[0].GetState()).To(Equal(registry.EndpointStateDraining))
+
+	_, err = rs.DrainNSE(context.Background(), &registry.DrainNSERequest{EndpointName: "unknown"})
+	Expect(err).NotTo(BeNil())
+}
+
+func TestRegistryVerifiesPeerNsm(t *testing.T) {
+	RegisterTestingT(t)
+
+	cache := newDrainTestCache()
+	provider := newSecurityProvider("registry")
+	rs := registryService{nsmName: "nsm-2", cache: cache, securityProvider: provider}
+
+	// Endpoint of nsm-1 could not be changed by other NSMs.
+	_, err := rs.DrainNSE(peerContext("nsm-2"), &registry.DrainNSERequest{EndpointName: "nse-1"})
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	_, err = rs.RemoveNSE(peerContext("nsm-2"), &registry.RemoveNSERequest{EndpointName: "nse-1"})
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	Expect(cache.endpoints["nse-1"].Status.State).To(Equal(v1.State(v1.RUNNING)))
+
+	_, err = rs.DrainNSE(peerContext("nsm-1"), &registry.DrainNSERequest{EndpointName: "nse-1"})
+	Expect(err).To(BeNil())
+
+	// Endpoints and prefixes are registered on behalf of NSM registry is running for.
+	_, err = rs.RegisterNSE(peerContext("nsm-1"), &registry.NSERegistration{
+		NetworkServiceManager: &registry.NetworkServiceManager{Url: "127.0.0.1:5001"},
+	})
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	_, err = rs.RegisterNSE(context.Background(), &registry.NSERegistration{
+		NetworkServiceManager: &registry.NetworkServiceManager{Url: "127.0.0.1:5001"},
+	})
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+
+	leases := &prefixLeaseService{nsmName: "nsm-2", securityProvider: provider}
+	_, err = leases.LeasePrefixes(peerContext("nsm-1"), &registry.PrefixLeaseRequest{NetworkServiceName: "golden-network", Owner: "nse-1"})
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	_, err = leases.ReleasePrefixes(peerContext("nsm-1"), &registry.PrefixLease{NetworkServiceName: "golden-network", Owner: "nse-1"})
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+}
@@ -0,0 +1,55 @@
+package registryserver
+
+import (
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/prefix_lease"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// prefixLeaseService - leases disjoint sub-prefixes to replicas of endpoints providing the same network service.
+type prefixLeaseService struct {
+	nsmName          string
+	prefixLeases     *prefix_lease.PrefixLeases
+	securityProvider *security.Provider
+}
+
+// LeasePrefixes - leases prefixes on behalf of NSM registry is running for, endpoints lease them through their NSM.
+func (s *prefixLeaseService) LeasePrefixes(ctx context.Context, request *registry.PrefixLeaseRequest) (*registry.PrefixLease, error) {
+	if err := verifyPeer(ctx, s.securityProvider, s.nsmName); err != nil {
+		return nil, err
+	}
+	lease, err := s.prefixLeases.Lease(request.NetworkServiceName, request.Owner, request.Prefixes, map[connectioncontext.IpFamily_Family]uint32{
+		connectioncontext.IpFamily_IPV4: request.Ipv4PrefixLen,
+		connectioncontext.IpFamily_IPV6: request.Ipv6PrefixLen,
+	})
+	if err != nil {
+		logrus.Errorf("Failed to lease prefixes to %s: %v", request.Owner, err)
+		return nil, err
+	}
+	expires, err := ptypes.TimestampProto(lease.Expires)
+	if err != nil {
+		return nil, err
+	}
+	return &registry.PrefixLease{
+		NetworkServiceName: request.NetworkServiceName,
+		Owner:              request.Owner,
+		Prefixes:           lease.Prefixes,
+		Expires:            expires,
+	}, nil
+}
+
+func (s *prefixLeaseService) ReleasePrefixes(ctx context.Context, lease *registry.PrefixLease) (*empty.Empty, error) {
+	if err := verifyPeer(ctx, s.securityProvider, s.nsmName); err != nil {
+		return nil, err
+	}
+	if err := s.prefixLeases.Release(lease.NetworkServiceName, lease.Owner); err != nil {
+		logrus.Errorf("Failed to release prefixes of %s: %v", lease.Owner, err)
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
@@ -0,0 +1,43 @@
+package registryserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// nsmd-k8s shares pod with nsmd, so it uses port next to nsmd probes port.
+	healthcheckProbesPort = "0.0.0.0:5556"
+)
+
+var (
+	cacheStatusOK = true
+)
+
+func SetCacheFailed() {
+	cacheStatusOK = false
+}
+
+func readiness(w http.ResponseWriter, r *http.Request) {
+	if !cacheStatusOK {
+		errMsg := fmt.Sprintf("NSMD-K8S not ready. RegistryCache - %t", cacheStatusOK)
+		http.Error(w, errMsg, http.StatusServiceUnavailable)
+	} else {
+		w.Write([]byte("OK"))
+	}
+}
+
+func liveness(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte("OK"))
+}
+
+func BeginHealthCheck() {
+	logrus.Debug("Starting NSMD-K8S liveness/readiness healthcheck and metrics")
+	http.HandleFunc("/liveness", liveness)
+	http.HandleFunc("/readiness", readiness)
+	http.Handle("/metrics", promhttp.Handler())
+	http.ListenAndServe(healthcheckProbesPort, nil)
+}
@@ -13,11 +13,15 @@ import (
 type RegistryCache interface {
 	AddNetworkService(ns *v1.NetworkService) (*v1.NetworkService, error)
 	GetNetworkService(name string) (*v1.NetworkService, error)
+	GetNetworkServices() ([]*v1.NetworkService, error)
 
 	AddNetworkServiceManager(nsm *v1.NetworkServiceManager) (*v1.NetworkServiceManager, error)
 	GetNetworkServiceManager(name string) (*v1.NetworkServiceManager, error)
+	UpdateNetworkServiceManager(nsm *v1.NetworkServiceManager) (*v1.NetworkServiceManager, error)
 
 	AddNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error)
+	GetNetworkServiceEndpoint(endpointName string) (*v1.NetworkServiceEndpoint, error)
+	UpdateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error)
 	DeleteNetworkServiceEndpoint(endpointName string) error
 	GetNetworkServiceEndpoints(networkServiceName string) []*v1.NetworkServiceEndpoint
 
@@ -86,6 +90,18 @@ func (rc *registryCacheImpl) GetNetworkService(name string) (*v1.NetworkService,
 	}
 }
 
+func (rc *registryCacheImpl) GetNetworkServices() ([]*v1.NetworkService, error) {
+	list, err := rc.clientset.NetworkservicemeshV1().NetworkServices("default").List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	services := make([]*v1.NetworkService, 0, len(list.Items))
+	for i := range list.Items {
+		services = append(services, &list.Items[i])
+	}
+	return services, nil
+}
+
 func (rc *registryCacheImpl) AddNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
 	nseResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints("default").Create(nse)
 	if nseResponse != nil {
@@ -94,6 +110,18 @@ func (rc *registryCacheImpl) AddNetworkServiceEndpoint(nse *v1.NetworkServiceEnd
 	return nseResponse, err
 }
 
+func (rc *registryCacheImpl) GetNetworkServiceEndpoint(endpointName string) (*v1.NetworkServiceEndpoint, error) {
+	return rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints("default").Get(endpointName, metav1.GetOptions{})
+}
+
+func (rc *registryCacheImpl) UpdateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
+	nseResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints("default").Update(nse)
+	if nseResponse != nil {
+		rc.networkServiceEndpointCache.Add(nseResponse)
+	}
+	return nseResponse, err
+}
+
 func (rc *registryCacheImpl) DeleteNetworkServiceEndpoint(endpointName string) error {
 	rc.networkServiceEndpointCache.Delete(endpointName)
 	return rc.clientset.NetworkservicemeshV1().NetworkServiceEndpoints("default").Delete(endpointName, &metav1.DeleteOptions{})
@@ -111,6 +139,14 @@ func (rc *registryCacheImpl) AddNetworkServiceManager(nsm *v1.NetworkServiceMana
 	return nsmResponse, err
 }
 
+func (rc *registryCacheImpl) UpdateNetworkServiceManager(nsm *v1.NetworkServiceManager) (*v1.NetworkServiceManager, error) {
+	nsmResponse, err := rc.clientset.NetworkservicemeshV1().NetworkServiceManagers("default").Update(nsm)
+	if nsmResponse != nil {
+		rc.networkServiceManagerCache.Add(nsmResponse)
+	}
+	return nsmResponse, err
+}
+
 func (rc *registryCacheImpl) GetNetworkServiceManager(name string) (*v1.NetworkServiceManager, error) {
 	if nsm := rc.networkServiceManagerCache.Get(name); nsm == nil {
 		return nil, fmt.Errorf("no NetworkServiceManager with name: %v", name)
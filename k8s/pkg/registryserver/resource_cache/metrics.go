@@ -0,0 +1,15 @@
+package resource_cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// cacheSize - number of resources kept in registry cache per resource type.
+var cacheSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "nsm_registry_cache_size",
+	Help: "Number of resources kept in registry cache per resource type.",
+}, []string{"resource"})
+
+func init() {
+	prometheus.MustRegister(cacheSize)
+}
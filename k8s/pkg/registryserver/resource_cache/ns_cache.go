@@ -18,6 +18,7 @@ func NewNetworkServiceCache() *NetworkServiceCache {
 		keyFunc:             getNsKey,
 		resourceAddedFunc:   rv.resourceAdded,
 		resourceDeletedFunc: rv.resourceDeleted,
+		resourceCountFunc:   func() int { return len(rv.networkServices) },
 		resourceType:        NsResource,
 	}
 	rv.cache = newAbstractResourceCache(config)
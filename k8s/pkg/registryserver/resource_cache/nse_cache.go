@@ -21,6 +21,7 @@ func NewNetworkServiceEndpointCache() *NetworkServiceEndpointCache {
 		keyFunc:             getNseKey,
 		resourceAddedFunc:   rv.resourceAdded,
 		resourceDeletedFunc: rv.resourceDeleted,
+		resourceCountFunc:   func() int { return len(rv.networkServiceEndpoints) },
 		resourceType:        NseResource,
 	}
 	rv.cache = newAbstractResourceCache(config)
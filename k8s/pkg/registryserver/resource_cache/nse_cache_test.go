@@ -5,10 +5,13 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/informers/externalversions"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/registryserver/resource_cache"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
+	"strings"
 	"testing"
 	"time"
 )
@@ -117,6 +120,28 @@ func TestRegistryDelete(t *testing.T) {
 	Expect(len(endpointList3)).To(Equal(0))
 }
 
+func TestRegistryCacheSizeMetric(t *testing.T) {
+	RegisterTestingT(t)
+
+	fakeRegistry := fakeRegistry{}
+	nseCache := resource_cache.NewNetworkServiceEndpointCache()
+
+	stopFunc, err := nseCache.Start(&fakeRegistry)
+	Expect(err).To(BeNil())
+	defer stopFunc()
+
+	fakeRegistry.Add(newTestNse("nse1", "ns1"))
+	fakeRegistry.Add(newTestNse("nse2", "ns2"))
+
+	Eventually(func() error {
+		return testutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(`
+# HELP nsm_registry_cache_size Number of resources kept in registry cache per resource type.
+# TYPE nsm_registry_cache_size gauge
+nsm_registry_cache_size{resource="networkserviceendpoints"} 2
+`), "nsm_registry_cache_size")
+	}, time.Second).Should(BeNil())
+}
+
 func getEndpoints(nseCache *resource_cache.NetworkServiceEndpointCache,
 	networkServiceName string, expectedLength int) []*v1.NetworkServiceEndpoint {
 	var endpointList []*v1.NetworkServiceEndpoint
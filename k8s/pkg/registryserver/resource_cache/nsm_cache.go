@@ -18,6 +18,7 @@ func NewNetworkServiceManagerCache() *NetworkServiceManagerCache {
 		keyFunc:             getNsmKey,
 		resourceAddedFunc:   rv.resourceAdded,
 		resourceDeletedFunc: rv.resourceDeleted,
+		resourceCountFunc:   func() int { return len(rv.networkServiceManagers) },
 		resourceType:        NsmResource,
 	}
 	rv.cache = newAbstractResourceCache(config)
@@ -16,6 +16,7 @@ type cacheConfig struct {
 	keyFunc             func(obj interface{}) string
 	resourceAddedFunc   func(obj interface{})
 	resourceDeletedFunc func(key string)
+	resourceCountFunc   func() int
 	resourceType        string
 }
 
@@ -60,14 +61,20 @@ func (c *abstractResourceCache) run(stopCh chan struct{}) {
 		select {
 		case newResource := <-c.addCh:
 			c.config.resourceAddedFunc(newResource)
+			c.updateSize()
 		case deleteResourceKey := <-c.deleteCh:
 			c.config.resourceDeletedFunc(deleteResourceKey)
+			c.updateSize()
 		case <-stopCh:
 			return
 		}
 	}
 }
 
+func (c *abstractResourceCache) updateSize() {
+	cacheSize.WithLabelValues(c.config.resourceType).Set(float64(c.config.resourceCountFunc()))
+}
+
 func (c *abstractResourceCache) startInformer(informerFactory externalversions.SharedInformerFactory) (chan struct{}, error) {
 	genericInformer, err := informerFactory.ForResource(v1.SchemeGroupVersion.WithResource(c.config.resourceType))
 	if err != nil {
@@ -77,6 +84,7 @@ func (c *abstractResourceCache) startInformer(informerFactory externalversions.S
 	informer := genericInformer.Informer()
 	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.add,
+		UpdateFunc: func(old interface{}, new interface{}) { c.add(new) },
 		DeleteFunc: func(obj interface{}) { c.delete(c.config.keyFunc(obj)) },
 	})
 
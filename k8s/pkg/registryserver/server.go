@@ -4,31 +4,45 @@ import (
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	nsmClientset "github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/clientset/versioned"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/prefix_collector"
+	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/prefix_lease"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 )
 
-func New(clientset *nsmClientset.Clientset, nsmName string) *grpc.Server {
+func New(clientset *nsmClientset.Clientset, nsmName string, securityProvider *security.Provider, prefixCollector *prefix_collector.PrefixCollector, prefixLeases *prefix_lease.PrefixLeases) *grpc.Server {
 	tracer := opentracing.GlobalTracer()
-	server := grpc.NewServer(
+	server := grpc.NewServer(append([]grpc.ServerOption{
 		grpc.UnaryInterceptor(
 			otgrpc.OpenTracingServerInterceptor(tracer, otgrpc.LogPayloads())),
 		grpc.StreamInterceptor(
-			otgrpc.OpenTracingStreamServerInterceptor(tracer)))
+			otgrpc.OpenTracingStreamServerInterceptor(tracer))},
+		securityProvider.ServerOptions()...)...)
 
 	cache := NewRegistryCache(clientset)
 	logrus.Info("RegistryCache started")
 
 	srv := &registryService{
-		nsmName: nsmName,
-		cache:   cache,
+		nsmName:          nsmName,
+		cache:            cache,
+		securityProvider: securityProvider,
 	}
 	registry.RegisterNetworkServiceRegistryServer(server, srv)
 	registry.RegisterNetworkServiceDiscoveryServer(server, srv)
+	registry.RegisterClusterInfoServer(server, &clusterInfoService{
+		prefixCollector: prefixCollector,
+	})
+	registry.RegisterPrefixLeaseRegistryServer(server, &prefixLeaseService{
+		nsmName:          nsmName,
+		prefixLeases:     prefixLeases,
+		securityProvider: securityProvider,
+	})
 
 	if err := cache.Start(); err != nil {
 		logrus.Error(err)
+		SetCacheFailed()
 	}
 	return server
 }
@@ -0,0 +1,270 @@
+// Package security provides mutual TLS credentials for gRPC traffic between NSMDs and Network Service Registries.
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	// CertFileEnv - PEM encoded certificate presented to peers.
+	CertFileEnv = "NSM_TLS_CERT_FILE"
+	// KeyFileEnv - PEM encoded private key of certificate.
+	KeyFileEnv = "NSM_TLS_KEY_FILE"
+	// CAFileEnv - PEM encoded CA certificates peer certificates are verified against.
+	CAFileEnv = "NSM_TLS_CA_FILE"
+	// TrustedProxiesEnv - comma separated names of inter-domain proxies, which are trusted to act on behalf of NSMs.
+	TrustedProxiesEnv = "NSM_TLS_TRUSTED_PROXIES"
+)
+
+// Provider - provides mutual TLS credentials, certificate files are re-read on change, so they could be rotated
+// without restart. Nil provider means TLS is disabled and plaintext credentials are used.
+type Provider struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	sync.Mutex
+	trustedProxies []string
+	certificate    *tls.Certificate
+	roots          *x509.CertPool
+	modTimes       map[string]time.Time
+}
+
+// NewProviderFromEnv - creates a provider using files configured by environment, returns nil provider if TLS
+// is not configured.
+func NewProviderFromEnv() (*Provider, error) {
+	certFile := strings.TrimSpace(os.Getenv(CertFileEnv))
+	keyFile := strings.TrimSpace(os.Getenv(KeyFileEnv))
+	caFile := strings.TrimSpace(os.Getenv(CAFileEnv))
+	if certFile == "" && keyFile == "" && caFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" || caFile == "" {
+		return nil, fmt.Errorf("all of %s, %s and %s should be set to enable TLS", CertFileEnv, KeyFileEnv, CAFileEnv)
+	}
+	p, err := NewProvider(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(os.Getenv(TrustedProxiesEnv), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			p.AddTrustedProxy(name)
+		}
+	}
+	return p, nil
+}
+
+// NewProvider - creates a provider using certificate, key and CA files.
+func NewProvider(certFile, keyFile, caFile string) (*Provider, error) {
+	p := &Provider{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		modTimes: map[string]time.Time{},
+	}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// AddTrustedProxy - trusts peer authenticated as name to act on behalf of any NSM. Inter-domain proxy relays
+// connections of NSMs with its own certificate, so it is refused as impersonation otherwise.
+func (p *Provider) AddTrustedProxy(name string) {
+	p.Lock()
+	defer p.Unlock()
+	p.trustedProxies = append(p.trustedProxies, name)
+}
+
+// ServerOptions - returns options to serve gRPC with mutual TLS, peers should present a certificate signed by CA.
+func (p *Provider) ServerOptions() []grpc.ServerOption {
+	if p == nil {
+		return nil
+	}
+	return []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(&tls.Config{
+			ClientAuth: tls.RequireAnyClientCert,
+			GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				return p.getCertificate()
+			},
+			// Peer certificate is verified against current CA, since CA could be rotated.
+			VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				return p.verify(rawCerts, "", x509.ExtKeyUsageClientAuth)
+			},
+		})),
+	}
+}
+
+// DialOption - returns option to dial peer with mutual TLS, peer certificate should be signed by CA and issued for
+// peerName or trusted proxy, any name is accepted if peerName is empty.
+func (p *Provider) DialOption(peerName string) grpc.DialOption {
+	if p == nil {
+		return grpc.WithInsecure()
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return p.getCertificate()
+		},
+		// Server certificate is verified by VerifyPeerCertificate against current CA and peerName instead of
+		// address we dial.
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return p.verify(rawCerts, peerName, x509.ExtKeyUsageServerAuth)
+		},
+	}))
+}
+
+// VerifyPeer - checks gRPC request is received from peer authenticated as name or as trusted proxy, any peer is
+// accepted by nil provider.
+func (p *Provider) VerifyPeer(ctx context.Context, name string) error {
+	if p == nil {
+		return nil
+	}
+	cert, err := peerCertificate(ctx)
+	if err != nil {
+		return err
+	}
+	if !p.isTrusted(cert, name) {
+		return fmt.Errorf("peer %s is neither %s nor trusted proxy", cert.Subject.CommonName, name)
+	}
+	return nil
+}
+
+func peerCertificate(ctx context.Context) (*x509.Certificate, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("peer is unknown")
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil, fmt.Errorf("peer %v is not authenticated", p.Addr)
+	}
+	return tlsInfo.State.PeerCertificates[0], nil
+}
+
+// isTrusted - checks certificate is issued for name or for trusted proxy.
+func (p *Provider) isTrusted(cert *x509.Certificate, name string) bool {
+	if hasIdentity(cert, name) {
+		return true
+	}
+	p.Lock()
+	defer p.Unlock()
+	for _, proxy := range p.trustedProxies {
+		if hasIdentity(cert, proxy) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Provider) getCertificate() (*tls.Certificate, error) {
+	if err := p.reload(); err != nil {
+		// Previous certificate is still valid to use.
+		logrus.Errorf("Failed to reload TLS certificates: %v", err)
+	}
+	p.Lock()
+	defer p.Unlock()
+	return p.certificate, nil
+}
+
+func (p *Provider) verify(rawCerts [][]byte, peerName string, usage x509.ExtKeyUsage) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("no certificate is presented by peer")
+	}
+	if err := p.reload(); err != nil {
+		logrus.Errorf("Failed to reload TLS certificates: %v", err)
+	}
+	p.Lock()
+	roots := p.roots
+	p.Unlock()
+
+	certs := []*x509.Certificate{}
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	}); err != nil {
+		return err
+	}
+	if peerName != "" && !p.isTrusted(certs[0], peerName) {
+		return fmt.Errorf("certificate is not issued for %s", peerName)
+	}
+	return nil
+}
+
+// reload - reads certificate files if they are changed since last read.
+func (p *Provider) reload() error {
+	p.Lock()
+	defer p.Unlock()
+
+	changed := false
+	modTimes := map[string]time.Time{}
+	for _, file := range []string{p.certFile, p.keyFile, p.caFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+		if !info.ModTime().Equal(p.modTimes[file]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(p.certFile, p.keyFile)
+	if err != nil {
+		return err
+	}
+	ca, err := ioutil.ReadFile(p.caFile)
+	if err != nil {
+		return err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("no CA certificates found in %s", p.caFile)
+	}
+	logrus.Infof("TLS certificates are loaded from %s", p.certFile)
+	p.certificate = &certificate
+	p.roots = roots
+	p.modTimes = modTimes
+	return nil
+}
+
+// hasIdentity - checks certificate is issued for name, either as common name or DNS name.
+func hasIdentity(cert *x509.Certificate, name string) bool {
+	if cert.Subject.CommonName == name {
+		return true
+	}
+	for _, dnsName := range cert.DNSNames {
+		if dnsName == name {
+			return true
+		}
+	}
+	return false
+}
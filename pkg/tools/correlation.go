@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+)
+
+// CorrelationIdKey - gRPC metadata key carrying an id of request passed through NSMs, NSEs and Dataplanes,
+// so a single client request could be followed across all their logs.
+const CorrelationIdKey = "nsm-correlation-id"
+
+type correlationIdContextKey struct{}
+
+type logContextKey struct{}
+
+// NewCorrelationId - creates a new random correlation id.
+func NewCorrelationId() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%X", b)
+}
+
+// CorrelationId - returns correlation id attached to ctx or received with gRPC request, empty if there is no one.
+func CorrelationId(ctx context.Context) string {
+	if id, ok := ctx.Value(correlationIdContextKey{}).(string); ok {
+		return id
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(CorrelationIdKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// WithCorrelationId - attaches correlation id to ctx, id is sent with all gRPC calls done with ctx, stamped on
+// opentracing span of ctx and on entries logged with Log(ctx).
+func WithCorrelationId(ctx context.Context, id string) context.Context {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.SetTag(CorrelationIdKey, id)
+	}
+	ctx = context.WithValue(ctx, correlationIdContextKey{}, id)
+	ctx = context.WithValue(ctx, logContextKey{}, logrus.WithField(CorrelationIdKey, id))
+	return metadata.AppendToOutgoingContext(ctx, CorrelationIdKey, id)
+}
+
+// Log - returns logger of ctx, entries logged with it carry correlation id attached to ctx or received with
+// gRPC request.
+func Log(ctx context.Context) *logrus.Entry {
+	if entry, ok := ctx.Value(logContextKey{}).(*logrus.Entry); ok {
+		return entry
+	}
+	if id := CorrelationId(ctx); id != "" {
+		return logrus.WithField(CorrelationIdKey, id)
+	}
+	return logrus.NewEntry(logrus.StandardLogger())
+}
+
+// EnsureCorrelationId - returns ctx with correlation id received with gRPC request attached, a new id is created
+// if request has no one, i.e. we are the first to handle it.
+func EnsureCorrelationId(ctx context.Context) (context.Context, string) {
+	if id, ok := ctx.Value(correlationIdContextKey{}).(string); ok {
+		return ctx, id
+	}
+	id := CorrelationId(ctx)
+	if id == "" {
+		id = NewCorrelationId()
+	}
+	return WithCorrelationId(ctx, id), id
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
@@ -30,6 +31,10 @@ import (
 const (
 	connectRetries = 10
 	connectSleep   = 5 * time.Second
+	// minRefreshDelay - a minimal delay between connection refreshes, used if lease is almost expired.
+	minRefreshDelay = 100 * time.Millisecond
+	// maxRefreshBackoff - a maximal delay between attempts to refresh connection after failures.
+	maxRefreshBackoff = 5 * time.Second
 )
 
 // NsmClient is the NSM client struct
@@ -38,6 +43,7 @@ type NsmClient struct {
 	OutgoingNscName     string
 	OutgoingNscLabels   map[string]string
 	OutgoingConnections []*connection.Connection
+	refreshCancels      map[string]context.CancelFunc
 }
 
 // Connect implements the business logic
@@ -87,6 +93,7 @@ func (nsmc *NsmClient) Connect(name, mechanism, description string) (*connection
 		}
 
 		nsmc.OutgoingConnections = append(nsmc.OutgoingConnections, outgoingConnection)
+		nsmc.startRefresh(outgoingConnection.GetId())
 		logrus.Infof("Received outgoing connection: %v", outgoingConnection)
 		break
 	}
@@ -99,16 +106,20 @@ func (nsmc *NsmClient) Close(outgoingConnection *connection.Connection) error {
 	nsmc.Lock()
 	defer nsmc.Unlock()
 
+	nsmc.stopRefresh(outgoingConnection.GetId())
 	nsmc.NsClient.Close(nsmc.Context, outgoingConnection)
 
 	arr := nsmc.OutgoingConnections
 	for i, c := range arr {
-		if c == outgoingConnection {
+		// Connection could be replaced by refreshed one, so compare by id.
+		if c.GetId() == outgoingConnection.GetId() {
 			copy(arr[i:], arr[i+1:])
 			arr[len(arr)-1] = nil
 			arr = arr[:len(arr)-1]
+			break
 		}
 	}
+	nsmc.OutgoingConnections = arr
 	return nil
 }
 
@@ -118,6 +129,7 @@ func (nsmc *NsmClient) Destroy() error {
 	defer nsmc.Unlock()
 
 	for _, c := range nsmc.OutgoingConnections {
+		nsmc.stopRefresh(c.GetId())
 		nsmc.NsClient.Close(nsmc.Context, c)
 	}
 	nsmc.NsmConnection.Close()
@@ -141,7 +153,111 @@ func NewNSMClient(ctx context.Context, configuration *common.NSConfiguration) (*
 		NsmConnection:     nsmConnection,
 		OutgoingNscName:   configuration.OutgoingNscName,
 		OutgoingNscLabels: tools.ParseKVStringToMap(configuration.OutgoingNscLabels, ",", "="),
+		refreshCancels:    map[string]context.CancelFunc{},
 	}
 
 	return client, nil
 }
+
+// startRefresh - starts a routine refreshing connection lease, should be called with lock held.
+func (nsmc *NsmClient) startRefresh(id string) {
+	if nsmc.refreshCancels == nil {
+		nsmc.refreshCancels = map[string]context.CancelFunc{}
+	}
+	ctx, cancel := context.WithCancel(nsmc.Context)
+	nsmc.refreshCancels[id] = cancel
+	go nsmc.refresh(ctx, id)
+}
+
+// stopRefresh - stops refresh of connection lease, should be called with lock held.
+func (nsmc *NsmClient) stopRefresh(id string) {
+	if cancel, ok := nsmc.refreshCancels[id]; ok {
+		cancel()
+		delete(nsmc.refreshCancels, id)
+	}
+}
+
+func (nsmc *NsmClient) outgoingConnection(id string) (int, *connection.Connection) {
+	for i, c := range nsmc.OutgoingConnections {
+		if c.GetId() == id {
+			return i, c
+		}
+	}
+	return -1, nil
+}
+
+// refresh - sends Request for connection when a third of its lease is left, so NSM will not close it. Failed
+// refreshes are retried with exponential backoff until lease is expired.
+func (nsmc *NsmClient) refresh(ctx context.Context, id string) {
+	backoff := time.Duration(0)
+	for {
+		nsmc.Lock()
+		_, outgoingConnection := nsmc.outgoingConnection(id)
+		nsmc.Unlock()
+		if outgoingConnection == nil || outgoingConnection.GetExpires() == nil {
+			// Connection is closed or has no lease.
+			return
+		}
+		expires, err := ptypes.Timestamp(outgoingConnection.GetExpires())
+		if err != nil {
+			logrus.Errorf("Invalid lease of connection %v: %v", id, err)
+			return
+		}
+		if !time.Now().Before(expires) {
+			logrus.Errorf("Lease of connection %v is expired at %v, connection is not refreshed anymore", id, expires)
+			return
+		}
+		delay := time.Until(expires) / 3
+		if backoff > 0 {
+			delay = backoff
+		}
+		if delay < minRefreshDelay {
+			delay = minRefreshDelay
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		request := &networkservice.NetworkServiceRequest{
+			Connection: outgoingConnection,
+			MechanismPreferences: []*connection.Mechanism{
+				outgoingConnection.GetMechanism(),
+			},
+		}
+		requestCtx, cancel := context.WithTimeout(ctx, connectSleep)
+		refreshed, err := nsmc.NsClient.Request(requestCtx, request)
+		cancel()
+		if err != nil {
+			backoff = nextRefreshBackoff(backoff)
+			logrus.Errorf("Failed to refresh connection %v, retrying in %v: %v", id, backoff, err)
+			continue
+		}
+		backoff = 0
+
+		nsmc.Lock()
+		if i, _ := nsmc.outgoingConnection(id); i >= 0 && ctx.Err() == nil {
+			nsmc.OutgoingConnections[i] = refreshed
+			if refreshed.GetId() != id {
+				// Connection was expired and NSM re-created it with a new id.
+				nsmc.refreshCancels[refreshed.GetId()] = nsmc.refreshCancels[id]
+				delete(nsmc.refreshCancels, id)
+				id = refreshed.GetId()
+			}
+		}
+		nsmc.Unlock()
+		logrus.Infof("Connection %v is refreshed, expires at %v", id, refreshed.GetExpires())
+	}
+}
+
+func nextRefreshBackoff(backoff time.Duration) time.Duration {
+	if backoff < minRefreshDelay {
+		return minRefreshDelay
+	}
+	backoff *= 2
+	if backoff > maxRefreshBackoff {
+		return maxRefreshBackoff
+	}
+	return backoff
+}
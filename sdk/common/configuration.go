@@ -29,6 +29,10 @@ const (
 	tracerEnabled         = "TRACER_ENABLED"
 	mechanismTypeEnv      = "MECHANISM_TYPE"
 	ipAddressEnv          = "IP_ADDRESS"
+	ipamStateFileEnv      = "IPAM_STATE_FILE"
+	ipamSharedEnv         = "IPAM_SHARED"
+	ipamIpv4PrefixLenEnv  = "IPAM_LEASE_IPV4_PREFIX_LEN"
+	ipamIpv6PrefixLenEnv  = "IPAM_LEASE_IPV6_PREFIX_LEN"
 )
 
 // NSConfiguration contains the full configuration used in the SDK
@@ -42,7 +46,16 @@ type NSConfiguration struct {
 	OutgoingNscLabels  string
 	TracerEnabled      bool
 	MechanismType      string
-	IPAddress          string
+	// IPAddress - comma separated networks of IPAM prefix pool, IPv4 and IPv6 networks make connections dual-stack.
+	IPAddress string
+	// IPAMStateFile - file IPAM persists allocations to, so endpoint keeps them across restarts.
+	IPAMStateFile string
+	// IPAMShared - IPAM leases sub-prefixes of IPAddress through registry, so replicas of endpoint do not overlap.
+	IPAMShared bool
+	// IPAMLeaseIpv4PrefixLen - length of IPv4 sub-prefix leased by shared IPAM, default length is used if it is zero.
+	IPAMLeaseIpv4PrefixLen uint32
+	// IPAMLeaseIpv6PrefixLen - length of IPv6 sub-prefix leased by shared IPAM, default length is used if it is zero.
+	IPAMLeaseIpv6PrefixLen uint32
 }
 
 // CompleteNSConfiguration fills all unset options from the env variables
@@ -85,4 +98,22 @@ func (configuration *NSConfiguration) CompleteNSConfiguration() {
 	if len(configuration.IPAddress) == 0 {
 		configuration.IPAddress = getEnv(ipAddressEnv, "IP Address", false)
 	}
+
+	if len(configuration.IPAMStateFile) == 0 {
+		configuration.IPAMStateFile = getEnv(ipamStateFileEnv, "IPAM state file", false)
+	}
+
+	if !configuration.IPAMShared {
+		configuration.IPAMShared, _ = strconv.ParseBool(getEnv(ipamSharedEnv, "IPAM shared", false))
+	}
+
+	if configuration.IPAMLeaseIpv4PrefixLen == 0 {
+		prefixLen, _ := strconv.ParseUint(getEnv(ipamIpv4PrefixLenEnv, "IPAM lease IPv4 prefix length", false), 10, 32)
+		configuration.IPAMLeaseIpv4PrefixLen = uint32(prefixLen)
+	}
+
+	if configuration.IPAMLeaseIpv6PrefixLen == 0 {
+		prefixLen, _ := strconv.ParseUint(getEnv(ipamIpv6PrefixLenEnv, "IPAM lease IPv6 prefix length", false), 10, 32)
+		configuration.IPAMLeaseIpv6PrefixLen = uint32(prefixLen)
+	}
 }
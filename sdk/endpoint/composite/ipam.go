@@ -18,15 +18,20 @@ package composite
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"net"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/connectioncontext"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/local/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/apis/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/prefix_pool"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/endpoint"
 	"github.com/sirupsen/logrus"
@@ -34,7 +39,10 @@ import (
 
 type IpamCompositeEndpoint struct {
 	endpoint.BaseCompositeEndpoint
+	sync.Mutex
 	prefixPool prefix_pool.PrefixPool
+	// newPrefixPool - creates shared prefix pool on first request, NSM leases its sub-prefixes to registered endpoint only.
+	newPrefixPool func() (prefix_pool.PrefixPool, error)
 }
 
 // Request imeplements the request handler
@@ -46,29 +54,63 @@ func (ice *IpamCompositeEndpoint) Request(ctx context.Context, request *networks
 		return nil, err
 	}
 
-	newConnection, err := ice.GetNext().Request(ctx, request)
+	// Extra prefix requests are checked before connection is requested from next endpoint.
+	extraPrefixRequests := request.GetConnection().GetContext().GetExtraPrefixRequest()
+	for _, extraPrefixRequest := range extraPrefixRequests {
+		if err := extraPrefixRequest.IsValid(); err != nil {
+			logrus.Errorf("Invalid extra prefix request: %v", err)
+			return nil, err
+		}
+	}
+
+	prefixPool, err := ice.getPrefixPool()
 	if err != nil {
-		logrus.Errorf("Next request failed: %v", err)
+		logrus.Errorf("IPAM prefix pool is not available: %v", err)
 		return nil, err
 	}
 
-	//TODO: We need to somehow support IPv6.
-	srcIP, dstIP, prefixes, err := ice.prefixPool.Extract(request.Connection.Id, connectioncontext.IpFamily_IPV4, request.Connection.Context.ExtraPrefixRequest...)
+	newConnection, err := ice.GetNext().Request(ctx, request)
 	if err != nil {
+		logrus.Errorf("Next request failed: %v", err)
 		return nil, err
 	}
 
-	// Update source/dst IP's
-	newConnection.Context.SrcIpAddr = srcIP.String()
-	newConnection.Context.DstIpAddr = dstIP.String()
+	// Connection gets address pair of every family pool has, first pair is primary one.
+	families := prefixPool.Families()
+	if len(families) == 0 {
+		return nil, fmt.Errorf("IPAM prefix pool is empty")
+	}
+	newConnection.Context.ExtraPrefixes = nil
+	for idx, family := range families {
+		var familyExtraPrefixRequests []*connectioncontext.ExtraPrefixRequest
+		if idx == 0 {
+			familyExtraPrefixRequests = extraPrefixRequests
+		}
+		srcIP, dstIP, prefixes, err := prefixPool.Extract(request.Connection.Id, family, familyExtraPrefixRequests...)
+		if err != nil {
+			_ = prefixPool.Release(request.Connection.Id)
+			return nil, err
+		}
+		newConnection.Context.ExtraPrefixes = append(newConnection.Context.ExtraPrefixes, prefixes...)
+
+		// Update source/dst IP's
+		if idx == 0 {
+			newConnection.Context.SrcIpAddr = srcIP.String()
+			newConnection.Context.DstIpAddr = dstIP.String()
+		} else {
+			newConnection.Context.SrcIpv6Addr = srcIP.String()
+			newConnection.Context.DstIpv6Addr = dstIP.String()
+		}
 
-	//Add extra routes.
-	newConnection.Context.Routes = []*connectioncontext.Route{
-		&connectioncontext.Route{
-			Prefix: "8.8.8.8/30",
-		},
+		//Add extra routes.
+		if family == connectioncontext.IpFamily_IPV4 {
+			newConnection.Context.Routes = []*connectioncontext.Route{
+				&connectioncontext.Route{
+					Prefix: "8.8.8.8/30",
+				},
+			}
+		}
 	}
-	newConnection.Context.ExtraPrefixes = prefixes
 
 	addrs, err := net.Interfaces()
 	if err == nil {
@@ -103,37 +145,98 @@ func (ice *IpamCompositeEndpoint) Request(ctx context.Context, request *networks
 
 // Close imeplements the close handler
 func (ice *IpamCompositeEndpoint) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
-	prefix, requests, err := ice.prefixPool.GetConnectionInformation(connection.GetId())
-	logrus.Infof("Release connection prefixes network: %s extra requests: %v", prefix, requests)
-	if err != nil {
-		logrus.Errorf("Error: %v", err)
+	if prefixPool, err := ice.getPrefixPool(); err == nil {
+		prefix, requests, err := prefixPool.GetConnectionInformation(connection.GetId())
+		logrus.Infof("Release connection prefixes network: %s extra requests: %v", prefix, requests)
+		if err != nil {
+			logrus.Errorf("Error: %v", err)
+		}
+		_ = prefixPool.Release(connection.GetId())
+	} else {
+		logrus.Errorf("IPAM prefix pool is not available: %v", err)
 	}
-	err = ice.prefixPool.Release(connection.GetId())
 	if ice.GetNext() != nil {
 		return ice.GetNext().Close(ctx, connection)
 	}
 	return &empty.Empty{}, nil
 }
 
+// Cleanup - releases sub-prefixes leased by prefix pool, if it is shared.
+func (ice *IpamCompositeEndpoint) Cleanup() error {
+	ice.Lock()
+	defer ice.Unlock()
+	if closer, ok := ice.prefixPool.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (ice *IpamCompositeEndpoint) getPrefixPool() (prefix_pool.PrefixPool, error) {
+	ice.Lock()
+	defer ice.Unlock()
+	if ice.prefixPool == nil {
+		pool, err := ice.newPrefixPool()
+		if err != nil {
+			return nil, err
+		}
+		ice.prefixPool = pool
+	}
+	return ice.prefixPool, nil
+}
+
 // NewIpamCompositeEndpoint creates a IpamCompositeEndpoint
-func NewIpamCompositeEndpoint(configuration *common.NSConfiguration) *IpamCompositeEndpoint {
+func NewIpamCompositeEndpoint(configuration *common.NSConfiguration) (*IpamCompositeEndpoint, error) {
 	// ensure the env variables are processed
 	if configuration == nil {
 		configuration = &common.NSConfiguration{}
 	}
 	configuration.CompleteNSConfiguration()
 
-	pool, err := prefix_pool.NewPrefixPool(configuration.IPAddress)
-	if err != nil {
-		panic(err.Error())
+	prefixes := []string{}
+	for _, prefix := range strings.Split(configuration.IPAddress, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	self := &IpamCompositeEndpoint{
+		newPrefixPool: func() (prefix_pool.PrefixPool, error) {
+			return newPrefixPool(configuration, prefixes)
+		},
+	}
+	// Sub-prefixes of shared pool are leased once endpoint is registered, so it is created on first request.
+	if !configuration.IPAMShared {
+		pool, err := self.newPrefixPool()
+		if err != nil {
+			return nil, err
+		}
+		self.prefixPool = pool
 	}
 
 	rand.Seed(time.Now().UTC().UnixNano())
 
-	self := &IpamCompositeEndpoint{
-		prefixPool: pool,
-	}
 	self.SetSelf(self)
 
-	return self
+	return self, nil
+}
+
+// newPrefixPool - creates prefix pool persisting allocations to IPAMStateFile if it is set, sub-prefixes of pool are
+// leased through registry if IPAMShared is set.
+func newPrefixPool(configuration *common.NSConfiguration, prefixes []string) (prefix_pool.PrefixPool, error) {
+	newPool := prefix_pool.NewPrefixPool
+	if configuration.IPAMStateFile != "" {
+		newPool = func(prefixes ...string) (prefix_pool.PrefixPool, error) {
+			return prefix_pool.NewFilePrefixPool(configuration.IPAMStateFile, prefixes...)
+		}
+	}
+	if !configuration.IPAMShared {
+		return newPool(prefixes...)
+	}
+
+	conn, err := tools.SocketOperationCheck(configuration.NsmServerSocket)
+	if err != nil {
+		return nil, fmt.Errorf("failure to communicate with the nsm on socket %s: %v", configuration.NsmServerSocket, err)
+	}
+	// NSM binds lease to endpoint registered through it, so owner is not set.
+	return prefix_pool.NewLeasedPrefixPool(registry.NewPrefixLeaseRegistryClient(conn), configuration.AdvertiseNseName, "",
+		configuration.IPAMLeaseIpv4PrefixLen, configuration.IPAMLeaseIpv6PrefixLen, newPool, prefixes...)
 }
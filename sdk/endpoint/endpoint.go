@@ -29,12 +29,15 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 type nsmEndpoint struct {
 	*common.NsmConnection
 	composite      CompositeEndpoint
 	grpcServer     *grpc.Server
+	healthServer   *health.Server
 	registryClient registry.NetworkServiceRegistryClient
 	endpointName   string
 	tracerCloser   io.Closer
@@ -81,6 +84,9 @@ func (nsme *nsmEndpoint) Start() error {
 
 	nsme.grpcServer = grpc.NewServer(grpcOptions...)
 	networkservice.RegisterNetworkServiceServer(nsme.grpcServer, nsme)
+	// NSM checks endpoint health to detect it is dead before its connections are broken.
+	nsme.healthServer = health.NewServer()
+	grpc_health_v1.RegisterHealthServer(nsme.grpcServer, nsme.healthServer)
 
 	listener, err := nsme.setupNSEServerConnection()
 
@@ -123,6 +129,21 @@ func (nsme *nsmEndpoint) Delete() error {
 	if nsme.Configuration.TracerEnabled {
 		nsme.tracerCloser.Close()
 	}
+	// NSM migrates existing connections to other endpoints before endpoint is removed.
+	drainNSE := &registry.DrainNSERequest{
+		EndpointName: nsme.endpointName,
+	}
+	if _, err := nsme.registryClient.DrainNSE(context.Background(), drainNSE); err != nil {
+		logrus.Errorf("Failed draining NSE: %v, with %v", drainNSE, err)
+	}
+	// Composites are cleaned up while endpoint is still registered, NSM releases prefixes leased to it only.
+	for composite := nsme.composite; composite != nil; composite = composite.GetNext() {
+		if cleaner, ok := composite.(CompositeEndpointCleaner); ok {
+			if cleanupErr := cleaner.Cleanup(); cleanupErr != nil {
+				logrus.Errorf("Failed cleanup of composite: %v", cleanupErr)
+			}
+		}
+	}
 	// prepare and defer removing of the advertised endpoint
 	removeNSE := &registry.RemoveNSERequest{
 		EndpointName: nsme.endpointName,
@@ -131,6 +152,7 @@ func (nsme *nsmEndpoint) Delete() error {
 	if err != nil {
 		logrus.Errorf("Failed removing NSE: %v, with %v", removeNSE, err)
 	}
+	nsme.healthServer.Shutdown()
 	nsme.grpcServer.Stop()
 
 	return err
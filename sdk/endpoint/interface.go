@@ -34,6 +34,11 @@ type CompositeEndpoint interface {
 	GetOpaque(interface{}) interface{}
 }
 
+// CompositeEndpointCleaner is a composite holding resources, they are released when endpoint is deleted
+type CompositeEndpointCleaner interface {
+	Cleanup() error
+}
+
 // BaseCompositeEndpoint is the base service compostion struct
 type BaseCompositeEndpoint struct {
 	self CompositeEndpoint